@@ -123,6 +123,14 @@ const (
 	REQUIRES                                    // Used for instances of [RequiresNode] representing requires directives in a module declaration.
 	USES                                        // Used for instances of [UsesNode] representing uses directives in a module declaration.
 	YIELD                                       // Used for instances of [YieldNode].
+	ENUM_CONSTANT                               // Used for instances of [EnumConstantNode].
+	RECORD_PATTERN                              // Used for instances of [RecordPatternNode].
+	CONSTANT_CASE_LABEL                         // Used for instances of [ConstantCaseLabelNode].
+	PATTERN_CASE_LABEL                          // Used for instances of [PatternCaseLabelNode].
+	VARIABLE_DECLARATION                        // Used for instances of [VariableDeclarationNode].
+	CLASS_LITERAL                               // Used for instances of [ClassLiteralNode].
+	THIS                                        // Used for instances of [ThisNode].
+	SUPER                                       // Used for instances of [SuperNode].
 	OTHER                                       // An implementation-reserved node. This is the not the node you are looking for.
 )
 
@@ -146,6 +154,18 @@ const (
 	NEW_REFERENCE_MODE                         // Enum constant for constructor references.
 )
 
+// The context of a variable declaration, which determines how it is terminated:
+// field and local variable declarations end with ";", and the others are terminated by their enclosing node.
+type DeclarationContext int
+
+const (
+	FIELD_CONTEXT          DeclarationContext = iota // A field declaration, such as "int a, b;".
+	LOCAL_VARIABLE_CONTEXT                           // A local variable declaration statement, such as "int a, b;".
+	FOR_INIT_CONTEXT                                 // The initializer of a "for" loop, such as "int i = 0, j = 0".
+	RESOURCE_CONTEXT                                 // A resource of a "try" statement, such as "Reader r = open()".
+	PARAMETER_CONTEXT                                // A formal parameter, such as "String... args".
+)
+
 // The syntactic form of this case:
 // STATEMENT: "case <expression>: <statements>"
 // RULE: "case <expression> -> <expression>/<statement>"
@@ -216,6 +236,9 @@ const (
 type Node interface {
 	io.WriterTo
 	GetKind() Kind
+	GetPos() Pos            // Returns the position of the first character of this node, or [NoPos] if it is unknown.
+	GetEnd() Pos            // Returns the position immediately after the last character of this node, or [NoPos] if it is unknown.
+	Accept(visitor Visitor) // Calls the method of the visitor for the interface implemented by this node.
 }
 
 // A tree node for an array type.
@@ -240,6 +263,20 @@ type DefaultCaseLabelNode interface {
 	defaultCaseLabelNode() // defaultCaseLabelNode() ensures that only default case label nodes can be assigned to a DefaultCaseLabelNode.
 }
 
+// A case label that marks "case constantExpression".
+type ConstantCaseLabelNode interface {
+	CaseLabelNode
+	GetConstantExpression() ExpressionNode // Returns the constant expression of the label.
+	constantCaseLabelNode()                // constantCaseLabelNode() ensures that only constant case label nodes can be assigned to a ConstantCaseLabelNode.
+}
+
+// A case label that marks "case pattern".
+type PatternCaseLabelNode interface {
+	CaseLabelNode
+	GetPattern() PatternNode // Returns the pattern of the label.
+	patternCaseLabelNode()   // patternCaseLabelNode() ensures that only pattern case label nodes can be assigned to a PatternCaseLabelNode.
+}
+
 // A tree node used as the base class for the different types of expressions.
 type ExpressionNode interface {
 	Node
@@ -307,6 +344,18 @@ type BinaryNode interface {
 	binaryNode()                     // binaryNode() ensures that only binary nodes can be assigned to a BinaryNode.
 }
 
+// A tree node for a class literal.
+// For example:
+//
+//	type . class
+//
+//	void . class
+type ClassLiteralNode interface {
+	ExpressionNode
+	GetType() Node     // Returns the type of the class literal: a primitive type, "void", a class type or an array type.
+	classLiteralNode() // classLiteralNode() ensures that only class literal nodes can be assigned to a ClassLiteralNode.
+}
+
 // A tree node for compound assignment operator.
 // Use [Node].GetKind to determine the kind of operator.
 // For example:
@@ -417,6 +466,10 @@ type MemberSelectNode interface {
 //	identifier ( arguments )
 //
 //	this . typeArguments identifier ( arguments )
+//
+//	this ( arguments )
+//
+//	expression . super ( arguments )
 type MethodInvocationNode interface {
 	ExpressionNode
 	GetTypeArguments() []Node        // Returns the type arguments for this method invocation.
@@ -431,6 +484,8 @@ type MethodInvocationNode interface {
 //	new type dimensions initializers
 //
 //	new type dimensions [ ] initializers
+//
+//	{ initializers }
 type NewArrayNode interface {
 	ExpressionNode
 	GetType() Node                         // Returns the base type of the expression. May be nil for an array initializer expression.
@@ -473,6 +528,19 @@ type ParenthesizedNode interface {
 	parenthesizedNode()            // parenthesizedNode() ensures that only parenthesized nodes can be assigned to a ParenthesizedNode.
 }
 
+// A tree node for the keyword "super", which selects a member of a superclass or a superinterface,
+// or invokes a superclass constructor as the method select of a [MethodInvocationNode].
+// For example:
+//
+//	super
+//
+//	qualifier . super
+type SuperNode interface {
+	ExpressionNode
+	GetQualifier() ExpressionNode // Returns the name of the enclosing class or of the superinterface, or the outer instance of a superclass constructor invocation. Returns nil if "super" is not qualified.
+	superNode()                   // superNode() ensures that only super nodes can be assigned to a SuperNode.
+}
+
 // A tree node for a "switch" expression.
 // For example:
 //
@@ -486,6 +554,19 @@ type SwitchExpressionNode interface {
 	switchExpressionNode()         // switchExpressionNode() ensures that only switch expression nodes can be assigned to a SwitchExpressionNode.
 }
 
+// A tree node for the keyword "this", which refers to the current or an enclosing instance,
+// or invokes another constructor of the class as the method select of a [MethodInvocationNode].
+// For example:
+//
+//	this
+//
+//	qualifier . this
+type ThisNode interface {
+	ExpressionNode
+	GetQualifier() ExpressionNode // Returns the name of the enclosing class, or nil if "this" is not qualified.
+	thisNode()                    // thisNode() ensures that only this nodes can be assigned to a ThisNode.
+}
+
 // A tree node for a type cast expression.
 // For example:
 //
@@ -518,13 +599,20 @@ type PatternNode interface {
 }
 
 // A tree node for a binding pattern.
+// The type of the variable is the identifier "var" if it is inferred.
+// For example:
+//
+//	type name
+//
+//	var name
 type BindingPatternNode interface {
 	PatternNode
 	GetVariable() VariableNode // Returns the binding variable.
 	bindingPatternNode()       // bindingPatternNode() ensures that only binding pattern nodes can be assigned to a BindingPatternNode.
 }
 
-// A tree node for a guard pattern.
+// A tree node for a guard pattern, as in the preview of pattern matching for switch.
+// The parser represents guards with [CaseNode.GetGuard] instead.
 type GuardedPatternNode interface {
 	PatternNode
 	GetPattern() PatternNode       // The guarded pattern expression.
@@ -542,12 +630,25 @@ type ParenthesizedPatternNode interface {
 	parenthesizedPatternNode() // parenthesizedPatternNode() ensures that only parenthesized pattern nodes can be assigned to a ParenthesizedPatternNode.
 }
 
+// A tree node for a record pattern.
+// For example:
+//
+//	deconstructor ( nestedPatterns )
+type RecordPatternNode interface {
+	PatternNode
+	GetDeconstructor() ExpressionNode // Returns the record type being deconstructed.
+	GetNestedPatterns() []PatternNode // Returns the patterns matching the components of the record.
+	recordPatternNode()               // recordPatternNode() ensures that only record pattern nodes can be assigned to a RecordPatternNode.
+}
+
 // A tree node for a "case" in a "switch" statement or expression.
 // For example:
 //
 //	case expression :
 //	    statements
 //
+//	case labels when guard -> body
+//
 //	default :
 //	    statements
 type CaseNode interface {
@@ -556,6 +657,7 @@ type CaseNode interface {
 	GetLabels() []CaseLabelNode       // Returns the labels for this case. For "default" case return a list with a single element, [DefaultCaseLabelNode].
 	GetStatements() []StatementNode   // For case with kind [STATEMENT_CASE_KIND], returns the statements labeled by the case. Returns nil for case with kind [RULE_CASE_KIND].
 	GetBody() Node                    // For case with kind [RULE_CASE_KIND], returns the statement or expression after the arrow. Returns nil for case with kind [STATEMENT_CASE_KIND].
+	GetGuard() ExpressionNode         // Returns the guard following "when", or nil if there is none.
 	GetCaseKind() CaseKind            // Returns the kind of this case.
 	caseNode()                        // caseNode() ensures that only case nodes can be assigned to a CaseNode.
 }
@@ -678,6 +780,9 @@ type IntersectionTypeNode interface {
 //	    body
 //
 //	modifiers type name () default defaultValue
+//
+//	modifiers name
+//	    body
 type MethodNode interface {
 	Node
 	GetModifiers() ModifiersNode            // Returns the modifiers, including any annotations for the method being declared.
@@ -689,6 +794,7 @@ type MethodNode interface {
 	GetThrows() []ExpressionNode            // Returns the exceptions listed as being thrown by this method.
 	GetBody() BlockNode                     // Returns the method body, or nil if this is an abstract or native method.
 	GetDefaultValue() Node                  // Returns the default value, if this is an element within an annotation type declaration. Returns nil otherwise.
+	IsCompact() bool                        // Returns true if this is a compact canonical constructor of a record, which has no parameter list.
 	methodNode()                            // methodNode() ensures that only method nodes can be assigned to a MethodNode.
 }
 
@@ -802,6 +908,7 @@ type BreakNode interface {
 //	modifiers class simpleName typeParameters
 //	    extends extendsClause
 //	    implements implementsClause
+//	    permits permitsClause
 //	{
 //	    members
 //	}
@@ -810,9 +917,11 @@ type ClassNode interface {
 	GetModifiers() ModifiersNode            // Returns the modifiers, including any annotations, for this type declaration.
 	GetSimpleName() string                  // Returns the simple name of this type declaration.
 	GetTypeParameters() []TypeParameterNode // Returns any type parameters of this type declaration.
-	GetExtendsClause() Node                 // Returns the supertype of this type declaration, or nil if none is provided.
-	GetImplementsClause() []Node            // Returns the interfaces implemented by this type declaration.
+	GetExtendsClause() Node                 // Returns the superclass of this class, or nil if none is provided.
+	GetImplementsClause() []Node            // Returns the interfaces implemented by this type declaration, or extended by it if it is an interface.
 	GetPermitsClause() []Node               // Returns the subclasses permitted by this type declaration.
+	GetEnumConstants() []EnumConstantNode   // Returns the constants declared in this type declaration, if it is an enum.
+	GetRecordComponents() []VariableNode    // Returns the components declared in the header of this type declaration, if it is a record.
 	GetMembers() []Node                     // Returns the members declared in this type declaration.
 	classNode()                             // classNode() ensures that only class nodes can be assigned to a ClassNode.
 }
@@ -887,11 +996,12 @@ type ExpressionStatementNode interface {
 //	    statement
 type ForLoopNode interface {
 	StatementNode
-	GetInitializer() []VariableNode // Returns any initializers of the "for" statement. The result will be an empty list if there are no initializers.
-	GetCondition() ExpressionNode   // Returns the condition of the "for" statement. May be nil if there is no condition.
-	GetUpdate() []ExpressionNode    // Returns any update expressions of the "for" statement.
-	GetStatement() StatementNode    // Returns the body of the "for" statement.
-	forLoopNode()                   // forLoopNode() ensures that only for-loop nodes can be assigned to a ForLoopNode.
+	GetInitializer() []VariableNode   // Returns any initializers of the "for" statement. The result will be an empty list if there are no initializers.
+	GetExpressions() []ExpressionNode // Returns the initializer expressions of the "for" statement, if it declares no variables.
+	GetCondition() ExpressionNode     // Returns the condition of the "for" statement. May be nil if there is no condition.
+	GetUpdate() []ExpressionNode      // Returns any update expressions of the "for" statement.
+	GetStatement() StatementNode      // Returns the body of the "for" statement.
+	forLoopNode()                     // forLoopNode() ensures that only for-loop nodes can be assigned to a ForLoopNode.
 }
 
 // A tree node for an "if" statement.
@@ -987,11 +1097,27 @@ type TryNode interface {
 	tryNode()                   // tryNode() ensures that only try nodes can be assigned to a TryNode.
 }
 
+// A tree node for a declaration of one or more variables, which share its modifiers and type.
+// A variable may have more array dimensions than the type of the declaration, which follow its name.
+// For example:
+//
+//	modifiers type name initializer , name [] initializer ;
+type VariableDeclarationNode interface {
+	StatementNode
+	GetContext() DeclarationContext // Returns the context of the declaration, which determines its terminator.
+	GetModifiers() ModifiersNode    // Returns the modifiers, including any annotations, of the declaration.
+	GetType() Node                  // Returns the type of the declaration, without the dimensions which follow the names of variables.
+	GetVariables() []VariableNode   // Returns the variables being declared.
+	variableDeclarationNode()       // variableDeclarationNode() ensures that only variable declaration nodes can be assigned to a VariableDeclarationNode.
+}
+
 // A tree node for a variable declaration.
 // For example:
 //
 //	modifiers type name initializer ;
 //
+//	modifiers type ... name
+//
 //	modifiers type qualified-name.this
 type VariableNode interface {
 	StatementNode
@@ -1000,6 +1126,7 @@ type VariableNode interface {
 	GetNameExpression() ExpressionNode // Returns the qualified identifier for the name being "declared". This is only used in certain cases for the receiver of a method declaration. Returns nil in all other cases.
 	GetType() Node                     // Returns the type of the variable being declared.
 	GetInitializer() ExpressionNode    // Returns the initializer for the variable, or nil if none.
+	IsVarargs() bool                   // Returns true if this is a variable arity parameter, whose array type is written as its element type followed by "...".
 	variableNode()                     // variableNode() ensures that only variable nodes can be assigned to a VariableNode.
 }
 
@@ -1062,3 +1189,20 @@ type WildcardNode interface {
 	GetBound() Node // Returns the bound of the wildcard.
 	wildcardNode()  // wildcardNode() ensures that only wildcard nodes can be assigned to a WildcardNode.
 }
+
+// A tree node for an enum constant.
+// For example:
+//
+//	annotations name
+//
+//	annotations name ( arguments )
+//
+//	annotations name ( arguments ) classBody
+type EnumConstantNode interface {
+	Node
+	GetAnnotations() []AnnotationNode // Returns the annotations of the enum constant.
+	GetName() string                  // Returns the name of the enum constant.
+	GetArguments() []ExpressionNode   // Returns the arguments of the enum constant, or nil if there is no argument list.
+	GetClassBody() ClassNode          // Returns the class body of the enum constant, or nil if there is none.
+	enumConstantNode()                // enumConstantNode() ensures that only enum constant nodes can be assigned to an EnumConstantNode.
+}
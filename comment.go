@@ -0,0 +1,240 @@
+package javast
+
+import (
+	"io"
+	"strings"
+)
+
+// A Comment is a "//" or "/* */" comment of the source.
+type Comment struct {
+	Span
+	Text string // The text of the comment, including the delimiters.
+}
+
+// Reports whether the comment is a "//" comment, which extends to the end of the line.
+func (c Comment) IsLineComment() bool { return strings.HasPrefix(c.Text, "//") }
+
+// Comments are the comments surrounding a declaration or a statement.
+// It is embedded in the nodes of declarations and statements.
+//
+// For example:
+//
+//	// Leading.
+//	/**
+//	 * Doc.
+//	 */
+//	void run() {} // Trailing.
+type Comments struct {
+	Leading  []Comment   // The comments preceding the node, before its documentation comment.
+	Doc      *DocComment // The documentation comment of the node, or nil if there is none.
+	Trailing []Comment   // The comments following the node on the same line.
+}
+
+// Returns the comments surrounding the node.
+func (c Comments) GetComments() Comments { return c }
+
+// A CommentedNode is a node which carries the comments surrounding it.
+type CommentedNode interface {
+	Node
+	GetComments() Comments // Returns the comments surrounding this node.
+}
+
+// A CommentWriter is a writer which lays out comments itself.
+// Nodes write their comments with [CommentWriter.WriteComment] if the writer implements it.
+// Otherwise, they write comments with Write, and "//" comments are followed by a line terminator,
+// so that they do not swallow the following tokens.
+type CommentWriter interface {
+	io.Writer
+	WriteComment(p []byte, trailing bool) (int, error) // Writes a comment, which precedes or follows a node.
+}
+
+// A DocComment is a "/** */" documentation comment.
+// For example:
+//
+//	/**
+//	 * description
+//	 *
+//	 * @param name text
+//	 * @return text
+//	 */
+type DocComment struct {
+	Span
+	Description string   // The main description, with lines separated by "\n". Inline tags such as {@code x} are kept as written.
+	Tags        []DocTag // The block tags following the main description.
+}
+
+// A DocTag is a block tag of a [DocComment], such as "@param name text".
+type DocTag struct {
+	Name     string // The name of the tag without "@", such as "param", "return" or "throws".
+	Argument string // The parameter name of "param" and the exception type of "throws" and "exception", or empty for other tags.
+	Text     string // The description of the tag, with lines separated by "\n" and without their indentation.
+}
+
+// Tags whose first word is an argument rather than a part of the description.
+var docTagsWithArgument = map[string]bool{
+	"param":     true,
+	"throws":    true,
+	"exception": true,
+}
+
+// Parses the text of a "/** */" comment, including the delimiters, into a [DocComment].
+// Leading whitespace and asterisks are removed from each line.
+func ParseDocComment(text string) DocComment {
+	text = strings.TrimPrefix(text, "/**")
+	text = strings.TrimSuffix(text, "*/")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimLeft(line, " \t\f")
+		if strings.HasPrefix(line, "*") {
+			line = strings.TrimPrefix(line[1:], " ")
+		}
+		lines = append(lines, strings.TrimRight(line, " \t\f"))
+	}
+	dc := DocComment{}
+	var description []string
+	var tag *DocTag
+	var tagLines []string
+	endTag := func() {
+		if tag != nil {
+			tag.Text = joinLines(tagLines)
+			dc.Tags = append(dc.Tags, *tag)
+		}
+	}
+	for _, line := range lines {
+		if strings.HasPrefix(line, "@") {
+			endTag()
+			name, rest, _ := strings.Cut(line[1:], " ")
+			tag = &DocTag{Name: name}
+			rest = strings.TrimLeft(rest, " \t")
+			if docTagsWithArgument[name] {
+				tag.Argument, rest, _ = strings.Cut(rest, " ")
+				rest = strings.TrimLeft(rest, " \t")
+			}
+			tagLines = []string{rest}
+			continue
+		}
+		if tag != nil {
+			tagLines = append(tagLines, strings.TrimLeft(line, " \t"))
+		} else {
+			description = append(description, line)
+		}
+	}
+	endTag()
+	dc.Description = joinLines(description)
+	return dc
+}
+
+// Joins the lines with "\n", without leading and trailing blank lines.
+func joinLines(lines []string) string {
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Returns the text of the documentation comment, including the delimiters.
+// A comment with a single line of description and no tags is written on one line.
+func (dc DocComment) String() string {
+	if len(dc.Tags) == 0 && !strings.Contains(dc.Description, "\n") {
+		if dc.Description == "" {
+			return "/** */"
+		}
+		return "/** " + dc.Description + " */"
+	}
+	var sb strings.Builder
+	sb.WriteString("/**\n")
+	line := func(s string) {
+		if s == "" {
+			sb.WriteString(" *\n")
+		} else {
+			sb.WriteString(" * " + s + "\n")
+		}
+	}
+	if dc.Description != "" {
+		for _, s := range strings.Split(dc.Description, "\n") {
+			line(s)
+		}
+		if len(dc.Tags) > 0 {
+			line("")
+		}
+	}
+	for _, tag := range dc.Tags {
+		head := "@" + tag.Name
+		if tag.Argument != "" {
+			head += " " + tag.Argument
+		}
+		texts := strings.Split(tag.Text, "\n")
+		if texts[0] != "" {
+			head += " " + texts[0]
+		}
+		line(head)
+		for _, s := range texts[1:] {
+			line(s)
+		}
+	}
+	sb.WriteString(" */")
+	return sb.String()
+}
+
+// Writes a comment preceding or following a node.
+func writeComment(w io.Writer, text string, trailing bool) (int, error) {
+	if cw, ok := w.(CommentWriter); ok {
+		return cw.WriteComment([]byte(text), trailing)
+	}
+	if strings.HasPrefix(text, "//") {
+		text += "\n"
+	}
+	return w.Write([]byte(text))
+}
+
+// Writes the leading comments followed by the documentation comment.
+func (c Comments) writeLeading(w io.Writer) (n int64, err error) {
+	for _, comment := range c.Leading {
+		if cn, cerr := writeComment(w, comment.Text, false); cerr != nil {
+			err = cerr
+			return
+		} else {
+			n += int64(cn)
+		}
+	}
+	if c.Doc != nil {
+		if dn, derr := writeComment(w, c.Doc.String(), false); derr != nil {
+			err = derr
+			return
+		} else {
+			n += int64(dn)
+		}
+	}
+	return
+}
+
+// Writes the trailing comments.
+func (c Comments) writeTrailing(w io.Writer) (n int64, err error) {
+	for _, comment := range c.Trailing {
+		if cn, cerr := writeComment(w, comment.Text, true); cerr != nil {
+			err = cerr
+			return
+		} else {
+			n += int64(cn)
+		}
+	}
+	return
+}
+
+// Writes the dangling comments of a body, which precede its closing brace.
+func writeDangling(w io.Writer, comments []Comment) (n int64, err error) {
+	for _, comment := range comments {
+		if cn, cerr := writeComment(w, comment.Text, false); cerr != nil {
+			err = cerr
+			return
+		} else {
+			n += int64(cn)
+		}
+	}
+	return
+}
@@ -0,0 +1,55 @@
+package javast_test
+
+import (
+	"testing"
+
+	"github.com/kapavkin/javast"
+)
+
+func TestParseDocComment(t *testing.T) {
+	t.Parallel()
+	dc := javast.ParseDocComment(`/**
+     * Adds to the count.
+     * Uses {@code +}.
+     *
+     * @param n the amount,
+     *        never negative
+     * @return the new count
+     * @throws IllegalStateException if closed
+     */`)
+	if got, want := dc.Description, "Adds to the count.\nUses {@code +}."; got != want {
+		t.Errorf("dc.Description = %q, want %q", got, want)
+	}
+	want := []javast.DocTag{
+		{Name: "param", Argument: "n", Text: "the amount,\nnever negative"},
+		{Name: "return", Text: "the new count"},
+		{Name: "throws", Argument: "IllegalStateException", Text: "if closed"},
+	}
+	if len(dc.Tags) != len(want) {
+		t.Fatalf("dc.Tags = %v, want %v", dc.Tags, want)
+	}
+	for i := range want {
+		if dc.Tags[i] != want[i] {
+			t.Errorf("dc.Tags[%d] = %v, want %v", i, dc.Tags[i], want[i])
+		}
+	}
+}
+
+func TestDocComment_String(t *testing.T) {
+	t.Parallel()
+	dc := javast.DocComment{
+		Description: "Returns the name.",
+		Tags: []javast.DocTag{
+			{Name: "param", Argument: "id", Text: "the id"},
+			{Name: "return", Text: "the name"},
+		},
+	}
+	got := dc.String()
+	want := "/**\n * Returns the name.\n *\n * @param id the id\n * @return the name\n */"
+	if got != want {
+		t.Errorf("dc.String() = %q, want %q", got, want)
+	}
+	if got, want := (javast.DocComment{Description: "The name."}).String(), "/** The name. */"; got != want {
+		t.Errorf("dc.String() = %q, want %q", got, want)
+	}
+}
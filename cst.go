@@ -2,6 +2,7 @@ package javast
 
 // Implements [AnnotatedTypeNode].
 type AnnotatedType struct {
+	Span
 	Annotations    []AnnotationNode
 	UnderlyingType ExpressionNode
 }
@@ -17,6 +18,7 @@ func (AnnotatedType) annotatedTypeNode() {}
 
 // Implements [AnnotationNode] of kind [ANNOTATION].
 type Annotation struct {
+	Span
 	AnnotationType Node
 	Arguments      []ExpressionNode
 }
@@ -32,6 +34,7 @@ func (Annotation) annotationNode() {}
 
 // Implements [AnnotationNode] of kind [TYPE_ANNOTATION].
 type TypeAnnotation struct {
+	Span
 	AnnotationType Node
 	Arguments      []ExpressionNode
 }
@@ -47,6 +50,7 @@ func (TypeAnnotation) annotationNode() {}
 
 // Implements [ArrayAccessNode].
 type ArrayAccess struct {
+	Span
 	Expression ExpressionNode
 	Index      ExpressionNode
 }
@@ -60,8 +64,9 @@ func (ArrayAccess) caseLabelNode()   {}
 func (ArrayAccess) expressionNode()  {}
 func (ArrayAccess) arrayAccessNode() {}
 
-// Implements [ArrayTypeNode].
+// Implements [ArrayTypeNode] and [ExpressionNode].
 type ArrayType struct {
+	Span
 	Type Node
 }
 
@@ -69,10 +74,14 @@ func (ArrayType) GetKind() Kind { return ARRAY_TYPE }
 
 func (at ArrayType) GetType() Node { return at.Type }
 
-func (ArrayType) arrayTypeNode() {}
+func (ArrayType) caseLabelNode()  {}
+func (ArrayType) expressionNode() {}
+func (ArrayType) arrayTypeNode()  {}
 
 // Implements [AssertNode].
 type Assert struct {
+	Span
+	Comments
 	Condition ExpressionNode
 	Detail    ExpressionNode
 }
@@ -87,6 +96,7 @@ func (Assert) assertNode()    {}
 
 // Implements [AssignmentNode].
 type Assignment struct {
+	Span
 	Variable   ExpressionNode
 	Expression ExpressionNode
 }
@@ -102,8 +112,11 @@ func (Assignment) assignmentNode() {}
 
 // Implements [BlockNode].
 type Block struct {
+	Span
+	Comments
 	Static     bool
 	Statements []StatementNode
+	Dangling   []Comment // The comments before the closing brace, which follow no statement on the same line.
 }
 
 func (Block) GetKind() Kind { return BLOCK }
@@ -116,6 +129,8 @@ func (Block) blockNode()     {}
 
 // Implements [BreakNode].
 type Break struct {
+	Span
+	Comments
 	Label *string
 }
 
@@ -128,29 +143,41 @@ func (Break) breakNode()     {}
 
 // Implements [CaseNode] of kind [STATEMENT_CASE_KIND].
 type StatementCase struct {
-	Expression ExpressionNode
+	Span
+	Labels     []CaseLabelNode
+	Guard      ExpressionNode
 	Statements []StatementNode
 }
 
 func (StatementCase) GetKind() Kind { return CASE }
 
+// Returns the constant expressions of the labels, followed by the guard if there is one.
 func (sc StatementCase) GetExpressions() []ExpressionNode {
-	if sc.Expression != nil {
-		return []ExpressionNode{sc.Expression}
+	expressions := []ExpressionNode{}
+	for _, label := range sc.Labels {
+		if cl, ok := label.(ConstantCaseLabelNode); ok {
+			expressions = append(expressions, cl.GetConstantExpression())
+		}
+	}
+	if sc.Guard != nil {
+		expressions = append(expressions, sc.Guard)
 	}
-	return []ExpressionNode{}
+	return expressions
 }
 
-func (sc StatementCase) GetLabels() []CaseLabelNode     { return nil }
+func (sc StatementCase) GetLabels() []CaseLabelNode     { return sc.Labels }
 func (sc StatementCase) GetStatements() []StatementNode { return sc.Statements }
 func (StatementCase) GetBody() Node                     { return nil }
+func (sc StatementCase) GetGuard() ExpressionNode       { return sc.Guard }
 func (StatementCase) GetCaseKind() CaseKind             { return STATEMENT_CASE_KIND }
 
 func (StatementCase) caseNode() {}
 
 // Implements [CaseNode] of kind [RULE_CASE_KIND].
 type RuleCase struct {
+	Span
 	Labels []CaseLabelNode
+	Guard  ExpressionNode
 	Body   Node
 }
 
@@ -160,12 +187,14 @@ func (rc RuleCase) GetExpressions() []ExpressionNode { return nil }
 func (rc RuleCase) GetLabels() []CaseLabelNode       { return rc.Labels }
 func (rc RuleCase) GetStatements() []StatementNode   { return nil }
 func (rc RuleCase) GetBody() Node                    { return rc.Body }
+func (rc RuleCase) GetGuard() ExpressionNode         { return rc.Guard }
 func (rc RuleCase) GetCaseKind() CaseKind            { return RULE_CASE_KIND }
 
 func (RuleCase) caseNode() {}
 
 // Implements [CatchNode].
 type Catch struct {
+	Span
 	Parameter VariableNode
 	Block     BlockNode
 }
@@ -179,12 +208,16 @@ func (Catch) catchNode() {}
 
 // Implements [ClassNode].
 type Class struct {
+	Span
+	Comments
 	Modifiers        ModifiersNode
 	SimpleName       string
 	TypeParameters   []TypeParameterNode
 	ExtendsClause    Node
 	ImplementsClause []Node
+	PermitsClause    []Node
 	Members          []Node
+	Dangling         []Comment // The comments before the closing brace, which follow no member on the same line.
 }
 
 func (Class) GetKind() Kind { return CLASS }
@@ -194,7 +227,9 @@ func (c Class) GetSimpleName() string                  { return c.SimpleName }
 func (c Class) GetTypeParameters() []TypeParameterNode { return c.TypeParameters }
 func (c Class) GetExtendsClause() Node                 { return c.ExtendsClause }
 func (c Class) GetImplementsClause() []Node            { return c.ImplementsClause }
-func (c Class) GetPermitsClause() []Node               { return nil }
+func (c Class) GetPermitsClause() []Node               { return c.PermitsClause }
+func (c Class) GetEnumConstants() []EnumConstantNode   { return nil }
+func (c Class) GetRecordComponents() []VariableNode    { return nil }
 func (c Class) GetMembers() []Node                     { return c.Members }
 
 func (Class) statementNode() {}
@@ -202,6 +237,7 @@ func (Class) classNode()     {}
 
 // Implements [CompilationUnitNode].
 type CompilationUnit struct {
+	Span
 	Module    ModuleNode
 	Package   PackageNode
 	Imports   []ImportNode
@@ -219,6 +255,7 @@ func (CompilationUnit) compilationUnitNode() {}
 
 // Implements [ConditionalExpressionNode].
 type ConditionalExpression struct {
+	Span
 	Condition       ExpressionNode
 	TrueExpression  ExpressionNode
 	FalseExpression ExpressionNode
@@ -236,6 +273,8 @@ func (ConditionalExpression) conditionalExpressionNode() {}
 
 // Implements [ContinueNode].
 type Continue struct {
+	Span
+	Comments
 	Label *string
 }
 
@@ -248,6 +287,8 @@ func (Continue) continueNode()  {}
 
 // Implements [DoWhileLoopNode].
 type DoWhileLoop struct {
+	Span
+	Comments
 	Condition ExpressionNode
 	Statement StatementNode
 }
@@ -262,6 +303,8 @@ func (DoWhileLoop) doWhileLoopNode() {}
 
 // Implements [EnhancedForLoopNode].
 type EnhancedForLoop struct {
+	Span
+	Comments
 	Variable   VariableNode
 	Expression ExpressionNode
 	Statement  StatementNode
@@ -278,6 +321,8 @@ func (EnhancedForLoop) enhancedForLoopNode() {}
 
 // Implements [ExpressionStatementNode].
 type ExpressionStatement struct {
+	Span
+	Comments
 	Expression ExpressionNode
 }
 
@@ -290,6 +335,7 @@ func (ExpressionStatement) expressionStatementNode() {}
 
 // Implements [MemberSelectNode].
 type MemberSelect struct {
+	Span
 	Expression ExpressionNode
 	Identifier string
 }
@@ -305,6 +351,7 @@ func (MemberSelect) memberSelectNode() {}
 
 // Implements [MemberReferenceNode] of mode [INVOKE_REFERENCE_MODE].
 type InvokeMemberReference struct {
+	Span
 	QualifierExpression ExpressionNode
 	Name                string
 	TypeArguments       []ExpressionNode
@@ -326,6 +373,7 @@ func (InvokeMemberReference) memberReferenceNode() {}
 
 // Implements [MemberReferenceNode] of mode [NEW_REFERENCE_MODE].
 type NewMemberReference struct {
+	Span
 	QualifierExpression ExpressionNode
 	TypeArguments       []ExpressionNode
 }
@@ -347,7 +395,10 @@ func (NewMemberReference) memberReferenceNode() {}
 
 // Implements [ForLoopNode].
 type ForLoop struct {
+	Span
+	Comments
 	Initializer []VariableNode
+	Expressions []ExpressionNode // The initializer expressions, if the loop declares no variables.
 	Condition   ExpressionNode
 	Update      []ExpressionNode
 	Statement   StatementNode
@@ -355,16 +406,18 @@ type ForLoop struct {
 
 func (ForLoop) GetKind() Kind { return FOR_LOOP }
 
-func (fl ForLoop) GetInitializer() []VariableNode { return fl.Initializer }
-func (fl ForLoop) GetCondition() ExpressionNode   { return fl.Condition }
-func (fl ForLoop) GetUpdate() []ExpressionNode    { return fl.Update }
-func (fl ForLoop) GetStatement() StatementNode    { return fl.Statement }
+func (fl ForLoop) GetInitializer() []VariableNode   { return fl.Initializer }
+func (fl ForLoop) GetExpressions() []ExpressionNode { return fl.Expressions }
+func (fl ForLoop) GetCondition() ExpressionNode     { return fl.Condition }
+func (fl ForLoop) GetUpdate() []ExpressionNode      { return fl.Update }
+func (fl ForLoop) GetStatement() StatementNode      { return fl.Statement }
 
 func (ForLoop) statementNode() {}
 func (ForLoop) forLoopNode()   {}
 
 // Implements [IdentifierNode].
 type Identifier struct {
+	Span
 	Name string
 }
 
@@ -378,6 +431,8 @@ func (Identifier) identifierNode() {}
 
 // Implements [IfNode].
 type If struct {
+	Span
+	Comments
 	Condition     ExpressionNode
 	ThenStatement StatementNode
 	ElseStatement StatementNode
@@ -394,6 +449,8 @@ func (If) ifNode()        {}
 
 // Implements [ImportNode].
 type Import struct {
+	Span
+	Comments
 	Static              bool
 	QualifiedIdentifier Node
 }
@@ -407,6 +464,7 @@ func (Import) importNode() {}
 
 // Implements [InstanceOfNode].
 type InstanceOf struct {
+	Span
 	Expression ExpressionNode
 	Type       Node
 	Pattern    PatternNode
@@ -424,6 +482,8 @@ func (InstanceOf) instanceOfNode() {}
 
 // Implements [LabeledStatementNode].
 type LabeledStatement struct {
+	Span
+	Comments
 	Label     string
 	Statement StatementNode
 }
@@ -438,6 +498,8 @@ func (LabeledStatement) labeledStatementNode() {}
 
 // Implements [MethodNode].
 type Method struct {
+	Span
+	Comments
 	Modifiers         ModifiersNode
 	Name              string
 	ReturnType        Node
@@ -447,6 +509,7 @@ type Method struct {
 	Throws            []ExpressionNode
 	Body              BlockNode
 	DefaultValue      Node
+	Compact           bool
 }
 
 func (Method) GetKind() Kind { return METHOD }
@@ -460,11 +523,13 @@ func (m Method) GetReceiverParameter() VariableNode     { return m.ReceiverParam
 func (m Method) GetThrows() []ExpressionNode            { return m.Throws }
 func (m Method) GetBody() BlockNode                     { return m.Body }
 func (m Method) GetDefaultValue() Node                  { return m.DefaultValue }
+func (m Method) IsCompact() bool                        { return m.Compact }
 
 func (Method) methodNode() {}
 
 // Implements [MethodInvocationNode].
 type MethodInvocation struct {
+	Span
 	TypeArguments []Node
 	MethodSelect  ExpressionNode
 	Arguments     []ExpressionNode
@@ -482,6 +547,7 @@ func (MethodInvocation) methodInvocationNode() {}
 
 // Implements [ModifiersNode].
 type Modifiers struct {
+	Span
 	Flags       []Modifier
 	Annotations []AnnotationNode
 }
@@ -496,6 +562,7 @@ func (Modifiers) modifiersNode() {}
 
 // Implements [NewArrayNode].
 type NewArray struct {
+	Span
 	Type           Node
 	Dimensions     []ExpressionNode
 	Initializers   []ExpressionNode
@@ -517,6 +584,7 @@ func (NewArray) newArrayNode()   {}
 
 // Implements [NewClassNode].
 type NewClass struct {
+	Span
 	EnclosingExpression ExpressionNode
 	TypeArguments       []Node
 	Identifier          ExpressionNode
@@ -538,6 +606,7 @@ func (NewClass) newClassNode()   {}
 
 // Implements [LambdaExpressionNode] of kind [EXPRESSION_BODY_KIND].
 type ExpressionLambdaExpression struct {
+	Span
 	Parameters []VariableNode
 	Expression ExpressionNode
 }
@@ -554,6 +623,7 @@ func (ExpressionLambdaExpression) lambdaExpressionNode() {}
 
 // Implements [LambdaExpressionNode] of kind [STATEMENT_BODY_KIND].
 type StatementLambdaExpression struct {
+	Span
 	Parameters []VariableNode
 	Block      BlockNode
 }
@@ -570,6 +640,8 @@ func (StatementLambdaExpression) lambdaExpressionNode() {}
 
 // Implements [PackageNode].
 type Package struct {
+	Span
+	Comments
 	Annotations []AnnotationNode
 	PackageName ExpressionNode
 }
@@ -583,6 +655,7 @@ func (Package) packageNode() {}
 
 // Implements [ParenthesizedNode].
 type Parenthesized struct {
+	Span
 	Expression ExpressionNode
 }
 
@@ -596,6 +669,7 @@ func (Parenthesized) parenthesizedNode() {}
 
 // Implements [BindingPatternNode].
 type BindingPattern struct {
+	Span
 	Variable VariableNode
 }
 
@@ -609,6 +683,7 @@ func (BindingPattern) bindingPatternNode() {}
 
 // Implements [GuardedPatternNode].
 type GuardedPattern struct {
+	Span
 	Pattern    PatternNode
 	Expression ExpressionNode
 }
@@ -624,6 +699,7 @@ func (GuardedPattern) guardedPatternNode() {}
 
 // Implements [ParenthesizedPatternNode].
 type ParenthesizedPattern struct {
+	Span
 	Pattern PatternNode
 }
 
@@ -635,16 +711,61 @@ func (ParenthesizedPattern) caseLabelNode()            {}
 func (ParenthesizedPattern) patternNode()              {}
 func (ParenthesizedPattern) parenthesizedPatternNode() {}
 
+// Implements [RecordPatternNode].
+type RecordPattern struct {
+	Span
+	Deconstructor  ExpressionNode
+	NestedPatterns []PatternNode
+}
+
+func (RecordPattern) GetKind() Kind { return RECORD_PATTERN }
+
+func (rp RecordPattern) GetDeconstructor() ExpressionNode { return rp.Deconstructor }
+func (rp RecordPattern) GetNestedPatterns() []PatternNode { return rp.NestedPatterns }
+
+func (RecordPattern) caseLabelNode()     {}
+func (RecordPattern) patternNode()       {}
+func (RecordPattern) recordPatternNode() {}
+
+// Implements [ConstantCaseLabelNode].
+type ConstantCaseLabel struct {
+	Span
+	ConstantExpression ExpressionNode
+}
+
+func (ConstantCaseLabel) GetKind() Kind { return CONSTANT_CASE_LABEL }
+
+func (ccl ConstantCaseLabel) GetConstantExpression() ExpressionNode { return ccl.ConstantExpression }
+
+func (ConstantCaseLabel) caseLabelNode()         {}
+func (ConstantCaseLabel) constantCaseLabelNode() {}
+
+// Implements [PatternCaseLabelNode].
+type PatternCaseLabel struct {
+	Span
+	Pattern PatternNode
+}
+
+func (PatternCaseLabel) GetKind() Kind { return PATTERN_CASE_LABEL }
+
+func (pcl PatternCaseLabel) GetPattern() PatternNode { return pcl.Pattern }
+
+func (PatternCaseLabel) caseLabelNode()        {}
+func (PatternCaseLabel) patternCaseLabelNode() {}
+
 // Implements [DefaultCaseLabelNode].
-type DefaultCaseLabel struct{}
+type DefaultCaseLabel struct {
+	Span
+}
 
 func (DefaultCaseLabel) GetKind() Kind { return DEFAULT_CASE_LABEL }
 
 func (DefaultCaseLabel) caseLabelNode()        {}
 func (DefaultCaseLabel) defaultCaseLabelNode() {}
 
-// Implements [PrimitiveTypeNode].
+// Implements [PrimitiveTypeNode] and [ExpressionNode].
 type PrimitiveType struct {
+	Span
 	PrimitiveTypeKind TypeKind
 }
 
@@ -652,10 +773,14 @@ func (PrimitiveType) GetKind() Kind { return PRIMITIVE_TYPE }
 
 func (pt PrimitiveType) GetPrimitiveTypeKind() TypeKind { return pt.PrimitiveTypeKind }
 
+func (PrimitiveType) caseLabelNode()     {}
+func (PrimitiveType) expressionNode()    {}
 func (PrimitiveType) primitiveTypeNode() {}
 
 // Implements [ReturnNode].
 type Return struct {
+	Span
+	Comments
 	Expression ExpressionNode
 }
 
@@ -667,7 +792,10 @@ func (Return) statementNode() {}
 func (Return) returnNode()    {}
 
 // Implements [EmptyStatementNode].
-type EmptyStatement struct{}
+type EmptyStatement struct {
+	Span
+	Comments
+}
 
 func (EmptyStatement) GetKind() Kind { return EMPTY_STATEMENT }
 
@@ -675,7 +803,9 @@ func (EmptyStatement) statementNode()      {}
 func (EmptyStatement) emptyStatementNode() {}
 
 // Implements [EmptyExpressionNode].
-type EmptyExpression struct{}
+type EmptyExpression struct {
+	Span
+}
 
 func (EmptyExpression) GetKind() Kind { return EMPTY_EXPRESSION }
 
@@ -685,8 +815,11 @@ func (EmptyExpression) emptyExpressionNode() {}
 
 // Implements [SwitchNode].
 type Switch struct {
+	Span
+	Comments
 	Expression ExpressionNode
 	Cases      []CaseNode
+	Dangling   []Comment // The comments before the closing brace, which follow no case on the same line.
 }
 
 func (Switch) GetKind() Kind { return SWITCH }
@@ -699,8 +832,10 @@ func (Switch) switchNode()    {}
 
 // Implements [SwitchExpressionNode].
 type SwitchExpression struct {
+	Span
 	Expression ExpressionNode
 	Cases      []CaseNode
+	Dangling   []Comment // The comments before the closing brace, which follow no case on the same line.
 }
 
 func (SwitchExpression) GetKind() Kind { return SWITCH_EXPRESSION }
@@ -714,6 +849,8 @@ func (SwitchExpression) switchExpressionNode() {}
 
 // Implements [SynchronizedNode].
 type Synchronized struct {
+	Span
+	Comments
 	Expression ExpressionNode
 	Block      BlockNode
 }
@@ -728,6 +865,8 @@ func (Synchronized) synchronizedNode() {}
 
 // Implements [ThrowNode].
 type Throw struct {
+	Span
+	Comments
 	Expression ExpressionNode
 }
 
@@ -740,6 +879,8 @@ func (Throw) throwNode()     {}
 
 // Implements [TryNode].
 type Try struct {
+	Span
+	Comments
 	Block        BlockNode
 	Catches      []CatchNode
 	FinallyBlock BlockNode
@@ -756,8 +897,9 @@ func (t Try) GetResources() []Node       { return t.Resources }
 func (Try) statementNode() {}
 func (Try) tryNode()       {}
 
-// Implements [ParameterizedTypeNode].
+// Implements [ParameterizedTypeNode] and [ExpressionNode].
 type ParameterizedType struct {
+	Span
 	Type          Node
 	TypeArguments []Node
 }
@@ -767,10 +909,13 @@ func (ParameterizedType) GetKind() Kind { return PARAMETERIZED_TYPE }
 func (pt ParameterizedType) GetType() Node            { return pt.Type }
 func (pt ParameterizedType) GetTypeArguments() []Node { return pt.TypeArguments }
 
+func (ParameterizedType) caseLabelNode()         {}
+func (ParameterizedType) expressionNode()        {}
 func (ParameterizedType) parameterizedTypeNode() {}
 
 // Implements [UnionTypeNode].
 type UnionType struct {
+	Span
 	TypeAlternatives []Node
 }
 
@@ -782,6 +927,7 @@ func (UnionType) unionTypeNode() {}
 
 // Implements [IntersectionTypeNode].
 type IntersectionType struct {
+	Span
 	Bounds []Node
 }
 
@@ -793,6 +939,7 @@ func (IntersectionType) intersectionTypeNode() {}
 
 // Implements [TypeCastNode].
 type TypeCast struct {
+	Span
 	Type       Node
 	Expression ExpressionNode
 }
@@ -808,6 +955,7 @@ func (TypeCast) typeCastNode()   {}
 
 // Implements [TypeParameterNode].
 type TypeParameter struct {
+	Span
 	Name        string
 	Bounds      []Node
 	Annotations []AnnotationNode
@@ -823,11 +971,14 @@ func (TypeParameter) typeParameterNode() {}
 
 // Implements [VariableNode].
 type Variable struct {
+	Span
+	Comments
 	Modifiers      ModifiersNode
 	Name           string
 	NameExpression ExpressionNode
 	Type           Node
 	Initializer    ExpressionNode
+	Varargs        bool
 }
 
 func (Variable) GetKind() Kind { return VARIABLE }
@@ -837,12 +988,64 @@ func (v Variable) GetName() string                   { return v.Name }
 func (v Variable) GetNameExpression() ExpressionNode { return v.NameExpression }
 func (v Variable) GetType() Node                     { return v.Type }
 func (v Variable) GetInitializer() ExpressionNode    { return v.Initializer }
+func (v Variable) IsVarargs() bool                   { return v.Varargs }
 
 func (Variable) statementNode() {}
 func (Variable) variableNode()  {}
 
+// Implements [VariableDeclarationNode].
+// The modifiers of the declaration are those of its first variable, and its type is the type of the variable
+// with the fewest array dimensions, so the variables carry their complete declarations.
+type VariableDeclaration struct {
+	Span
+	Comments
+	Context   DeclarationContext
+	Variables []VariableNode
+}
+
+func (VariableDeclaration) GetKind() Kind { return VARIABLE_DECLARATION }
+
+func (vd VariableDeclaration) GetContext() DeclarationContext { return vd.Context }
+func (vd VariableDeclaration) GetVariables() []VariableNode   { return vd.Variables }
+
+func (vd VariableDeclaration) GetModifiers() ModifiersNode {
+	if len(vd.Variables) == 0 {
+		return nil
+	}
+	return vd.Variables[0].GetModifiers()
+}
+
+func (vd VariableDeclaration) GetType() Node {
+	if len(vd.Variables) == 0 {
+		return nil
+	}
+	dims := arrayDims(vd.Variables[0].GetType())
+	for _, v := range vd.Variables[1:] {
+		dims = min(dims, arrayDims(v.GetType()))
+	}
+	typ := vd.Variables[0].GetType()
+	for i := arrayDims(typ); i > dims; i-- {
+		typ = typ.(ArrayTypeNode).GetType()
+	}
+	return typ
+}
+
+func (VariableDeclaration) statementNode()           {}
+func (VariableDeclaration) variableDeclarationNode() {}
+
+// Returns the number of array dimensions of the type.
+func arrayDims(typ Node) int {
+	dims := 0
+	for at, ok := typ.(ArrayTypeNode); ok; at, ok = at.GetType().(ArrayTypeNode) {
+		dims++
+	}
+	return dims
+}
+
 // Implements [WhileLoopNode].
 type WhileLoop struct {
+	Span
+	Comments
 	Condition ExpressionNode
 	Statement StatementNode
 }
@@ -857,6 +1060,7 @@ func (WhileLoop) whileLoopNode() {}
 
 // Implements [UnaryNode] of kind [POSTFIX_INCREMENT].
 type PostfixIncrement struct {
+	Span
 	Expression ExpressionNode
 }
 
@@ -870,6 +1074,7 @@ func (PostfixIncrement) unaryNode()      {}
 
 // Implements [UnaryNode] of kind [POSTFIX_DECREMENT].
 type PostfixDecrement struct {
+	Span
 	Expression ExpressionNode
 }
 
@@ -883,6 +1088,7 @@ func (PostfixDecrement) unaryNode()      {}
 
 // Implements [UnaryNode] of kind [PREFIX_INCREMENT].
 type PrefixIncrement struct {
+	Span
 	Expression ExpressionNode
 }
 
@@ -896,6 +1102,7 @@ func (PrefixIncrement) unaryNode()      {}
 
 // Implements [UnaryNode] of kind [PREFIX_DECREMENT].
 type PrefixDecrement struct {
+	Span
 	Expression ExpressionNode
 }
 
@@ -909,6 +1116,7 @@ func (PrefixDecrement) unaryNode()      {}
 
 // Implements [UnaryNode] of kind [UNARY_PLUS].
 type UnaryPlus struct {
+	Span
 	Expression ExpressionNode
 }
 
@@ -922,6 +1130,7 @@ func (UnaryPlus) unaryNode()      {}
 
 // Implements [UnaryNode] of kind [UNARY_MINUS].
 type UnaryMinus struct {
+	Span
 	Expression ExpressionNode
 }
 
@@ -935,6 +1144,7 @@ func (UnaryMinus) unaryNode()      {}
 
 // Implements [UnaryNode] of kind [BITWISE_COMPLEMENT].
 type BitwiseComplement struct {
+	Span
 	Expression ExpressionNode
 }
 
@@ -948,6 +1158,7 @@ func (BitwiseComplement) unaryNode()      {}
 
 // Implements [UnaryNode] of kind [LOGICAL_COMPLEMENT].
 type LogicalComplement struct {
+	Span
 	Expression ExpressionNode
 }
 
@@ -961,6 +1172,7 @@ func (LogicalComplement) unaryNode()      {}
 
 // Implements [BinaryNode] of kind [MULTIPLY].
 type Multiply struct {
+	Span
 	LeftOperand  ExpressionNode
 	RightOperand ExpressionNode
 }
@@ -976,6 +1188,7 @@ func (Multiply) binaryNode()     {}
 
 // Implements [BinaryNode] of kind [DIVIDE].
 type Divide struct {
+	Span
 	LeftOperand  ExpressionNode
 	RightOperand ExpressionNode
 }
@@ -991,6 +1204,7 @@ func (Divide) binaryNode()     {}
 
 // Implements [BinaryNode] of kind [REMAINDER].
 type Remainder struct {
+	Span
 	LeftOperand  ExpressionNode
 	RightOperand ExpressionNode
 }
@@ -1006,6 +1220,7 @@ func (Remainder) binaryNode()     {}
 
 // Implements [BinaryNode] of kind [PLUS].
 type Plus struct {
+	Span
 	LeftOperand  ExpressionNode
 	RightOperand ExpressionNode
 }
@@ -1021,6 +1236,7 @@ func (Plus) binaryNode()     {}
 
 // Implements [BinaryNode] of kind [MINUS].
 type Minus struct {
+	Span
 	LeftOperand  ExpressionNode
 	RightOperand ExpressionNode
 }
@@ -1036,6 +1252,7 @@ func (Minus) binaryNode()     {}
 
 // Implements [BinaryNode] of kind [LEFT_SHIFT].
 type LeftShift struct {
+	Span
 	LeftOperand  ExpressionNode
 	RightOperand ExpressionNode
 }
@@ -1051,6 +1268,7 @@ func (LeftShift) binaryNode()     {}
 
 // Implements [BinaryNode] of kind [RIGHT_SHIFT].
 type RightShift struct {
+	Span
 	LeftOperand  ExpressionNode
 	RightOperand ExpressionNode
 }
@@ -1066,6 +1284,7 @@ func (RightShift) binaryNode()     {}
 
 // Implements [BinaryNode] of kind [UNSIGNED_RIGHT_SHIFT].
 type UnsignedRightShift struct {
+	Span
 	LeftOperand  ExpressionNode
 	RightOperand ExpressionNode
 }
@@ -1081,6 +1300,7 @@ func (UnsignedRightShift) binaryNode()     {}
 
 // Implements [BinaryNode] of kind [LESS_THAN].
 type LessThan struct {
+	Span
 	LeftOperand  ExpressionNode
 	RightOperand ExpressionNode
 }
@@ -1096,6 +1316,7 @@ func (LessThan) binaryNode()     {}
 
 // Implements [BinaryNode] of kind [GREATER_THAN].
 type GreaterThan struct {
+	Span
 	LeftOperand  ExpressionNode
 	RightOperand ExpressionNode
 }
@@ -1111,6 +1332,7 @@ func (GreaterThan) binaryNode()     {}
 
 // Implements [BinaryNode] of kind [LESS_THAN_EQUAL].
 type LessThanEqual struct {
+	Span
 	LeftOperand  ExpressionNode
 	RightOperand ExpressionNode
 }
@@ -1126,6 +1348,7 @@ func (LessThanEqual) binaryNode()     {}
 
 // Implements [BinaryNode] of kind [GREATER_THAN_EQUAL].
 type GreaterThanEqual struct {
+	Span
 	LeftOperand  ExpressionNode
 	RightOperand ExpressionNode
 }
@@ -1141,6 +1364,7 @@ func (GreaterThanEqual) binaryNode()     {}
 
 // Implements [BinaryNode] of kind [EQUAL_TO].
 type EqualTo struct {
+	Span
 	LeftOperand  ExpressionNode
 	RightOperand ExpressionNode
 }
@@ -1156,6 +1380,7 @@ func (EqualTo) binaryNode()     {}
 
 // Implements [BinaryNode] of kind [NOT_EQUAL_TO].
 type NotEqualTo struct {
+	Span
 	LeftOperand  ExpressionNode
 	RightOperand ExpressionNode
 }
@@ -1171,6 +1396,7 @@ func (NotEqualTo) binaryNode()     {}
 
 // Implements [BinaryNode] of kind [AND].
 type And struct {
+	Span
 	LeftOperand  ExpressionNode
 	RightOperand ExpressionNode
 }
@@ -1186,6 +1412,7 @@ func (And) binaryNode()     {}
 
 // Implements [BinaryNode] of kind [XOR].
 type Xor struct {
+	Span
 	LeftOperand  ExpressionNode
 	RightOperand ExpressionNode
 }
@@ -1201,6 +1428,7 @@ func (Xor) binaryNode()     {}
 
 // Implements [BinaryNode] of kind [OR].
 type Or struct {
+	Span
 	LeftOperand  ExpressionNode
 	RightOperand ExpressionNode
 }
@@ -1216,6 +1444,7 @@ func (Or) binaryNode()     {}
 
 // Implements [BinaryNode] of kind [CONDITIONAL_AND].
 type ConditionalAnd struct {
+	Span
 	LeftOperand  ExpressionNode
 	RightOperand ExpressionNode
 }
@@ -1231,6 +1460,7 @@ func (ConditionalAnd) binaryNode()     {}
 
 // Implements [BinaryNode] of kind [CONDITIONAL_OR].
 type ConditionalOr struct {
+	Span
 	LeftOperand  ExpressionNode
 	RightOperand ExpressionNode
 }
@@ -1246,6 +1476,7 @@ func (ConditionalOr) binaryNode()     {}
 
 // Implements [CompoundAssignmentNode] of kind [MULTIPLY_ASSIGNMENT].
 type MultiplyAssignment struct {
+	Span
 	Variable   ExpressionNode
 	Expression ExpressionNode
 }
@@ -1261,6 +1492,7 @@ func (MultiplyAssignment) compoundAssignmentNode() {}
 
 // Implements [CompoundAssignmentNode] of kind [DIVIDE_ASSIGNMENT].
 type DivideAssignment struct {
+	Span
 	Variable   ExpressionNode
 	Expression ExpressionNode
 }
@@ -1276,6 +1508,7 @@ func (DivideAssignment) compoundAssignmentNode() {}
 
 // Implements [CompoundAssignmentNode] of kind [REMAINDER_ASSIGNMENT].
 type RemainderAssignment struct {
+	Span
 	Variable   ExpressionNode
 	Expression ExpressionNode
 }
@@ -1291,6 +1524,7 @@ func (RemainderAssignment) compoundAssignmentNode() {}
 
 // Implements [CompoundAssignmentNode] of kind [PLUS_ASSIGNMENT].
 type PlusAssignment struct {
+	Span
 	Variable   ExpressionNode
 	Expression ExpressionNode
 }
@@ -1306,6 +1540,7 @@ func (PlusAssignment) compoundAssignmentNode() {}
 
 // Implements [CompoundAssignmentNode] of kind [MINUS_ASSIGNMENT].
 type MinusAssignment struct {
+	Span
 	Variable   ExpressionNode
 	Expression ExpressionNode
 }
@@ -1321,6 +1556,7 @@ func (MinusAssignment) compoundAssignmentNode() {}
 
 // Implements [CompoundAssignmentNode] of kind [LEFT_SHIFT_ASSIGNMENT].
 type LeftShiftAssignment struct {
+	Span
 	Variable   ExpressionNode
 	Expression ExpressionNode
 }
@@ -1336,6 +1572,7 @@ func (LeftShiftAssignment) compoundAssignmentNode() {}
 
 // Implements [CompoundAssignmentNode] of kind [RIGHT_SHIFT_ASSIGNMENT].
 type RightShiftAssignment struct {
+	Span
 	Variable   ExpressionNode
 	Expression ExpressionNode
 }
@@ -1351,6 +1588,7 @@ func (RightShiftAssignment) compoundAssignmentNode() {}
 
 // Implements [CompoundAssignmentNode] of kind [UNSIGNED_RIGHT_SHIFT_ASSIGNMENT].
 type UnsignedRightShiftAssignment struct {
+	Span
 	Variable   ExpressionNode
 	Expression ExpressionNode
 }
@@ -1366,6 +1604,7 @@ func (UnsignedRightShiftAssignment) compoundAssignmentNode() {}
 
 // Implements [CompoundAssignmentNode] of kind [AND_ASSIGNMENT].
 type AndAssignment struct {
+	Span
 	Variable   ExpressionNode
 	Expression ExpressionNode
 }
@@ -1381,6 +1620,7 @@ func (AndAssignment) compoundAssignmentNode() {}
 
 // Implements [CompoundAssignmentNode] of kind [XOR_ASSIGNMENT].
 type XorAssignment struct {
+	Span
 	Variable   ExpressionNode
 	Expression ExpressionNode
 }
@@ -1396,6 +1636,7 @@ func (XorAssignment) compoundAssignmentNode() {}
 
 // Implements [CompoundAssignmentNode] of kind [OR_ASSIGNMENT].
 type OrAssignment struct {
+	Span
 	Variable   ExpressionNode
 	Expression ExpressionNode
 }
@@ -1411,6 +1652,7 @@ func (OrAssignment) compoundAssignmentNode() {}
 
 // Implements [LiteralNode] of kind [INT_LITERAL].
 type IntLiteral struct {
+	Span
 	Value string
 }
 
@@ -1424,6 +1666,7 @@ func (IntLiteral) literalNode()    {}
 
 // Implements [LiteralNode] of kind [LONG_LITERAL].
 type LongLiteral struct {
+	Span
 	Value string
 }
 
@@ -1437,6 +1680,7 @@ func (LongLiteral) literalNode()    {}
 
 // Implements [LiteralNode] of kind [FLOAT_LITERAL].
 type FloatLiteral struct {
+	Span
 	Value string
 }
 
@@ -1450,6 +1694,7 @@ func (FloatLiteral) literalNode()    {}
 
 // Implements [LiteralNode] of kind [DOUBLE_LITERAL].
 type DoubleLiteral struct {
+	Span
 	Value string
 }
 
@@ -1463,6 +1708,7 @@ func (DoubleLiteral) literalNode()    {}
 
 // Implements [LiteralNode] of kind [BOOLEAN_LITERAL].
 type BooleanLiteral struct {
+	Span
 	Value bool
 }
 
@@ -1480,8 +1726,12 @@ func (BooleanLiteral) expressionNode() {}
 func (BooleanLiteral) literalNode()    {}
 
 // Implements [LiteralNode] of kind [CHAR_LITERAL].
+// The value is the character itself rather than its escaped source form.
+// A lone surrogate is encoded by [lexer.EncodeRune], and written as a Unicode escape.
 type CharLiteral struct {
+	Span
 	Value string
+	ASCII bool // Whether non-ASCII characters are written as Unicode escapes.
 }
 
 func (CharLiteral) GetKind() Kind { return CHAR_LITERAL }
@@ -1493,8 +1743,12 @@ func (CharLiteral) expressionNode() {}
 func (CharLiteral) literalNode()    {}
 
 // Implements [LiteralNode] of kind [STRING_LITERAL].
+// The value is the string itself rather than its escaped source form.
+// A lone surrogate is encoded by [lexer.EncodeRune], and written as a Unicode escape.
 type StringLiteral struct {
+	Span
 	Value string
+	ASCII bool // Whether non-ASCII characters are written as Unicode escapes.
 }
 
 func (StringLiteral) GetKind() Kind { return STRING_LITERAL }
@@ -1505,8 +1759,31 @@ func (StringLiteral) caseLabelNode()  {}
 func (StringLiteral) expressionNode() {}
 func (StringLiteral) literalNode()    {}
 
+// Implements [LiteralNode] of kind [STRING_LITERAL] written as a text block.
+// For example:
+//
+//	"""
+//	    SELECT *
+//	    FROM t
+//	    """
+type TextBlockLiteral struct {
+	Span
+	Value string
+	ASCII bool // Whether non-ASCII characters are written as Unicode escapes.
+}
+
+func (TextBlockLiteral) GetKind() Kind { return STRING_LITERAL }
+
+func (tbl TextBlockLiteral) GetValue() string { return tbl.Value }
+
+func (TextBlockLiteral) caseLabelNode()  {}
+func (TextBlockLiteral) expressionNode() {}
+func (TextBlockLiteral) literalNode()    {}
+
 // Implements [LiteralNode] of kind [NULL_LITERAL].
-type NullLiteral struct{}
+type NullLiteral struct {
+	Span
+}
 
 func (NullLiteral) GetKind() Kind { return NULL_LITERAL }
 
@@ -1516,17 +1793,24 @@ func (NullLiteral) caseLabelNode()  {}
 func (NullLiteral) expressionNode() {}
 func (NullLiteral) literalNode()    {}
 
-// Implements [WildcardNode] of kind [UNBOUNDED_WILDCARD].
-type UnboundedWildcard struct{}
+// Implements [WildcardNode] of kind [UNBOUNDED_WILDCARD] and [ExpressionNode].
+// An annotated wildcard is the underlying type of an [AnnotatedType].
+type UnboundedWildcard struct {
+	Span
+}
 
 func (UnboundedWildcard) GetKind() Kind { return UNBOUNDED_WILDCARD }
 
 func (uw UnboundedWildcard) GetBound() Node { return nil }
 
-func (UnboundedWildcard) wildcardNode() {}
+func (UnboundedWildcard) caseLabelNode()  {}
+func (UnboundedWildcard) expressionNode() {}
+func (UnboundedWildcard) wildcardNode()   {}
 
-// Implements [WildcardNode] of kind [EXTENDS_WILDCARD].
+// Implements [WildcardNode] of kind [EXTENDS_WILDCARD] and [ExpressionNode].
+// An annotated wildcard is the underlying type of an [AnnotatedType].
 type ExtendsWildcard struct {
+	Span
 	Bound Node
 }
 
@@ -1534,10 +1818,14 @@ func (ExtendsWildcard) GetKind() Kind { return EXTENDS_WILDCARD }
 
 func (xw ExtendsWildcard) GetBound() Node { return xw.Bound }
 
-func (ExtendsWildcard) wildcardNode() {}
+func (ExtendsWildcard) caseLabelNode()  {}
+func (ExtendsWildcard) expressionNode() {}
+func (ExtendsWildcard) wildcardNode()   {}
 
-// Implements [WildcardNode] of kind [SUPER_WILDCARD].
+// Implements [WildcardNode] of kind [SUPER_WILDCARD] and [ExpressionNode].
+// An annotated wildcard is the underlying type of an [AnnotatedType].
 type SuperWildcard struct {
+	Span
 	Bound Node
 }
 
@@ -1545,10 +1833,13 @@ func (SuperWildcard) GetKind() Kind { return SUPER_WILDCARD }
 
 func (sw SuperWildcard) GetBound() Node { return sw.Bound }
 
-func (SuperWildcard) wildcardNode() {}
+func (SuperWildcard) caseLabelNode()  {}
+func (SuperWildcard) expressionNode() {}
+func (SuperWildcard) wildcardNode()   {}
 
 // Implements [ErroneousNode].
 type Erroneous struct {
+	Span
 	ErrorNodes []Node
 }
 
@@ -1562,12 +1853,15 @@ func (Erroneous) erroneousNode()  {}
 
 // Implements [ClassNode] of kind [INTERFACE].
 type Interface struct {
+	Span
+	Comments
 	Modifiers      ModifiersNode
 	SimpleName     string
 	TypeParameters []TypeParameterNode
-	ExtendsClause  Node
+	ExtendsClause  []Node // The interfaces extended by the interface.
 	PermitsClause  []Node
 	Members        []Node
+	Dangling       []Comment // The comments before the closing brace, which follow no member on the same line.
 }
 
 func (Interface) GetKind() Kind { return INTERFACE }
@@ -1575,9 +1869,11 @@ func (Interface) GetKind() Kind { return INTERFACE }
 func (i Interface) GetModifiers() ModifiersNode            { return i.Modifiers }
 func (i Interface) GetSimpleName() string                  { return i.SimpleName }
 func (i Interface) GetTypeParameters() []TypeParameterNode { return i.TypeParameters }
-func (i Interface) GetExtendsClause() Node                 { return i.ExtendsClause }
-func (i Interface) GetImplementsClause() []Node            { return nil }
+func (i Interface) GetExtendsClause() Node                 { return nil }
+func (i Interface) GetImplementsClause() []Node            { return i.ExtendsClause }
 func (i Interface) GetPermitsClause() []Node               { return i.PermitsClause }
+func (i Interface) GetEnumConstants() []EnumConstantNode   { return nil }
+func (i Interface) GetRecordComponents() []VariableNode    { return nil }
 func (i Interface) GetMembers() []Node                     { return i.Members }
 
 func (Interface) statementNode() {}
@@ -1585,9 +1881,14 @@ func (Interface) classNode()     {}
 
 // Implements [ClassNode] of kind [ENUM].
 type Enum struct {
-	Modifiers  ModifiersNode
-	SimpleName string
-	Members    []Node
+	Span
+	Comments
+	Modifiers        ModifiersNode
+	SimpleName       string
+	ImplementsClause []Node
+	Constants        []EnumConstantNode
+	Members          []Node
+	Dangling         []Comment // The comments before the closing brace, which follow no constant or member on the same line.
 }
 
 func (Enum) GetKind() Kind { return ENUM }
@@ -1596,8 +1897,10 @@ func (e Enum) GetModifiers() ModifiersNode            { return e.Modifiers }
 func (e Enum) GetSimpleName() string                  { return e.SimpleName }
 func (e Enum) GetTypeParameters() []TypeParameterNode { return nil }
 func (e Enum) GetExtendsClause() Node                 { return nil }
-func (e Enum) GetImplementsClause() []Node            { return nil }
+func (e Enum) GetImplementsClause() []Node            { return e.ImplementsClause }
 func (e Enum) GetPermitsClause() []Node               { return nil }
+func (e Enum) GetEnumConstants() []EnumConstantNode   { return e.Constants }
+func (e Enum) GetRecordComponents() []VariableNode    { return nil }
 func (e Enum) GetMembers() []Node                     { return e.Members }
 
 func (Enum) statementNode() {}
@@ -1605,9 +1908,12 @@ func (Enum) classNode()     {}
 
 // Implements [ClassNode] of kind [ANNOTATION_TYPE].
 type AnnotationType struct {
+	Span
+	Comments
 	Modifiers  ModifiersNode
 	SimpleName string
 	Members    []Node
+	Dangling   []Comment // The comments before the closing brace, which follow no member on the same line.
 }
 
 func (AnnotationType) GetKind() Kind { return ANNOTATION_TYPE }
@@ -1618,6 +1924,8 @@ func (at AnnotationType) GetTypeParameters() []TypeParameterNode { return nil }
 func (at AnnotationType) GetExtendsClause() Node                 { return nil }
 func (at AnnotationType) GetImplementsClause() []Node            { return nil }
 func (at AnnotationType) GetPermitsClause() []Node               { return nil }
+func (at AnnotationType) GetEnumConstants() []EnumConstantNode   { return nil }
+func (at AnnotationType) GetRecordComponents() []VariableNode    { return nil }
 func (at AnnotationType) GetMembers() []Node                     { return at.Members }
 
 func (AnnotationType) statementNode() {}
@@ -1625,6 +1933,7 @@ func (AnnotationType) classNode()     {}
 
 // Implements [ModuleNode].
 type Module struct {
+	Span
 	Annotations []AnnotationNode
 	ModuleType  ModuleKind
 	Name        ExpressionNode
@@ -1642,6 +1951,7 @@ func (Module) moduleNode() {}
 
 // Implements [ExportsNode].
 type Exports struct {
+	Span
 	PackageName ExpressionNode
 	ModuleNames []ExpressionNode
 }
@@ -1656,6 +1966,7 @@ func (Exports) exportsNode()   {}
 
 // Implements [OpensNode].
 type Opens struct {
+	Span
 	PackageName ExpressionNode
 	ModuleNames []ExpressionNode
 }
@@ -1670,6 +1981,7 @@ func (Opens) opensNode()     {}
 
 // Implements [ProvidesNode].
 type Provides struct {
+	Span
 	ServiceName         ExpressionNode
 	ImplementationNames []ExpressionNode
 }
@@ -1684,11 +1996,15 @@ func (Provides) providesNode()  {}
 
 // Implements [ClassNode] of kind [RECORD].
 type Record struct {
+	Span
+	Comments
 	Modifiers        ModifiersNode
 	SimpleName       string
 	TypeParameters   []TypeParameterNode
+	Components       []VariableNode
 	ImplementsClause []Node
 	Members          []Node
+	Dangling         []Comment // The comments before the closing brace, which follow no member on the same line.
 }
 
 func (Record) GetKind() Kind { return RECORD }
@@ -1699,6 +2015,8 @@ func (r Record) GetTypeParameters() []TypeParameterNode { return r.TypeParameter
 func (r Record) GetExtendsClause() Node                 { return nil }
 func (r Record) GetImplementsClause() []Node            { return r.ImplementsClause }
 func (r Record) GetPermitsClause() []Node               { return nil }
+func (r Record) GetEnumConstants() []EnumConstantNode   { return nil }
+func (r Record) GetRecordComponents() []VariableNode    { return r.Components }
 func (r Record) GetMembers() []Node                     { return r.Members }
 
 func (Record) statementNode() {}
@@ -1706,6 +2024,7 @@ func (Record) classNode()     {}
 
 // Implements [RequiresNode].
 type Requires struct {
+	Span
 	Static     bool
 	Transitive bool
 	ModuleName ExpressionNode
@@ -1722,6 +2041,7 @@ func (Requires) requiresNode()  {}
 
 // Implements [UsesNode].
 type Uses struct {
+	Span
 	ServiceName ExpressionNode
 }
 
@@ -1734,6 +2054,8 @@ func (Uses) usesNode()      {}
 
 // Implements [YieldNode].
 type Yield struct {
+	Span
+	Comments
 	Value ExpressionNode
 }
 
@@ -1743,3 +2065,65 @@ func (y Yield) GetValue() ExpressionNode { return y.Value }
 
 func (Yield) statementNode() {}
 func (Yield) yieldNode()     {}
+
+// Implements [EnumConstantNode].
+// A nil Arguments slice omits the argument list, while an empty one is written as "()".
+type EnumConstant struct {
+	Span
+	Comments
+	Annotations []AnnotationNode
+	Name        string
+	Arguments   []ExpressionNode
+	ClassBody   ClassNode
+}
+
+func (EnumConstant) GetKind() Kind { return ENUM_CONSTANT }
+
+func (ec EnumConstant) GetAnnotations() []AnnotationNode { return ec.Annotations }
+func (ec EnumConstant) GetName() string                  { return ec.Name }
+func (ec EnumConstant) GetArguments() []ExpressionNode   { return ec.Arguments }
+func (ec EnumConstant) GetClassBody() ClassNode          { return ec.ClassBody }
+
+func (EnumConstant) enumConstantNode() {}
+
+// Implements [ClassLiteralNode].
+type ClassLiteral struct {
+	Span
+	Type Node
+}
+
+func (ClassLiteral) GetKind() Kind { return CLASS_LITERAL }
+
+func (cl ClassLiteral) GetType() Node { return cl.Type }
+
+func (ClassLiteral) caseLabelNode()    {}
+func (ClassLiteral) expressionNode()   {}
+func (ClassLiteral) classLiteralNode() {}
+
+// Implements [ThisNode].
+type This struct {
+	Span
+	Qualifier ExpressionNode
+}
+
+func (This) GetKind() Kind { return THIS }
+
+func (t This) GetQualifier() ExpressionNode { return t.Qualifier }
+
+func (This) caseLabelNode()  {}
+func (This) expressionNode() {}
+func (This) thisNode()       {}
+
+// Implements [SuperNode].
+type Super struct {
+	Span
+	Qualifier ExpressionNode
+}
+
+func (Super) GetKind() Kind { return SUPER }
+
+func (s Super) GetQualifier() ExpressionNode { return s.Qualifier }
+
+func (Super) caseLabelNode()  {}
+func (Super) expressionNode() {}
+func (Super) superNode()      {}
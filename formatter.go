@@ -1,8 +1,12 @@
 package javast
 
 import (
+	"bytes"
 	"io"
+	"slices"
 	"strings"
+
+	"github.com/kapavkin/javast/lexer"
 )
 
 const (
@@ -10,157 +14,861 @@ const (
 	LineLength int    = 80
 )
 
+// Formatting profiles of common Java styles.
+var (
+	// Google Java Style: two-space indentation, four-space continuation and 100 columns.
+	GoogleStyle = FormatterOptions{
+		Identation:                    "  ",
+		ContinuationIdentation:        "    ",
+		LineLength:                    100,
+		JavaSpacing:                   true,
+		SpaceAroundOperators:          true,
+		SpaceAfterCast:                true,
+		BlankLinesAfterPackage:        1,
+		BlankLinesAfterImports:        1,
+		BlankLinesBetweenMembers:      1,
+		BlankLinesBetweenFieldGroups:  1,
+		KeepBlankLines:                1,
+		BlankLinesBetweenImportGroups: 1,
+	}
+	// AOSP Java style: Google Java Style with four-space indentation and eight-space continuation.
+	AOSPStyle = FormatterOptions{
+		Identation:                    "    ",
+		ContinuationIdentation:        "        ",
+		LineLength:                    100,
+		JavaSpacing:                   true,
+		SpaceAroundOperators:          true,
+		SpaceAfterCast:                true,
+		BlankLinesAfterPackage:        1,
+		BlankLinesAfterImports:        1,
+		BlankLinesBetweenMembers:      1,
+		BlankLinesBetweenFieldGroups:  1,
+		KeepBlankLines:                1,
+		BlankLinesBetweenImportGroups: 1,
+		Imports:                       ImportOptions{Groups: []string{"android", "com", "junit", "net", "org", "java", "javax"}},
+	}
+	// The default Eclipse formatter profile: tab indentation, two-tab continuation and 120 columns.
+	EclipseStyle = FormatterOptions{
+		Identation:                    "\t",
+		ContinuationIdentation:        "\t\t",
+		LineLength:                    120,
+		JavaSpacing:                   true,
+		SpaceAroundOperators:          true,
+		SpaceAfterCast:                true,
+		BlankLinesAfterPackage:        1,
+		BlankLinesAfterImports:        1,
+		BlankLinesBetweenMembers:      1,
+		BlankLinesBetweenFieldGroups:  0,
+		KeepBlankLines:                1,
+		BlankLinesBetweenImportGroups: 1,
+		Imports:                       ImportOptions{Groups: []string{"java", "javax", "org", "com"}},
+	}
+	// The default IntelliJ IDEA code style: four-space indentation, eight-space continuation and 120 columns.
+	IntelliJStyle = FormatterOptions{
+		Identation:                    "    ",
+		ContinuationIdentation:        "        ",
+		LineLength:                    120,
+		JavaSpacing:                   true,
+		SpaceAroundOperators:          true,
+		SpaceAfterCast:                true,
+		BlankLinesAfterPackage:        1,
+		BlankLinesAfterImports:        1,
+		BlankLinesBetweenMembers:      1,
+		BlankLinesBetweenFieldGroups:  0,
+		KeepBlankLines:                2,
+		BlankLinesBetweenImportGroups: 1,
+		Imports:                       ImportOptions{Groups: []string{"", "javax", "java"}, StaticLast: true},
+	}
+)
+
 type Formatter struct {
 	Writer  io.Writer
 	Options FormatterOptions
+	FileSet *FileSet // The file set of the written nodes, used to keep their blank lines, or nil.
 	state   FormatterState
 }
 
+// Implements [io.Writer] interface for [Formatter].
+// Each call writes a single token. The tokens of a line are buffered until the line ends or a block is closed,
+// and then wrapped to fit [FormatterOptions.LineLength], so [Formatter.Flush] must be called after writing a node
+// which does not end with a closing brace, such as a statement or an expression.
 func (f *Formatter) Write(p []byte) (int, error) {
-	writer, options := f.Writer, f.Options
-	written := 0
-	switch f.state.LastToken {
-	case ".":
-		if n, err := writer.Write(p); err != nil {
-			return written + n, err
-		} else {
-			written += n
+	options, n := f.Options, len(p)
+	if bytes.HasPrefix(p, []byte(`"""`)) {
+		p = f.indentTextBlock(p)
+	}
+	token, owner, last := string(p), f.owner(), f.state.LastNode
+	newline, space := false, false
+	switch previous := f.state.LastToken; {
+	case previous == "." || previous == "<" && delimiter(last):
+	case (previous == ";" || previous == "{") && !f.state.Inline:
+		newline = true
+	case previous == "}" && !f.state.Inline:
+		// A closing brace ends a statement or a declaration, unless the statement continues after it.
+		switch token {
+		case ";", ",", ")", ".":
+			space = token != "."
+		case "else", "catch", "finally":
+			// With braces on lines of their own, the statement continues on the next line.
+			space, newline = !options.NextLineBraces, options.NextLineBraces
+		case "while":
+			space = true
+		default:
+			newline = true
 		}
-	case "<":
-		if n, err := writer.Write(p); err != nil {
-			return written + n, err
-		} else {
-			written += n
+	case previous == ":" && kindOf(last) == CASE:
+		// The statements of a case start on the line after its label.
+		newline = true
+	default:
+		switch {
+		case token == ".", token == ">" && delimiter(owner):
+		case token == "}":
+			newline = !f.inline()
+			space = !newline
+		default:
+			space = len(previous) > 0
 		}
-	case ";":
-		switch string(p) {
-		case "}":
+	}
+	if f.state.Break {
+		newline, space = true, false
+	}
+	if options.JavaSpacing && !newline {
+		space = f.javaSpace(token)
+	}
+	if !newline && !space && merges(f.state.LastToken, token) && !(token == ">" && delimiter(owner)) {
+		// Tokens which would read as a single token, such as the operators of "- -k", are kept apart.
+		space = true
+	}
+	// An opening brace of a block starts a line of its own, unless it opens an array initializer.
+	brace := options.NextLineBraces && token == "{" && !newline && kindOf(owner) != NEW_ARRAY &&
+		(owner != nil || !opensInitializer(f.state.LastToken))
+	if newline || brace {
+		if err := f.Flush(); err != nil {
+			return 0, err
+		}
+		for ; f.state.Indent > 0; f.state.Indent-- {
+			f.state.Identation += options.Identation
+		}
+		for ; f.state.Indent < 0; f.state.Indent++ {
 			f.state.Identation = strings.Replace(f.state.Identation, options.Identation, "", 1)
-			if n, err := writer.Write([]byte{'\n'}); err != nil {
-				return written + n, err
-			} else {
-				written += n
-			}
-			if n, err := writer.Write([]byte(f.state.Identation)); err != nil {
-				return written + n, err
-			} else {
-				written += n
-			}
-			if n, err := writer.Write(p); err != nil {
-				return written + n, err
-			} else {
-				written += n
-			}
-		default:
-			if n, err := writer.Write([]byte{'\n'}); err != nil {
-				return written + n, err
-			} else {
-				written += n
-			}
-			if n, err := writer.Write([]byte(f.state.Identation)); err != nil {
-				return written + n, err
-			} else {
-				written += n
-			}
-			if n, err := writer.Write(p); err != nil {
-				return written + n, err
-			} else {
-				written += n
-			}
 		}
-	case "{":
-		switch string(p) {
-		case "}":
+		switch {
+		case token == "}" && f.state.LastToken != "{":
 			f.state.Identation = strings.Replace(f.state.Identation, options.Identation, "", 1)
-			if n, err := writer.Write([]byte{'\n'}); err != nil {
-				return written + n, err
-			} else {
-				written += n
-			}
-			if n, err := writer.Write([]byte(f.state.Identation)); err != nil {
-				return written + n, err
-			} else {
-				written += n
-			}
-			if n, err := writer.Write(p); err != nil {
-				return written + n, err
-			} else {
-				written += n
-			}
-		default:
+		case token != "}" && f.state.LastToken == "{" && !brace:
 			f.state.Identation += options.Identation
-			if n, err := writer.Write([]byte{'\n'}); err != nil {
-				return written + n, err
-			} else {
-				written += n
-			}
-			if n, err := writer.Write([]byte(f.state.Identation)); err != nil {
-				return written + n, err
-			} else {
-				written += n
-			}
-			if n, err := writer.Write(p); err != nil {
-				return written + n, err
-			} else {
-				written += n
+		}
+		if err := f.write(strings.Repeat("\n", f.state.BlankLines+1) + f.state.Identation); err != nil {
+			return 0, err
+		}
+	}
+	f.state.BlankLines = 0
+	if token == "{" && isTypeDeclaration(owner) {
+		f.state.Nodes[len(f.state.Nodes)-1].Body = true
+	}
+	f.state.Line = append(f.state.Line, FormatterToken{Text: token, Space: space && !brace, Node: owner})
+	f.track(token)
+	f.state.LastToken, f.state.LastNode, f.state.Inline = token, owner, f.inline()
+	f.state.LineComment, f.state.Break = false, false
+	if token == "}" && !f.state.Inline {
+		if err := f.Flush(); err != nil {
+			return 0, err
+		}
+	}
+	return n, nil
+}
+
+// Writes the node, laid out by the nodes its tokens belong to, and flushes the buffered tokens.
+func (f *Formatter) Format(node Node) error {
+	if _, err := writeNode(f, node); err != nil {
+		return err
+	}
+	return f.Flush()
+}
+
+// Implements [NodeWriter] interface for [Formatter].
+// The statements of a case are indented by one level more than its label.
+// The declarations of a compilation unit or a type body and the statements of a block or a case
+// are separated by the blank lines of [Formatter.blankLines].
+func (f *Formatter) BeginNode(node Node) {
+	if n := len(f.state.Nodes); n > 0 {
+		parent := &f.state.Nodes[n-1]
+		parent.Children++
+		_, statements := parent.Node.(StatementCase)
+		if statements && f.state.LastToken == ":" && kindOf(f.state.LastNode) == CASE {
+			parent.Indented = true
+			f.state.Indent++
+		}
+		switch kind := parent.Node.GetKind(); {
+		case kind == COMPILATION_UNIT, kind == BLOCK, statements && parent.Indented,
+			isTypeDeclaration(parent.Node) && parent.Body:
+			if parent.Member != nil {
+				f.state.BlankLines = f.blankLines(parent.Member, node)
 			}
+			parent.Member = node
+		}
+	}
+	f.state.Nodes = append(f.state.Nodes, FormatterNode{Node: node})
+}
+
+// Implements [NodeWriter] interface for [Formatter].
+// A declaration annotation of a type, a method or a field is followed by a line break.
+func (f *Formatter) EndNode(node Node) {
+	n := len(f.state.Nodes)
+	if n == 0 {
+		return
+	}
+	if f.state.Nodes[n-1].Indented {
+		f.state.Indent--
+	}
+	f.state.Nodes = f.state.Nodes[:n-1]
+	if node.GetKind() == ANNOTATION && n >= 3 && f.state.Nodes[n-2].Node.GetKind() == MODIFIERS {
+		switch declaration := f.state.Nodes[n-3].Node; declaration.GetKind() {
+		case CLASS, INTERFACE, ENUM, RECORD, ANNOTATION_TYPE, METHOD:
+			f.state.Break = true
+		case VARIABLE, VARIABLE_DECLARATION:
+			f.state.Break = n >= 4 && isTypeDeclaration(f.state.Nodes[n-4].Node)
+		}
+	}
+}
+
+// Returns the innermost node being written, or nil if it is not known.
+func (f *Formatter) owner() Node {
+	if n := len(f.state.Nodes); n > 0 {
+		return f.state.Nodes[n-1].Node
+	}
+	return nil
+}
+
+// Returns the number of blank lines between two consecutive declarations or statements:
+// the number of [FormatterOptions] for the declarations, or the number of blank lines between them in the source
+// if it is greater, up to [FormatterOptions.KeepBlankLines].
+func (f *Formatter) blankLines(previous, node Node) int {
+	options, lines := f.Options, 0
+	switch kind := node.GetKind(); {
+	case previous.GetKind() == PACKAGE:
+		lines = options.BlankLinesAfterPackage
+	case previous.GetKind() == IMPORT:
+		switch {
+		case kind != IMPORT:
+			lines = options.BlankLinesAfterImports
+		case options.Imports.Group(previous.(ImportNode)) != options.Imports.Group(node.(ImportNode)):
+			lines = options.BlankLinesBetweenImportGroups
+		}
+	case !isTypeDeclaration(f.owner()) && f.owner().GetKind() != COMPILATION_UNIT, kind == ENUM_CONSTANT:
+		// Statements are only separated by the blank lines kept from the source.
+	case isField(node) && isField(previous):
+		lines = options.BlankLinesBetweenFieldGroups
+		if sameFlags(previous.(fieldNode).GetModifiers(), node.(fieldNode).GetModifiers()) {
+			lines = options.BlankLinesBetweenFields
 		}
 	default:
-		switch string(p) {
-		case ".":
-			if n, err := writer.Write(p); err != nil {
-				return written + n, err
-			} else {
-				written += n
-			}
-		case ">":
-			if n, err := writer.Write(p); err != nil {
-				return written + n, err
-			} else {
-				written += n
+		lines = options.BlankLinesBetweenMembers
+	}
+	if f.FileSet == nil {
+		return lines
+	}
+	end, start := f.FileSet.Position(previous.GetEnd()), f.FileSet.Position(leadingPos(node))
+	if end.IsValid() && start.IsValid() {
+		lines = max(lines, min(start.Line-end.Line-1, options.KeepBlankLines))
+	}
+	return lines
+}
+
+// Returns the position of the first character of the node, including its leading and documentation comments.
+func leadingPos(node Node) Pos {
+	pos := node.GetPos()
+	if c, ok := node.(CommentedNode); ok {
+		comments := c.GetComments()
+		if comments.Doc != nil && comments.Doc.Pos.IsValid() {
+			pos = min(pos, comments.Doc.Pos)
+		}
+		if len(comments.Leading) > 0 && comments.Leading[0].Pos.IsValid() {
+			pos = min(pos, comments.Leading[0].Pos)
+		}
+	}
+	return pos
+}
+
+// A fieldNode is a declaration of one or more fields.
+type fieldNode interface {
+	Node
+	GetModifiers() ModifiersNode
+}
+
+// Reports whether the member of a type declaration declares fields.
+func isField(node Node) bool {
+	kind := node.GetKind()
+	return kind == VARIABLE || kind == VARIABLE_DECLARATION
+}
+
+// Reports whether the modifiers have the same flags.
+func sameFlags(a, b ModifiersNode) bool {
+	var x, y []Modifier
+	if a != nil {
+		x = a.GetFlags()
+	}
+	if b != nil {
+		y = b.GetFlags()
+	}
+	return slices.Equal(x, y)
+}
+
+// Returns the kind of the node, or -1 if it is nil.
+func kindOf(node Node) Kind {
+	if node == nil {
+		return -1
+	}
+	return node.GetKind()
+}
+
+// Reports whether the node is a type declaration.
+func isTypeDeclaration(node Node) bool {
+	switch kindOf(node) {
+	case CLASS, INTERFACE, ENUM, RECORD, ANNOTATION_TYPE:
+		return true
+	}
+	return false
+}
+
+// Reports whether the braces and semicolons of the innermost node do not end lines,
+// as those of array initializers, for loop headers and resources of try statements.
+func (f *Formatter) inline() bool {
+	switch kindOf(f.owner()) {
+	case NEW_ARRAY, FOR_LOOP, TRY:
+		return true
+	}
+	return false
+}
+
+// Writes the buffered tokens of the current line, wrapped to fit [FormatterOptions.LineLength].
+//
+// A line which does not fit is broken at the break points with the lowest level, and each part which still does not fit
+// is broken at its own lowest level, in the style of google-java-format. Break points are found after "," and "->",
+// before binary operators, and before "." in method chains. Their level is their nesting in parentheses, brackets and braces,
+// then commas and arrows before operators, which are ordered by precedence, and operators before dots.
+// Continuation lines are indented by [FormatterOptions.ContinuationIdentation] more than the line they continue.
+func (f *Formatter) Flush() error {
+	line := f.state.Line
+	f.state.Line = nil
+	if len(line) == 0 {
+		return nil
+	}
+	var b strings.Builder
+	column := f.state.Column
+	identation := f.state.Identation + f.continuation()
+	f.layout(&b, line, breakPoints(line), &column, identation, f.state.Identation)
+	return f.write(b.String())
+}
+
+// Writes the tokens to the builder, breaking the line at the break points of the lowest level if they do not fit.
+// The level of each token is the level of the break point before it, or -1 if there is none.
+// An array initializer which does not fit and is not enclosed in parentheses or brackets is broken instead,
+// with one element per line, indented by one level more than the line of its opening brace at base.
+func (f *Formatter) layout(b *strings.Builder, line []FormatterToken, levels []int, column *int, identation, base string) {
+	width := 0
+	for i, t := range line {
+		if t.Space && (i > 0 || *column > 0) {
+			width++
+		}
+		first, _, _ := strings.Cut(t.Text, "\n")
+		width += columns(0, first)
+	}
+	level := -1
+	for i := 1; i < len(levels); i++ {
+		if levels[i] >= 0 && (level < 0 || levels[i] < level) {
+			level = levels[i]
+		}
+	}
+	fits := f.Options.LineLength <= 0 || *column+width <= f.Options.LineLength
+	if open, close, nesting := initializer(line); !fits && open >= 0 && (level < 0 || nesting*breakLevels <= level) {
+		f.layout(b, line[:open+1], breakPoints(line[:open+1]), column, identation, base)
+		elements := base + f.Options.Identation
+		for start, i, depth := open+1, open+1, 0; i < close; i++ {
+			switch line[i].Text {
+			case "(", "[", "{":
+				depth++
+			case ")", "]", "}":
+				depth--
 			}
-		case "}":
-			f.state.Identation = strings.Replace(f.state.Identation, options.Identation, "", 1)
-			if n, err := writer.Write([]byte{'\n'}); err != nil {
-				return written + n, err
-			} else {
-				written += n
+			if i+1 < close && (depth > 0 || line[i].Text != ",") {
+				continue
 			}
-			if n, err := writer.Write([]byte(f.state.Identation)); err != nil {
-				return written + n, err
-			} else {
-				written += n
+			b.WriteString("\n" + elements)
+			*column = columns(0, elements)
+			line[start].Space = false
+			f.layout(b, line[start:i+1], breakPoints(line[start:i+1]), column, elements+f.continuation(), elements)
+			start = i + 1
+		}
+		b.WriteString("\n" + base)
+		*column = columns(0, base)
+		line[close].Space = false
+		f.layout(b, line[close:], breakPoints(line[close:]), column, identation, base)
+		return
+	}
+	if fits || level < 0 {
+		for _, t := range line {
+			if t.Space && *column > 0 {
+				b.WriteByte(' ')
+				*column++
 			}
-			if n, err := writer.Write(p); err != nil {
-				return written + n, err
-			} else {
-				written += n
+			b.WriteString(t.Text)
+			*column = columns(*column, t.Text)
+		}
+		return
+	}
+	start := 0
+	for i := 1; i <= len(line); i++ {
+		if i < len(line) && levels[i] != level {
+			continue
+		}
+		if start > 0 {
+			b.WriteString("\n" + identation)
+			*column = columns(0, identation)
+			line[start].Space = false
+			base = identation
+		}
+		f.layout(b, line[start:i], levels[start:i], column, identation+f.continuation(), base)
+		start = i
+	}
+}
+
+// Returns the indexes of the braces of the first non-empty array initializer of the line with the lowest nesting
+// in parentheses, brackets and braces, and its nesting, or -1 if there is none.
+func initializer(line []FormatterToken) (open, close, nesting int) {
+	open, close, nesting = -1, -1, -1
+	depth := 0
+	var opens []int
+	for i, t := range line {
+		switch t.Text {
+		case "(", "[", "{":
+			opens = append(opens, i)
+			depth++
+		case ")", "]", "}":
+			if len(opens) == 0 {
+				// The line continues a node which it does not start.
+				continue
 			}
-		default:
-			if len(f.state.LastToken) > 0 {
-				if n, err := writer.Write([]byte{' '}); err != nil {
-					return written + n, err
-				} else {
-					written += n
-				}
+			start := opens[len(opens)-1]
+			opens = opens[:len(opens)-1]
+			depth--
+			if t.Text == "}" && line[start].Text == "{" && i > start+1 && (nesting < 0 || depth < nesting) &&
+				(t.Node == nil || t.Node.GetKind() == NEW_ARRAY) {
+				open, close, nesting = start, i, depth
 			}
-			if n, err := writer.Write(p); err != nil {
-				return written + n, err
-			} else {
-				written += n
+		}
+	}
+	return
+}
+
+// Returns the level of the break point before each token of the line, or -1 if there is none.
+// There are no break points within type arguments and type parameters. The break point before "throws"
+// has the level of the commas between the parameters, and the commas between the thrown exceptions follow them.
+func breakPoints(line []FormatterToken) []int {
+	levels := make([]int, len(line))
+	depth, types, throws := 0, 0, false
+	for i, t := range line {
+		levels[i] = -1
+		switch {
+		case t.Text == ">" && delimiter(t.Node):
+			depth--
+			types--
+		case t.Text == ")" || t.Text == "]" || t.Text == "}" && depth > 0:
+			depth--
+		case (t.Text == "{" || t.Text == ";") && depth == 0:
+			throws = false
+		}
+		if i > 0 && types <= 0 {
+			switch previous := line[i-1].Text; {
+			case t.Text == "throws" && depth == 0:
+				levels[i] = breakLevels
+				throws = true
+			case previous == "," && throws && depth == 0:
+				levels[i] = breakLevels + 1
+			case previous == "," || previous == "->":
+				levels[i] = depth * breakLevels
+			case t.Text == "." && (previous == ")" || previous == "]"):
+				levels[i] = depth*breakLevels + breakLevels - 1
+			case binaryPrecedence[t.Text] > 0 && operator(t.Text, previous, t.Node):
+				levels[i] = depth*breakLevels + binaryPrecedence[t.Text]
 			}
 		}
+		switch {
+		case t.Text == "<" && delimiter(t.Node):
+			depth++
+			types++
+		case t.Text == "(" || t.Text == "[" || t.Text == "{":
+			depth++
+		}
+	}
+	return levels
+}
+
+// The number of break point levels at each nesting depth: commas and arrows, binary operators and dots.
+const breakLevels = 12
+
+// Reports whether the token can end an operand, so that an operator after it is binary rather than unary.
+func endsOperand(token string) bool {
+	switch token {
+	case ")", "]", "++", "--", "this", "super", "class":
+		return true
+	}
+	kind := tokenKind(token)
+	return kind == lexer.IDENTIFIER || kind.IsLiteral()
+}
+
+// Returns the kind of the token, or [lexer.EOF] if it is not a single token.
+func tokenKind(token string) lexer.Kind {
+	l, err := lexer.New([]byte(token))
+	if err != nil {
+		return lexer.EOF
+	}
+	if t, err := l.Next(); err == nil && t.End == len(token) {
+		return t.Kind
+	}
+	return lexer.EOF
+}
+
+// Reports whether the token would be read as a part of the previous one if it were not separated by a space.
+func merges(previous, token string) bool {
+	if previous == "" {
+		return false
+	}
+	l, err := lexer.New([]byte(previous + token))
+	if err != nil {
+		return false
+	}
+	t, err := l.Next()
+	return err == nil && t.End > len(previous)
+}
+
+// Writes the text and keeps track of the column of the current line.
+func (f *Formatter) write(s string) error {
+	if _, err := f.Writer.Write([]byte(s)); err != nil {
+		return err
+	}
+	f.state.Column = columns(f.state.Column, s)
+	return nil
+}
+
+// The number of columns of a tab.
+const tabWidth = 4
+
+// Returns the column after writing the text at the column, with tab stops every [tabWidth] columns.
+func columns(column int, s string) int {
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		column, s = 0, s[i+1:]
+	}
+	for _, ch := range s {
+		if ch == '\t' {
+			column += tabWidth - column%tabWidth
+		} else {
+			column++
+		}
+	}
+	return column
+}
+
+// Returns the indentation of continuation lines.
+func (f *Formatter) continuation() string {
+	if f.Options.ContinuationIdentation != "" {
+		return f.Options.ContinuationIdentation
+	}
+	return f.Options.Identation + f.Options.Identation
+}
+
+// Keywords which are followed by a space before an opening parenthesis.
+var parenthesizedKeywords = map[string]bool{
+	"if": true, "for": true, "while": true, "switch": true, "catch": true, "synchronized": true, "try": true,
+	"return": true, "throw": true, "assert": true, "yield": true, "case": true, "else": true, "do": true,
+}
+
+// Operators which are spaced if [FormatterOptions.SpaceAroundOperators] is set, in addition to binary operators.
+var spacedOperators = map[string]bool{
+	"=": true, "*=": true, "/=": true, "%=": true, "+=": true, "-=": true, "<<=": true, ">>=": true, ">>>=": true,
+	"&=": true, "^=": true, "|=": true, "?": true, ":": true, "->": true,
+}
+
+// Reports whether the token is separated from the previous one by a space, as in Java source.
+// The spacing depends on the nodes the tokens belong to, or is guessed from the tokens if the nodes are not known.
+func (f *Formatter) javaSpace(token string) bool {
+	previous, owner, last := f.state.LastToken, f.owner(), f.state.LastNode
+	switch {
+	case previous == "":
+		return false
+	case token == "," || token == ";" || token == ")" || token == "]" || token == "[" || token == "." ||
+		token == "[]" || token == "::" || token == "..." || token == ">" && delimiter(owner):
+		return false
+	case previous == "(" || previous == "[" || previous == "." || previous == "::" || previous == "@" ||
+		previous == "<" && delimiter(last) || f.state.Unary:
+		return false
+	case previous == "{" && kindOf(last) == NEW_ARRAY || token == "}" && kindOf(owner) == NEW_ARRAY:
+		return false
+	case (token == "++" || token == "--") && postfix(previous, owner):
+		return false
+	case f.state.Cast && startsOperand(token):
+		return f.Options.SpaceAfterCast
+	case token == "<" && delimiter(owner):
+		// Type arguments follow a type name, and type parameters of a method follow its modifiers.
+		return tokenKind(previous) != lexer.IDENTIFIER
+	case previous == ">" && delimiter(last):
+		// Type arguments of a method invocation precede its name.
+		return token != "(" && token != ">" && kindOf(last) != METHOD_INVOCATION
+	case token == ":" && label(f.state.Line, owner):
+		return false
+	case operator(token, previous, owner) || f.state.Binary:
+		return f.Options.SpaceAroundOperators
+	case token == "(":
+		return parenthesizedKeywords[previous] || !endsOperand(previous) || previous == ")"
+	}
+	return true
+}
+
+// Reports whether "<" or ">" of the node delimits type arguments or type parameters, rather than compares operands.
+// Without the node, it is assumed to delimit them.
+func delimiter(node Node) bool {
+	switch kindOf(node) {
+	case LESS_THAN, GREATER_THAN:
+		return false
+	}
+	return true
+}
+
+// Reports whether "++" or "--" of the node after the previous token is a postfix operator.
+func postfix(previous string, node Node) bool {
+	switch kindOf(node) {
+	case -1:
+		return endsOperand(previous)
+	case POSTFIX_INCREMENT, POSTFIX_DECREMENT:
+		return true
+	}
+	return false
+}
+
+// Reports whether a ":" of the node ends a case label or a statement label, rather than separates operands.
+// Without the node, it is guessed from the tokens of the line before it.
+func label(line []FormatterToken, node Node) bool {
+	switch kindOf(node) {
+	case CASE, LABELED_STATEMENT:
+		return true
+	case -1:
+	default:
+		return false
+	}
+	if len(line) == 1 {
+		return true
+	}
+	if len(line) == 0 || line[0].Text != "case" && line[0].Text != "default" {
+		return false
+	}
+	conditionals := 0
+	for _, t := range line {
+		switch {
+		case t.Text == "?":
+			conditionals++
+		case t.Text == ":" && conditionals > 0:
+			conditionals--
+		}
 	}
-	f.state.LastToken = string(p)
-	return written, nil
+	return conditionals == 0
+}
+
+// Reports whether the token of the node is an operator which separates operands, such as a binary operator,
+// an assignment, "?" and ":" of a conditional expression, or "->" of a lambda expression.
+// Without the node, it is guessed from the previous token.
+func operator(token, previous string, node Node) bool {
+	switch token {
+	case "=", "->":
+		return true
+	case "(", ")":
+		return false
+	}
+	switch node.(type) {
+	case nil:
+		return spacedOperators[token] ||
+			binaryPrecedence[token] > 0 && token != "<" && token != ">" && token != "instanceof" && endsOperand(previous)
+	case BinaryNode, AssignmentNode, CompoundAssignmentNode:
+		return true
+	}
+	switch node.GetKind() {
+	case CONDITIONAL_EXPRESSION:
+		return token == "?" || token == ":"
+	case ENHANCED_FOR_LOOP, ASSERT:
+		return token == ":"
+	case INTERSECTION_TYPE:
+		return token == "&"
+	case UNION_TYPE:
+		return token == "|"
+	}
+	return false
+}
+
+// Reports whether the token can start an operand, so that a parenthesized type before it is a cast.
+func startsOperand(token string) bool {
+	switch token {
+	case "(", "!", "~", "new", "this", "super", "switch":
+		return true
+	}
+	kind := tokenKind(token)
+	return kind == lexer.IDENTIFIER || kind.IsLiteral()
+}
+
+// Reports whether an opening brace after the token opens an array initializer rather than a block.
+func opensInitializer(previous string) bool {
+	switch previous {
+	case "=", "]", "[]", ",", "{", "(":
+		return true
+	}
+	return false
+}
+
+// Keeps track of unary and binary operators and of the parentheses which enclose the type of a cast,
+// from the nodes of the tokens, or guessed from the tokens if the nodes are not known.
+func (f *Formatter) track(token string) {
+	previous, owner := f.state.LastToken, f.owner()
+	f.state.Unary, f.state.Binary, f.state.Cast = false, false, false
+	if owner != nil {
+		switch owner.GetKind() {
+		case PREFIX_INCREMENT, PREFIX_DECREMENT, UNARY_PLUS, UNARY_MINUS, BITWISE_COMPLEMENT, LOGICAL_COMPLEMENT:
+			f.state.Unary = token != "(" && token != ")"
+		case TYPE_CAST:
+			// The type of a cast is its first child, which is followed by the closing parenthesis of the cast.
+			f.state.Cast = token == ")" && f.state.Nodes[len(f.state.Nodes)-1].Children == 1
+		}
+		f.state.Binary = operator(token, previous, owner)
+		return
+	}
+	switch token {
+	case "+", "-", "++", "--", "!", "~":
+		f.state.Unary = !endsOperand(previous)
+		f.state.Binary = (token == "+" || token == "-") && !f.state.Unary
+	case "(":
+		f.state.Parens = append(f.state.Parens, !endsOperand(previous) && !parenthesizedKeywords[previous])
+		return
+	case ")":
+		if n := len(f.state.Parens); n > 0 {
+			f.state.Cast = f.state.Parens[n-1] && previous != "("
+			f.state.Parens = f.state.Parens[:n-1]
+		}
+		return
+	default:
+		f.state.Binary = operator(token, previous, nil)
+	}
+	// A cast encloses a type, which consists of names, type arguments, array brackets and intersections.
+	if n := len(f.state.Parens); n > 0 && f.state.Parens[n-1] {
+		switch token {
+		case ".", "<", ">", "?", ",", "[", "]", "&", "@", "extends", "super":
+		default:
+			_, primitive := primitiveTypes[token]
+			f.state.Parens[n-1] = primitive || tokenKind(token) == lexer.IDENTIFIER
+		}
+	}
+}
+
+// Indents the lines of a text block one level deeper than the line it starts on.
+// The indentation is incidental white space, which is not a part of the value of the text block.
+func (f *Formatter) indentTextBlock(p []byte) []byte {
+	identation := f.state.Identation + f.Options.Identation
+	if f.state.LastToken == "{" {
+		identation += f.Options.Identation
+	}
+	lines := bytes.Split(p, []byte("\n"))
+	for i := 1; i < len(lines); i++ {
+		if len(lines[i]) > 0 {
+			lines[i] = append([]byte(identation), lines[i]...)
+		}
+	}
+	return bytes.Join(lines, []byte("\n"))
 }
 
 type FormatterOptions struct {
-	Identation string
-	LineLength int
+	Identation             string
+	LineLength             int
+	ContinuationIdentation string // The indentation of continuation lines, or two levels of Identation if empty.
+	NextLineBraces         bool   // Whether an opening brace of a block starts a line of its own.
+	JavaSpacing            bool   // Whether tokens are spaced as in Java source, rather than all separated by spaces.
+	SpaceAroundOperators   bool   // Whether binary, assignment and lambda operators are surrounded by spaces with JavaSpacing.
+	SpaceAfterCast         bool   // Whether a cast is separated from its operand by a space with JavaSpacing.
+
+	BlankLinesAfterPackage        int           // The number of blank lines after the package declaration.
+	BlankLinesAfterImports        int           // The number of blank lines after the import declarations.
+	BlankLinesBetweenImportGroups int           // The number of blank lines between the import groups of Imports.
+	BlankLinesBetweenMembers      int           // The number of blank lines around methods, constructors, initializers and types.
+	BlankLinesBetweenFields       int           // The number of blank lines between fields with the same modifiers.
+	BlankLinesBetweenFieldGroups  int           // The number of blank lines between fields with different modifiers.
+	KeepBlankLines                int           // The maximum number of blank lines kept from the source, if [Formatter.FileSet] is set.
+	Imports                       ImportOptions // The grouping of import declarations, as [OrganizeImports] orders them.
 }
 
 type FormatterState struct {
-	Identation string
-	LastToken  string
+	Identation  string
+	LastToken   string
+	LineComment bool
+	Column      int              // The column of the current line, after the written text.
+	Line        []FormatterToken // The buffered tokens of the current line.
+	LastNode    Node             // The innermost node of the last token, or nil if it is not known.
+	Inline      bool             // Whether the last token is a brace or a semicolon which does not end a line.
+	Nodes       []FormatterNode  // The nodes being written, from the outermost to the innermost.
+	Indent      int              // The change of the indentation level at the next line.
+	Break       bool             // Whether the next token starts a new line.
+	Unary       bool             // Whether the last token is a unary operator.
+	Binary      bool             // Whether the last token is an operator which separates operands.
+	Cast        bool             // Whether the last token closes parentheses which enclose the type of a cast.
+	Parens      []bool           // For each open parenthesis, whether it may enclose the type of a cast.
+	BlankLines  int              // The number of blank lines before the next line.
+}
+
+// A FormatterNode is a node being written to [Formatter].
+type FormatterNode struct {
+	Node     Node
+	Children int  // The number of child nodes written so far.
+	Indented bool // Whether the indentation level was increased for the child nodes.
+	Body     bool // Whether the body of a type declaration was opened.
+	Member   Node // The last declaration or statement written as a child node, or nil if there is none.
+}
+
+// A FormatterToken is a token buffered by [Formatter].
+type FormatterToken struct {
+	Text  string
+	Space bool // Whether the token is separated from the previous one by a space.
+	Node  Node // The innermost node of the token, or nil if it is not known.
+}
+
+// Implements [CommentWriter] interface for [Formatter].
+// A leading comment is written on a line of its own, and a trailing comment at the end of the current line.
+// The continuation lines of a block comment are indented to the current level.
+func (f *Formatter) WriteComment(p []byte, trailing bool) (int, error) {
+	options := f.Options
+	if err := f.Flush(); err != nil {
+		return 0, err
+	}
+	var prefix string
+	switch {
+	case trailing && !f.state.LineComment:
+		prefix = " "
+	case len(f.state.LastToken) == 0 && !f.state.LineComment:
+	case f.state.LastToken == "{" && !f.state.LineComment:
+		f.state.Identation += options.Identation
+		prefix = "\n" + f.state.Identation
+	default:
+		prefix = strings.Repeat("\n", f.state.BlankLines+1) + f.state.Identation
+	}
+	f.state.BlankLines = 0
+	lines := strings.Split(string(p), "\n")
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimLeft(lines[i], " \t")
+		if strings.HasPrefix(line, "*") {
+			line = " " + line
+		}
+		lines[i] = f.state.Identation + line
+	}
+	if err := f.write(prefix + strings.Join(lines, "\n")); err != nil {
+		return 0, err
+	}
+	f.state.LineComment = bytes.HasPrefix(p, []byte("//"))
+	if !trailing || f.state.LineComment {
+		// The next token starts a new line, as it does after a statement.
+		f.state.LastToken = ";"
+	}
+	return len(p), nil
 }
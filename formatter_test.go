@@ -2,6 +2,7 @@ package javast_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/kapavkin/javast"
@@ -64,3 +65,723 @@ func TestFormatter_Write(t *testing.T) {
 		t.Errorf("string(buf) = %s, want %s", got, want)
 	}
 }
+
+func TestFormatter_WriteComment(t *testing.T) {
+	t.Parallel()
+	var buf []byte
+	formatter := javast.Formatter{
+		Writer: javast.WriterFunc(
+			func(p []byte) (int, error) {
+				n := len(p)
+				buf = append(buf, p...)
+				return n, nil
+			},
+		),
+		Options: javast.FormatterOptions{
+			Identation: javast.Identation,
+			LineLength: javast.LineLength,
+		},
+	}
+	node := javast.Class{
+		Comments: javast.Comments{
+			Doc: &javast.DocComment{
+				Description: "Prints.",
+				Tags: []javast.DocTag{
+					{Name: "see", Text: "Printer"},
+				},
+			},
+		},
+		Modifiers:  javast.Modifiers{},
+		SimpleName: "PrettyPrinter",
+		Members: []javast.Node{
+			javast.Variable{
+				Comments: javast.Comments{
+					Leading:  []javast.Comment{{Text: "// Lines."}},
+					Trailing: []javast.Comment{{Text: "// Zero based."}},
+				},
+				Modifiers: javast.Modifiers{},
+				Name:      "line",
+				Type:      javast.PrimitiveType{PrimitiveTypeKind: javast.INT_TYPE_KIND},
+			},
+		},
+	}
+	if _, err := node.WriteTo(&formatter); err != nil {
+		t.Error(err)
+	}
+	got := string(buf)
+	want := `/**
+ * Prints.
+ *
+ * @see Printer
+ */
+class PrettyPrinter {
+    // Lines.
+    int line ; // Zero based.
+}`
+	if got != want {
+		t.Errorf("string(buf) = %s, want %s", got, want)
+	}
+}
+
+func TestFormatter_TextBlock(t *testing.T) {
+	t.Parallel()
+	var buf []byte
+	formatter := javast.Formatter{
+		Writer: javast.WriterFunc(
+			func(p []byte) (int, error) {
+				n := len(p)
+				buf = append(buf, p...)
+				return n, nil
+			},
+		),
+		Options: javast.FormatterOptions{
+			Identation: javast.Identation,
+			LineLength: javast.LineLength,
+		},
+	}
+	node := javast.Class{
+		Modifiers:  javast.Modifiers{},
+		SimpleName: "Queries",
+		Members: []javast.Node{
+			javast.Variable{
+				Modifiers: javast.Modifiers{},
+				Name:      "SELECT",
+				Type:      javast.Identifier{Name: "String"},
+				Initializer: javast.TextBlockLiteral{
+					Value: "SELECT *\n  FROM t\n\nWHERE a = \"b\"\n",
+				},
+			},
+		},
+	}
+	if _, err := node.WriteTo(&formatter); err != nil {
+		t.Error(err)
+	}
+	got := string(buf)
+	want := `class Queries {
+    String SELECT = """
+        SELECT *
+          FROM t
+
+        WHERE a = "b"
+        """ ;
+}`
+	if got != want {
+		t.Errorf("string(buf) = %s, want %s", got, want)
+	}
+}
+
+func TestFormatter_LineLength(t *testing.T) {
+	t.Parallel()
+	var buf []byte
+	formatter := javast.Formatter{
+		Writer: javast.WriterFunc(
+			func(p []byte) (int, error) {
+				n := len(p)
+				buf = append(buf, p...)
+				return n, nil
+			},
+		),
+		Options: javast.FormatterOptions{
+			Identation: javast.Identation,
+			LineLength: 50,
+		},
+	}
+	src := `class Greeter {
+    String greeting = builder.append(name).append(title).build();
+    boolean valid = count > 0 && names.contains(name) || title == null;
+    Runnable task = () -> print(first + second, third + fourth, fifth);
+    int sum = a + b;
+}`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cu.WriteTo(&formatter); err != nil {
+		t.Error(err)
+	}
+	if err := formatter.Flush(); err != nil {
+		t.Error(err)
+	}
+	got := string(buf)
+	want := `class Greeter {
+    String greeting = builder.append ( name )
+            .append ( title )
+            .build ( ) ;
+    boolean valid = count > 0
+                    && names.contains ( name )
+            || title == null ;
+    Runnable task = ( ) ->
+            print ( first + second ,
+                    third + fourth ,
+                    fifth ) ;
+    int sum = a + b ;
+}`
+	if got != want {
+		t.Errorf("string(buf) = %s, want %s", got, want)
+	}
+}
+
+func TestFormatter_Profiles(t *testing.T) {
+	t.Parallel()
+	src := `class Counter {
+    int count;
+    void add(Object value, long limit) {
+        if (count >= 0 && !(value == null)) {
+            count += (int) limit - 1;
+        } else {
+            throw new IllegalStateException("count: " + count);
+        }
+    }
+}`
+	tests := []struct {
+		name    string
+		options javast.FormatterOptions
+		want    string
+	}{
+		{"GoogleStyle", javast.GoogleStyle, `class Counter {
+  int count;
+
+  void add(Object value, long limit) {
+    if (count >= 0 && !(value == null)) {
+      count += (int) limit - 1;
+    } else {
+      throw new IllegalStateException("count: " + count);
+    }
+  }
+}`},
+		{"EclipseStyle", javast.EclipseStyle, "class Counter {\n" +
+			"\tint count;\n" +
+			"\n" +
+			"\tvoid add(Object value, long limit) {\n" +
+			"\t\tif (count >= 0 && !(value == null)) {\n" +
+			"\t\t\tcount += (int) limit - 1;\n" +
+			"\t\t} else {\n" +
+			"\t\t\tthrow new IllegalStateException(\"count: \" + count);\n" +
+			"\t\t}\n" +
+			"\t}\n" +
+			"}"},
+		{"NextLineBraces", javast.FormatterOptions{
+			Identation:  "    ",
+			LineLength:  80,
+			JavaSpacing: true, NextLineBraces: true,
+		}, `class Counter
+{
+    int count;
+    void add(Object value, long limit)
+    {
+        if (count>=0&&!(value==null))
+        {
+            count+=(int)limit-1;
+        }
+        else
+        {
+            throw new IllegalStateException("count: "+count);
+        }
+    }
+}`},
+	}
+	for _, test := range tests {
+		cu, err := javast.Parse(strings.NewReader(src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf []byte
+		formatter := javast.Formatter{
+			Writer: javast.WriterFunc(
+				func(p []byte) (int, error) {
+					n := len(p)
+					buf = append(buf, p...)
+					return n, nil
+				},
+			),
+			Options: test.options,
+		}
+		if _, err := cu.WriteTo(&formatter); err != nil {
+			t.Error(err)
+		}
+		got := string(buf)
+		if got != test.want {
+			t.Errorf("%s: string(buf) = %s, want %s", test.name, got, test.want)
+		}
+	}
+}
+
+func TestFormatter_Format(t *testing.T) {
+	t.Parallel()
+	var buf []byte
+	formatter := javast.Formatter{
+		Writer: javast.WriterFunc(
+			func(p []byte) (int, error) {
+				n := len(p)
+				buf = append(buf, p...)
+				return n, nil
+			},
+		),
+		Options: javast.GoogleStyle,
+	}
+	src := `@Deprecated public class Cache<K extends Comparable<K>, V> implements Store<K, List<V>> {
+    @Inject private Map<K, List<V>> entries = new HashMap<>();
+    int sum(int[] values) {
+        int[][] grid = {{1}, {2, 3}};
+        for (int i = 0; i < values.length && i >= 0; i++) { total += (int) values[i]; }
+        switch (total) { case 0: case 1: return -total; default: return Collections.<Integer>max(list); }
+    }
+}`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := formatter.Format(cu); err != nil {
+		t.Error(err)
+	}
+	got := string(buf)
+	want := `@Deprecated
+public class Cache<K extends Comparable<K>, V> implements Store<K, List<V>> {
+  @Inject
+  private Map<K, List<V>> entries = new HashMap<>();
+
+  int sum(int[] values) {
+    int[][] grid = {{1}, {2, 3}};
+    for (int i = 0; i < values.length && i >= 0; i++) {
+      total += (int) values[i];
+    }
+    switch (total) {
+      case 0:
+      case 1:
+        return -total;
+      default:
+        return Collections.<Integer>max(list);
+    }
+  }
+}`
+	if got != want {
+		t.Errorf("string(buf) = %s, want %s", got, want)
+	}
+}
+
+func TestFormatter_BlankLines(t *testing.T) {
+	t.Parallel()
+	src := `package com.example;
+import java.util.List;
+import java.util.Map;
+class Registry {
+    static final int LIMIT = 10;
+    static final int SIZE = 20;
+    private List<String> names;
+    Registry() {}
+    void register(String name) {
+        names.add(name);
+
+
+        check();
+    }
+}
+interface Store {}
+`
+	tests := []struct {
+		name    string
+		options javast.FormatterOptions
+		keep    bool
+		want    string
+	}{
+		{"GoogleStyle", javast.GoogleStyle, false, `package com.example;
+
+import java.util.List;
+import java.util.Map;
+
+class Registry {
+  static final int LIMIT = 10;
+  static final int SIZE = 20;
+
+  private List<String> names;
+
+  Registry() {
+  }
+
+  void register(String name) {
+    names.add(name);
+    check();
+  }
+}
+
+interface Store {
+}`},
+		{"KeepBlankLines", javast.FormatterOptions{
+			Identation:               "  ",
+			LineLength:               100,
+			JavaSpacing:              true,
+			SpaceAroundOperators:     true,
+			BlankLinesAfterImports:   2,
+			BlankLinesBetweenMembers: 1,
+			KeepBlankLines:           1,
+		}, true, `package com.example;
+import java.util.List;
+import java.util.Map;
+
+
+class Registry {
+  static final int LIMIT = 10;
+  static final int SIZE = 20;
+  private List<String> names;
+
+  Registry() {
+  }
+
+  void register(String name) {
+    names.add(name);
+
+    check();
+  }
+}
+
+interface Store {
+}`},
+	}
+	for _, test := range tests {
+		fset := javast.NewFileSet()
+		cu, err := javast.ParseFile(fset, "Registry.java", []byte(src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf []byte
+		formatter := javast.Formatter{
+			Writer: javast.WriterFunc(
+				func(p []byte) (int, error) {
+					n := len(p)
+					buf = append(buf, p...)
+					return n, nil
+				},
+			),
+			Options: test.options,
+		}
+		if test.keep {
+			formatter.FileSet = fset
+		}
+		if err := formatter.Format(cu); err != nil {
+			t.Error(err)
+		}
+		got := string(buf)
+		if got != test.want {
+			t.Errorf("%s: string(buf) = %s, want %s", test.name, got, test.want)
+		}
+	}
+}
+
+func TestFormatter_ArrayInitializers(t *testing.T) {
+	t.Parallel()
+	var buf []byte
+	formatter := javast.Formatter{
+		Writer: javast.WriterFunc(
+			func(p []byte) (int, error) {
+				n := len(p)
+				buf = append(buf, p...)
+				return n, nil
+			},
+		),
+		Options: javast.GoogleStyle,
+	}
+	formatter.Options.LineLength = 60
+	src := `@SuppressWarnings({"unchecked", "rawtypes"})
+class Tables {
+  static final String[] NAMES = {"alpha", "beta", "gamma", "delta", "epsilon", "zeta"};
+  static final int[][] GRID = {{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, {13, 14, 15, 16, 17, 18, 19, 20}};
+  int[] small = {1, 2};
+  void print() {
+    print(new String[] {"alpha", "beta", "gamma", "delta", "epsilon", "zeta"}, out);
+  }
+}`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := formatter.Format(cu); err != nil {
+		t.Error(err)
+	}
+	got := string(buf)
+	want := `@SuppressWarnings({"unchecked", "rawtypes"})
+class Tables {
+  static final String[] NAMES = {
+    "alpha",
+    "beta",
+    "gamma",
+    "delta",
+    "epsilon",
+    "zeta"
+  };
+  static final int[][] GRID = {
+    {1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+    {13, 14, 15, 16, 17, 18, 19, 20}
+  };
+
+  int[] small = {1, 2};
+
+  void print() {
+    print(new String[] {
+      "alpha",
+      "beta",
+      "gamma",
+      "delta",
+      "epsilon",
+      "zeta"
+    }, out);
+  }
+}`
+	if got != want {
+		t.Errorf("string(buf) = %s, want %s", got, want)
+	}
+}
+
+func TestFormatter_Initializers(t *testing.T) {
+	t.Parallel()
+	var buf []byte
+	formatter := javast.Formatter{
+		Writer: javast.WriterFunc(
+			func(p []byte) (int, error) {
+				n := len(p)
+				buf = append(buf, p...)
+				return n, nil
+			},
+		),
+		Options: javast.GoogleStyle,
+	}
+	src := `class Registry { static int size; static { size = load(); } { count = 0; }
+enum Kind { A, B; static { init(); } }
+record Entry(String key) { static { init(); } }
+}`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := formatter.Format(cu); err != nil {
+		t.Error(err)
+	}
+	got := string(buf)
+	want := `class Registry {
+  static int size;
+
+  static {
+    size = load();
+  }
+
+  {
+    count = 0;
+  }
+
+  enum Kind {
+    A, B;
+
+    static {
+      init();
+    }
+  }
+
+  record Entry(String key) {
+    static {
+      init();
+    }
+  }
+}`
+	if got != want {
+		t.Errorf("string(buf) = %s, want %s", got, want)
+	}
+}
+
+func TestFormatter_UnaryOperators(t *testing.T) {
+	t.Parallel()
+	src := `class Signs {
+  int f(int k) {
+    return - -k + + +k - - --k;
+  }
+}`
+	for _, options := range []javast.FormatterOptions{javast.GoogleStyle, {Identation: "  "}} {
+		var buf []byte
+		formatter := javast.Formatter{
+			Writer: javast.WriterFunc(
+				func(p []byte) (int, error) {
+					n := len(p)
+					buf = append(buf, p...)
+					return n, nil
+				},
+			),
+			Options: options,
+		}
+		cu, err := javast.Parse(strings.NewReader(src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := formatter.Format(cu); err != nil {
+			t.Error(err)
+		}
+		reparsed, err := javast.Parse(strings.NewReader(string(buf)))
+		if err != nil {
+			t.Fatalf("Parse(%s) = %v", buf, err)
+		}
+		want, got := SpaceWriter{}, SpaceWriter{}
+		if _, err := cu.WriteTo(&want); err != nil {
+			t.Error(err)
+		}
+		if _, err := reparsed.WriteTo(&got); err != nil {
+			t.Error(err)
+		}
+		if got.String() != want.String() {
+			t.Errorf("string(buf) = %s, reparsed as %s, want %s", buf, got.String(), want.String())
+		}
+	}
+}
+
+func TestFormatter_DanglingComments(t *testing.T) {
+	t.Parallel()
+	var buf []byte
+	formatter := javast.Formatter{
+		Writer: javast.WriterFunc(
+			func(p []byte) (int, error) {
+				n := len(p)
+				buf = append(buf, p...)
+				return n, nil
+			},
+		),
+		Options: javast.GoogleStyle,
+	}
+	src := `class Counter {
+    void add(int n) {
+        count += n;
+        // Done.
+    }
+    void reset() { /* Nothing. */ }
+    int sign(int n) {
+        switch (n) {
+        case 0:
+            return 0;
+            // Zero.
+        }
+        return 1;
+    }
+    // More members.
+}`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := formatter.Format(cu); err != nil {
+		t.Error(err)
+	}
+	got := string(buf)
+	want := `class Counter {
+  void add(int n) {
+    count += n;
+    // Done.
+  }
+
+  void reset() {
+    /* Nothing. */
+  }
+
+  int sign(int n) {
+    switch (n) {
+      case 0:
+        return 0;
+        // Zero.
+    }
+    return 1;
+  }
+  // More members.
+}`
+	if got != want {
+		t.Errorf("string(buf) = %s, want %s", got, want)
+	}
+}
+
+func TestFormatter_Comparisons(t *testing.T) {
+	t.Parallel()
+	src := `class Range {
+  boolean contains(List<Integer> xs, int x, int y, int z) {
+    return x < y && y > z;
+  }
+}`
+	for _, test := range []struct {
+		options javast.FormatterOptions
+		want    string
+	}{
+		{javast.GoogleStyle, src},
+		{javast.FormatterOptions{Identation: "  "}, `class Range {
+  boolean contains ( List <Integer> xs , int x , int y , int z ) {
+    return x < y && y > z ;
+  }
+}`},
+	} {
+		var buf []byte
+		formatter := javast.Formatter{
+			Writer: javast.WriterFunc(
+				func(p []byte) (int, error) {
+					n := len(p)
+					buf = append(buf, p...)
+					return n, nil
+				},
+			),
+			Options: test.options,
+		}
+		cu, err := javast.Parse(strings.NewReader(src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := formatter.Format(cu); err != nil {
+			t.Error(err)
+		}
+		if got := string(buf); got != test.want {
+			t.Errorf("string(buf) = %s, want %s", got, test.want)
+		}
+	}
+}
+
+func TestFormatter_Signatures(t *testing.T) {
+	t.Parallel()
+	var buf []byte
+	formatter := javast.Formatter{
+		Writer: javast.WriterFunc(
+			func(p []byte) (int, error) {
+				n := len(p)
+				buf = append(buf, p...)
+				return n, nil
+			},
+		),
+		Options: javast.FormatterOptions{
+			Identation:             "  ",
+			ContinuationIdentation: "    ",
+			LineLength:             60,
+			JavaSpacing:            true,
+			SpaceAroundOperators:   true,
+		},
+	}
+	src := `class Loader {
+  public static Map<String, List<Integer>> load(String name, int size, boolean strict) throws IOException, InterruptedException {
+    return read(name);
+  }
+  void run() throws IOException, InterruptedException, TimeoutException, ExecutionException {
+  }
+}`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := formatter.Format(cu); err != nil {
+		t.Error(err)
+	}
+	got := string(buf)
+	want := `class Loader {
+  public static Map<String, List<Integer>> load(String name,
+      int size,
+      boolean strict)
+      throws IOException, InterruptedException {
+    return read(name);
+  }
+  void run()
+      throws IOException,
+          InterruptedException,
+          TimeoutException,
+          ExecutionException {
+  }
+}`
+	if got != want {
+		t.Errorf("string(buf) = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,456 @@
+package javast
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// ImportOptions configure how [OrganizeImports] orders and groups import declarations.
+// The zero value places static imports in a group before non-static ones and never replaces single-type imports
+// with on-demand ones, as the Google Java Style does.
+type ImportOptions struct {
+	// The package prefixes of the import groups in order, such as "java" and "javax".
+	// An import belongs to the group of the longest prefix which matches it. Imports which match no prefix belong
+	// to the group of the empty prefix, or to a group after the others if the empty prefix is not listed.
+	Groups            []string
+	StaticLast        bool // Whether static imports follow non-static ones, rather than precede them.
+	WildcardThreshold int  // The number of single-type imports of a package or type replaced with an on-demand import, or 0.
+	ExpandWildcards   bool // Whether an on-demand import which supplies fewer than WildcardThreshold names is expanded.
+	// Returns the simple names of the types of a package, or of the static members of a type, such as "java.util",
+	// which an on-demand import of it supplies, or nil if they are unknown. On-demand imports are only expanded
+	// or removed if their names are known.
+	Members func(name string, static bool) []string
+}
+
+// Returns the group of the import declaration, the groups ordered as they are written.
+func (o ImportOptions) Group(i ImportNode) int {
+	name, group, prefix := importName(i), len(o.Groups), -1
+	for g, p := range o.Groups {
+		if (p == "" || name == p || strings.HasPrefix(name, p+".")) && len(p) > prefix {
+			group, prefix = g, len(p)
+		}
+	}
+	if i.IsStatic() != o.StaticLast {
+		return group
+	}
+	return group + len(o.Groups) + 1
+}
+
+// Returns the compilation unit with its import declarations organized:
+//
+//   - duplicate imports and single-type imports which nothing in the type declarations references are removed;
+//   - single-type imports of the same package or type are replaced with an on-demand import
+//     if there are at least WildcardThreshold of them;
+//   - an on-demand import is expanded into single-type imports of the names it supplies if ExpandWildcards is set
+//     and there are fewer than WildcardThreshold of them, or removed if it supplies none, provided that
+//     [ImportOptions.Members] knows the names of its package or type;
+//   - the imports are sorted by their group of [ImportOptions.Group] and then by their names.
+//
+// References are found by simple names, including the names in documentation comments.
+// A name is supplied by an on-demand import if it is a member of its package or type,
+// and the name is neither declared in the compilation unit, nor imported otherwise, nor a type of java.lang.
+func OrganizeImports(cu CompilationUnitNode, options ImportOptions) CompilationUnit {
+	used, declared := referencedNames(cu.GetTypeDecls())
+	var imports []ImportNode
+	seen := map[string]bool{}
+	singles := map[string]bool{}
+	for _, i := range cu.GetImports() {
+		name := importName(i)
+		key := importKey(name, i.IsStatic())
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if !strings.HasSuffix(name, ".*") {
+			if !used[lastName(name)] {
+				continue
+			}
+			singles[lastName(name)] = true
+		}
+		imports = append(imports, i)
+	}
+	imports = expandWildcards(imports, options, used, declared, singles)
+	imports = collapseWildcards(imports, options)
+	slices.SortStableFunc(imports, func(a, b ImportNode) int {
+		if ga, gb := options.Group(a), options.Group(b); ga != gb {
+			return ga - gb
+		}
+		return strings.Compare(importName(a), importName(b))
+	})
+	return CompilationUnit{
+		Span:      Span{Pos: cu.GetPos(), End: cu.GetEnd()},
+		Module:    cu.GetModule(),
+		Package:   cu.GetPackage(),
+		Imports:   imports,
+		TypeDecls: cu.GetTypeDecls(),
+	}
+}
+
+// Returns a single-type or on-demand import declaration of the qualified name, such as "java.util.List" or "java.util.*".
+func NewImport(name string, static bool) Import {
+	parts := strings.Split(name, ".")
+	var qualified ExpressionNode = Identifier{Name: parts[0]}
+	for _, part := range parts[1:] {
+		qualified = MemberSelect{Expression: qualified, Identifier: part}
+	}
+	return Import{Static: static, QualifiedIdentifier: qualified}
+}
+
+// Returns the compilation unit with the fully qualified names of types in its type declarations,
+// such as java.util.List or java.util.Map.Entry, replaced with simple names, such as List or Map.Entry,
+// and single-type imports of the types added after its import declarations.
+//
+// A qualified name refers to a type if its first capitalized identifier is conventionally the name of a type
+// rather than of a constant, and the first identifier of the name is not a variable visible where it occurs:
+// a field of an enclosing type declaration, a parameter, or a local variable declared before it. The name remains qualified
+// if its simple name refers to another type: another type declared in the compilation unit, a type imported
+// by a single-type import, another type whose name occurs first, or a type referenced by the simple name.
+// Types of java.lang and of the package of the compilation unit are not imported.
+func ImportTypes(cu CompilationUnitNode) CompilationUnit {
+	used, _ := referencedNames(cu.GetTypeDecls())
+	var pkg string
+	if cu.GetPackage() != nil {
+		pkg = qualifiedName(cu.GetPackage().GetPackageName())
+	}
+	// The qualified names of the types referenced by simple names, or empty strings for unknown types.
+	types := map[string]string{}
+	for _, i := range cu.GetImports() {
+		if name := importName(i); !i.IsStatic() && !strings.HasSuffix(name, ".*") {
+			types[lastName(name)] = name
+		}
+	}
+	for _, node := range cu.GetTypeDecls() {
+		Walk(node, func(node Node) bool {
+			switch n := node.(type) {
+			case ClassNode:
+				types[n.GetSimpleName()] = ""
+			case TypeParameterNode:
+				types[n.GetName()] = ""
+			}
+			return true
+		})
+	}
+	for _, node := range cu.GetTypeDecls() {
+		if c, ok := node.(ClassNode); ok && pkg != "" {
+			types[c.GetSimpleName()] = pkg + "." + c.GetSimpleName()
+		}
+	}
+	for name := range used {
+		if _, ok := types[name]; !ok && typeName(name) {
+			types[name] = ""
+			if javaLangTypes[name] {
+				types[name] = "java.lang." + name
+			}
+		}
+	}
+	imports := slices.Clone(cu.GetImports())
+	// Rewrites the tree rooted at node, in which the variables are visible, and the nested scopes with their variables.
+	var importTypes func(node Node, variables map[string]bool) Node
+	importTypes = func(node Node, variables map[string]bool) Node {
+		if b, ok := node.(Block); ok {
+			// A local variable is visible in the statements which follow its declaration.
+			statements := make([]StatementNode, len(b.Statements))
+			for i, statement := range b.Statements {
+				statements[i] = importTypes(statement, variables).(StatementNode)
+				variables = withVariables(variables, variableNames([]Node{statement}))
+			}
+			b.Statements = statements
+			return b
+		}
+		variables = withVariables(variables, scopeVariables(node))
+		root := true
+		return rewrite(node, func(node Node) (Node, bool) {
+			if _, block := node.(Block); !root && (block || len(scopeVariables(node)) > 0) {
+				return importTypes(node, variables), false
+			}
+			root = false
+			ms, ok := node.(MemberSelect)
+			if !ok {
+				return node, true
+			}
+			parts := strings.Split(qualifiedName(ms), ".")
+			k := slices.IndexFunc(parts, func(part string) bool { return part != "" && unicode.IsUpper([]rune(part)[0]) })
+			if len(parts) < 2 || k < 1 || !typeName(parts[k]) || variables[parts[0]] {
+				return node, true
+			}
+			name, qualifier := parts[k], strings.Join(parts[:k], ".")
+			switch qualified, ok := types[name]; {
+			case !ok:
+				types[name] = qualifier + "." + name
+				if qualifier != pkg && qualifier != "java.lang" {
+					imports = append(imports, NewImport(qualifier+"."+name, false))
+				}
+			case qualified != qualifier+"."+name:
+				return node, false
+			}
+			return shortenQualifier(ms, len(parts)-1-k), false
+		})
+	}
+	decls := make([]Node, len(cu.GetTypeDecls()))
+	for d, decl := range cu.GetTypeDecls() {
+		decls[d] = importTypes(decl, nil)
+	}
+	return CompilationUnit{
+		Span:      Span{Pos: cu.GetPos(), End: cu.GetEnd()},
+		Module:    cu.GetModule(),
+		Package:   cu.GetPackage(),
+		Imports:   imports,
+		TypeDecls: decls,
+	}
+}
+
+// Returns the names of the variables declared by the node for the scope of its subtree: the fields and record
+// components of a type declaration, or the variables among the children of other nodes, such as the parameters of a method.
+func scopeVariables(node Node) []string {
+	c, ok := node.(ClassNode)
+	if !ok {
+		return variableNames(Children(node))
+	}
+	var nodes []Node
+	for _, v := range c.GetRecordComponents() {
+		nodes = append(nodes, v)
+	}
+	return variableNames(append(nodes, c.GetMembers()...))
+}
+
+// Returns the names of the variables declared by the variable and variable declaration nodes among the nodes.
+func variableNames(nodes []Node) []string {
+	var names []string
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case VariableNode:
+			names = append(names, n.GetName())
+		case VariableDeclarationNode:
+			for _, v := range n.GetVariables() {
+				names = append(names, v.GetName())
+			}
+		}
+	}
+	return names
+}
+
+// Returns the variables with the names added, or the variables themselves if there are no names.
+func withVariables(variables map[string]bool, names []string) map[string]bool {
+	if len(names) == 0 {
+		return variables
+	}
+	variables = maps.Clone(variables)
+	if variables == nil {
+		variables = map[string]bool{}
+	}
+	for _, name := range names {
+		variables[name] = true
+	}
+	return variables
+}
+
+// Replaces the qualifier of the type in a member select chain with the simple name of the type,
+// where depth is the number of identifiers which follow the type.
+func shortenQualifier(node ExpressionNode, depth int) ExpressionNode {
+	ms, ok := node.(MemberSelect)
+	if !ok {
+		return node
+	}
+	if depth == 0 {
+		return Identifier{Span: ms.Span, Name: ms.Identifier}
+	}
+	ms.Expression = shortenQualifier(ms.Expression, depth-1)
+	return ms
+}
+
+// Replaces the on-demand imports which supply fewer than WildcardThreshold names with single-type imports of them,
+// and removes the ones which supply none. The on-demand imports whose members are unknown are kept.
+func expandWildcards(imports []ImportNode, options ImportOptions, used, declared, singles map[string]bool) []ImportNode {
+	var result []ImportNode
+	for _, i := range imports {
+		name, static := importName(i), i.IsStatic()
+		var members []string
+		if strings.HasSuffix(name, ".*") && options.Members != nil {
+			members = options.Members(strings.TrimSuffix(name, ".*"), static)
+		}
+		if members == nil {
+			result = append(result, i)
+			continue
+		}
+		var supplied []string
+		for _, n := range members {
+			if used[n] && !declared[n] && !singles[n] && !javaLangTypes[n] {
+				supplied = append(supplied, n)
+			}
+		}
+		switch {
+		case len(supplied) == 0:
+		case options.ExpandWildcards && len(supplied) < options.WildcardThreshold:
+			for _, n := range supplied {
+				result = append(result, NewImport(strings.TrimSuffix(name, "*")+n, static))
+			}
+		default:
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// Replaces at least WildcardThreshold single-type imports of the same package or type with an on-demand import.
+func collapseWildcards(imports []ImportNode, options ImportOptions) []ImportNode {
+	if options.WildcardThreshold <= 0 {
+		return imports
+	}
+	counts := map[string]int{}
+	for _, i := range imports {
+		if name := importName(i); !strings.HasSuffix(name, ".*") && strings.Contains(name, ".") {
+			counts[importKey(containerName(name), i.IsStatic())]++
+		}
+	}
+	var result []ImportNode
+	added := map[string]bool{}
+	for _, i := range imports {
+		name, static := importName(i), i.IsStatic()
+		if strings.HasSuffix(name, ".*") {
+			if key := importKey(strings.TrimSuffix(name, ".*"), static); !added[key] {
+				added[key] = true
+				result = append(result, i)
+			}
+			continue
+		}
+		key := importKey(containerName(name), static)
+		if counts[key] < options.WildcardThreshold {
+			result = append(result, i)
+		} else if !added[key] {
+			added[key] = true
+			result = append(result, NewImport(containerName(name)+".*", static))
+		}
+	}
+	return result
+}
+
+// Returns the simple names referenced by the nodes and the names they declare.
+func referencedNames(nodes []Node) (used, declared map[string]bool) {
+	used, declared = map[string]bool{}, map[string]bool{}
+	for _, node := range nodes {
+		Walk(node, func(node Node) bool {
+			switch n := node.(type) {
+			case IdentifierNode:
+				used[n.GetName()] = true
+			case ClassNode:
+				declared[n.GetSimpleName()] = true
+			case MethodNode:
+				declared[n.GetName()] = true
+			case VariableNode:
+				declared[n.GetName()] = true
+			case TypeParameterNode:
+				declared[n.GetName()] = true
+			case EnumConstantNode:
+				declared[n.GetName()] = true
+			}
+			if c, ok := node.(CommentedNode); ok && c.GetComments().Doc != nil {
+				for _, name := range docReferences(*c.GetComments().Doc) {
+					used[name] = true
+				}
+			}
+			return true
+		})
+	}
+	return
+}
+
+var docLink = regexp.MustCompile(`\{@(?:link|linkplain|value)\s+([\w$]+)`)
+
+// Returns the simple names of the types referenced by the documentation comment,
+// such as "List" for {@link List#add} or "IOException" for @throws IOException.
+func docReferences(doc DocComment) []string {
+	var names []string
+	texts := []string{doc.Description}
+	for _, tag := range doc.Tags {
+		texts = append(texts, tag.Text)
+		switch tag.Name {
+		case "throws", "exception":
+			names = append(names, strings.Split(tag.Argument, ".")[0])
+		case "see":
+			if fields := strings.FieldsFunc(tag.Text, func(r rune) bool { return r == '.' || r == '#' || unicode.IsSpace(r) }); len(fields) > 0 {
+				names = append(names, fields[0])
+			}
+		}
+	}
+	for _, text := range texts {
+		for _, match := range docLink.FindAllStringSubmatch(text, -1) {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// Returns the qualified name of the import declaration, such as "java.util.List" or "java.util.*".
+func importName(i ImportNode) string {
+	return qualifiedName(i.GetQualifiedIdentifier())
+}
+
+// Returns the qualified name of an identifier or a member select chain, or an empty string if it is neither.
+func qualifiedName(node Node) string {
+	switch n := node.(type) {
+	case IdentifierNode:
+		return n.GetName()
+	case MemberSelectNode:
+		if prefix := qualifiedName(n.GetExpression()); prefix != "" {
+			return prefix + "." + n.GetIdentifier()
+		}
+	}
+	return ""
+}
+
+// Reports whether the name is conventionally the name of a type: capitalized, but not in upper case as a constant.
+func typeName(name string) bool {
+	return unicode.IsUpper([]rune(name)[0]) && strings.ToUpper(name) != name
+}
+
+// Returns the key of an import declaration, which distinguishes static imports from others of the same name.
+func importKey(name string, static bool) string {
+	if static {
+		return "static " + name
+	}
+	return name
+}
+
+// Returns the last identifier of a qualified name.
+func lastName(name string) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// Returns the qualified name without its last identifier.
+func containerName(name string) string {
+	return name[:max(strings.LastIndex(name, "."), 0)]
+}
+
+// The types of java.lang, which are imported implicitly.
+var javaLangTypes = map[string]bool{
+	"AbstractMethodError": true, "Appendable": true, "ArithmeticException": true,
+	"ArrayIndexOutOfBoundsException": true, "ArrayStoreException": true, "AssertionError": true,
+	"AutoCloseable": true, "Boolean": true, "BootstrapMethodError": true, "Byte": true, "Character": true,
+	"CharSequence": true, "Class": true, "ClassCastException": true, "ClassCircularityError": true,
+	"ClassFormatError": true, "ClassLoader": true, "ClassNotFoundException": true, "ClassValue": true,
+	"CloneNotSupportedException": true, "Cloneable": true, "Comparable": true, "Deprecated": true, "Double": true,
+	"Enum": true, "EnumConstantNotPresentException": true, "Error": true, "Exception": true,
+	"ExceptionInInitializerError": true, "Float": true, "FunctionalInterface": true, "IllegalAccessError": true,
+	"IllegalAccessException": true, "IllegalArgumentException": true, "IllegalCallerException": true,
+	"IllegalMonitorStateException": true, "IllegalStateException": true, "IllegalThreadStateException": true,
+	"IncompatibleClassChangeError": true, "IndexOutOfBoundsException": true, "InheritableThreadLocal": true,
+	"InstantiationError": true, "InstantiationException": true, "Integer": true, "InternalError": true,
+	"InterruptedException": true, "Iterable": true, "LayerInstantiationException": true, "LinkageError": true,
+	"Long": true, "MatchException": true, "Math": true, "Module": true, "ModuleLayer": true,
+	"NegativeArraySizeException": true, "NoClassDefFoundError": true, "NoSuchFieldError": true,
+	"NoSuchFieldException": true, "NoSuchMethodError": true, "NoSuchMethodException": true,
+	"NullPointerException": true, "Number": true, "NumberFormatException": true, "Object": true,
+	"OutOfMemoryError": true, "Override": true, "Package": true, "Process": true, "ProcessBuilder": true,
+	"ProcessHandle": true, "Readable": true, "Record": true, "ReflectiveOperationException": true,
+	"Runnable": true, "Runtime": true, "RuntimeException": true, "RuntimePermission": true, "SafeVarargs": true,
+	"ScopedValue": true, "SecurityException": true, "Short": true, "StackOverflowError": true,
+	"StackTraceElement": true, "StackWalker": true, "StrictMath": true, "String": true, "StringBuffer": true,
+	"StringBuilder": true, "StringIndexOutOfBoundsException": true, "StringTemplate": true,
+	"SuppressWarnings": true, "System": true, "Thread": true, "ThreadDeath": true, "ThreadGroup": true,
+	"ThreadLocal": true, "Throwable": true, "TypeNotPresentException": true, "UnknownError": true,
+	"UnsatisfiedLinkError": true, "UnsupportedClassVersionError": true, "UnsupportedOperationException": true,
+	"VerifyError": true, "VirtualMachineError": true, "Void": true, "WrongThreadException": true,
+}
@@ -0,0 +1,195 @@
+package javast_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kapavkin/javast"
+)
+
+func TestOrganizeImports(t *testing.T) {
+	t.Parallel()
+	src := `package com.example;
+import static org.junit.Assert.assertEquals;
+import static org.junit.Assert.*;
+import java.util.Map;
+import java.util.List;
+import java.util.List;
+import java.util.Set;
+import com.google.common.collect.ImmutableList;
+import javax.annotation.Nullable;
+import java.io.*;
+import org.slf4j.Logger;
+/** Uses {@link Set}. */
+class Registry extends Helper {
+  @Nullable List<String> names;
+  Map<String, Integer> counts;
+  void check() throws IOException { assertEquals(1, 1); assertTrue(true); ImmutableList.of(); }
+}
+`
+	// The members of the packages and types, without the package of the compilation unit, which declares Helper.
+	members := func(name string, static bool) []string {
+		return map[string][]string{
+			"java.io":          {"File", "IOException", "Reader"},
+			"org.junit.Assert": {"assertEquals", "assertTrue", "fail"},
+		}[name]
+	}
+	tests := []struct {
+		name    string
+		options javast.FormatterOptions
+		imports javast.ImportOptions
+		want    string
+	}{
+		{"GoogleStyle", javast.GoogleStyle, javast.GoogleStyle.Imports, `import static org.junit.Assert.*;
+import static org.junit.Assert.assertEquals;
+
+import com.google.common.collect.ImmutableList;
+import java.io.*;
+import java.util.List;
+import java.util.Map;
+import java.util.Set;
+import javax.annotation.Nullable;`},
+		{"IntelliJStyle", javast.IntelliJStyle, javast.IntelliJStyle.Imports, `import com.google.common.collect.ImmutableList;
+
+import javax.annotation.Nullable;
+
+import java.io.*;
+import java.util.List;
+import java.util.Map;
+import java.util.Set;
+
+import static org.junit.Assert.*;
+import static org.junit.Assert.assertEquals;`},
+		{"WildcardThreshold", javast.GoogleStyle, javast.ImportOptions{WildcardThreshold: 2, ExpandWildcards: true, Members: members}, `import static org.junit.Assert.*;
+
+import com.google.common.collect.ImmutableList;
+import java.io.IOException;
+import java.util.*;
+import javax.annotation.Nullable;`},
+		{"UnknownMembers", javast.GoogleStyle, javast.ImportOptions{WildcardThreshold: 5, ExpandWildcards: true}, `import static org.junit.Assert.*;
+import static org.junit.Assert.assertEquals;
+
+import com.google.common.collect.ImmutableList;
+import java.io.*;
+import java.util.List;
+import java.util.Map;
+import java.util.Set;
+import javax.annotation.Nullable;`},
+	}
+	for _, test := range tests {
+		cu, err := javast.Parse(strings.NewReader(src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		organized := javast.OrganizeImports(cu, test.imports)
+		organized.Package, organized.TypeDecls = nil, nil
+		var buf []byte
+		formatter := javast.Formatter{
+			Writer: javast.WriterFunc(
+				func(p []byte) (int, error) {
+					n := len(p)
+					buf = append(buf, p...)
+					return n, nil
+				},
+			),
+			Options: test.options,
+		}
+		formatter.Options.Imports = test.imports
+		if err := formatter.Format(organized); err != nil {
+			t.Error(err)
+		}
+		got := string(buf)
+		if got != test.want {
+			t.Errorf("%s: string(buf) = %s, want %s", test.name, got, test.want)
+		}
+	}
+}
+
+func TestImportTypes(t *testing.T) {
+	t.Parallel()
+	src := `package com.example;
+import java.util.Set;
+class Registry {
+  java.util.List<String> names = java.util.Collections.emptyList();
+  java.awt.List view;
+  java.util.Map.Entry<String, java.lang.Integer> entry;
+  com.example.Helper helper;
+  com.other.Set other;
+  java.util.Set<com.example.Registry> all;
+  @javax.annotation.Nullable Object value;
+  void check(String text) { text.length(); }
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf []byte
+	formatter := javast.Formatter{
+		Writer: javast.WriterFunc(
+			func(p []byte) (int, error) {
+				n := len(p)
+				buf = append(buf, p...)
+				return n, nil
+			},
+		),
+		Options: javast.GoogleStyle,
+	}
+	if err := formatter.Format(javast.ImportTypes(cu)); err != nil {
+		t.Error(err)
+	}
+	got := string(buf)
+	want := `package com.example;
+
+import java.util.Set;
+import java.util.List;
+import java.util.Collections;
+import java.util.Map;
+import javax.annotation.Nullable;
+
+class Registry {
+  List<String> names = Collections.emptyList();
+  java.awt.List view;
+  Map.Entry<String, Integer> entry;
+  Helper helper;
+  com.other.Set other;
+  Set<Registry> all;
+  @Nullable
+  Object value;
+
+  void check(String text) {
+    text.length();
+  }
+}`
+	if got != want {
+		t.Errorf("string(buf) = %s, want %s", got, want)
+	}
+}
+
+func TestImportTypes_Variables(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	src := `package com.example;
+class Client {
+  Config config;
+  int timeout = settings.DEFAULT_TIMEOUT;
+  void first(Point point) { String java = point.Origin.name(); config.Defaults.load(); }
+  void second() { java.util.Objects.hash(java.lang.Math.PI); String java; java.util.Objects.hash(); }
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := javast.ImportTypes(cu).WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "package com . example ; import java . util . Objects ; class Client { Config config ; " +
+		"int timeout = settings . DEFAULT_TIMEOUT ; " +
+		"void first ( Point point ) { String java = point . Origin . name ( ) ; config . Defaults . load ( ) ; } " +
+		"void second ( ) { Objects . hash ( Math . PI ) ; String java ; java . util . Objects . hash ( ) ; } }"
+	if got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+}
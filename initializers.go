@@ -0,0 +1,107 @@
+package javast
+
+import "slices"
+
+// Returns the initializers of the type declaration in declaration order:
+// its static initializers if static is true, and its instance initializers otherwise.
+func Initializers(c ClassNode, static bool) []BlockNode {
+	var blocks []BlockNode
+	for _, member := range c.GetMembers() {
+		if b, ok := member.(BlockNode); ok && b.IsStatic() == static {
+			blocks = append(blocks, b)
+		}
+	}
+	return blocks
+}
+
+// Returns a copy of the type declaration, in which each initializer is merged into the previous initializer
+// of the same kind, static or instance. An initializer is not merged across a field declaration: the initializers
+// and the field initializers are executed in textual order, and an initializer may only refer to the fields
+// declared before it by their simple names.
+//
+// The statements of a merged initializer are appended to those of the previous one, unless it has comments
+// or declares local variables or classes, in which case it is appended as a nested block.
+// If the statements of the previous initializer declare local variables or classes, they are nested in a block
+// of their own first, so that their declarations are not in scope in the appended statements.
+// The type declaration is returned unchanged if it is not implemented by a node struct of this package.
+func MergeInitializers(c ClassNode) ClassNode {
+	members := mergeInitializers(c.GetMembers())
+	switch t := c.(type) {
+	case Class:
+		t.Members = members
+		return t
+	case Interface:
+		t.Members = members
+		return t
+	case Enum:
+		t.Members = members
+		return t
+	case Record:
+		t.Members = members
+		return t
+	case AnnotationType:
+		t.Members = members
+		return t
+	}
+	return c
+}
+
+// Merges the initializers of the members of a type declaration.
+func mergeInitializers(members []Node) []Node {
+	var result []Node
+	// The index in the result of the initializer of each kind into which the next one is merged, or -1 if none.
+	last := map[bool]int{false: -1, true: -1}
+	for _, member := range members {
+		switch m := member.(type) {
+		case BlockNode:
+			if i := last[m.IsStatic()]; i >= 0 {
+				result[i] = appendInitializer(result[i].(Block), m)
+				continue
+			}
+			last[m.IsStatic()] = len(result)
+			member = toBlock(m)
+		case VariableNode, VariableDeclarationNode:
+			last[false], last[true] = -1, -1
+		}
+		result = append(result, member)
+	}
+	return result
+}
+
+// Appends the statements of the initializer to the block, or the initializer itself as a nested block.
+func appendInitializer(b Block, initializer BlockNode) Block {
+	next := toBlock(initializer)
+	if declares(b) {
+		b.Statements = []StatementNode{Block{Span: b.Span, Statements: b.Statements}}
+	}
+	if len(next.Leading) > 0 || next.Doc != nil || len(next.Trailing) > 0 || len(next.Dangling) > 0 || declares(next) {
+		next.Static = false
+		b.Statements = append(slices.Clone(b.Statements), next)
+	} else {
+		b.Statements = append(slices.Clone(b.Statements), next.Statements...)
+	}
+	return b
+}
+
+// Reports whether the block declares local variables or classes.
+func declares(b Block) bool {
+	return slices.ContainsFunc(b.Statements, func(statement StatementNode) bool {
+		switch statement.GetKind() {
+		case VARIABLE, VARIABLE_DECLARATION, CLASS, INTERFACE, ENUM, RECORD:
+			return true
+		}
+		return false
+	})
+}
+
+// Returns the block struct of a block node.
+func toBlock(node BlockNode) Block {
+	if b, ok := node.(Block); ok {
+		return b
+	}
+	b := Block{Span: Span{Pos: node.GetPos(), End: node.GetEnd()}, Static: node.IsStatic(), Statements: node.GetStatements()}
+	if c, ok := node.(CommentedNode); ok {
+		b.Comments = c.GetComments()
+	}
+	return b
+}
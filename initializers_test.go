@@ -0,0 +1,97 @@
+package javast_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kapavkin/javast"
+)
+
+func TestInitializers(t *testing.T) {
+	t.Parallel()
+	src := `class Registry {
+    static { load(); }
+    { count = 0; }
+    int count;
+    static { check(); }
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := cu.GetTypeDecls()[0].(javast.ClassNode)
+	if got := len(javast.Initializers(c, true)); got != 2 {
+		t.Errorf("len(Initializers(c, true)) = %d, want 2", got)
+	}
+	if got := len(javast.Initializers(c, false)); got != 1 {
+		t.Errorf("len(Initializers(c, false)) = %d, want 1", got)
+	}
+}
+
+func TestMergeInitializers(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	src := `class Registry {
+    static int size;
+    static { load(); }
+    { count = 0; }
+    static { init(); }
+    int count;
+    static { check(); }
+    static int limit = size * 2;
+    static { size++; }
+    void reset() {}
+    { int i = 1; count += i; }
+    { count++; }
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := javast.MergeInitializers(cu.GetTypeDecls()[0].(javast.ClassNode))
+	if _, err := c.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "class Registry { static int size ; " +
+		"static { load ( ) ; init ( ) ; } " +
+		"{ count = 0 ; } " +
+		"int count ; " +
+		"static { check ( ) ; } " +
+		"static int limit = size * 2 ; " +
+		"static { size ++ ; } " +
+		"void reset ( ) { } " +
+		"{ { int i = 1 ; count += i ; } count ++ ; } }"
+	if got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+}
+
+func TestMergeInitializers_SameLocals(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	src := `class Registry {
+    static { int i = 1; f(i); }
+    static { int i = 2; g(i); }
+    static { int i = 3; h(i); }
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := javast.MergeInitializers(cu.GetTypeDecls()[0].(javast.ClassNode))
+	if _, err := c.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "class Registry { static { " +
+		"{ int i = 1 ; f ( i ) ; } " +
+		"{ int i = 2 ; g ( i ) ; } " +
+		"{ int i = 3 ; h ( i ) ; } } }"
+	if got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,753 @@
+package lexer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+var keywords = map[string]bool{
+	"abstract": true, "assert": true, "boolean": true, "break": true, "byte": true,
+	"case": true, "catch": true, "char": true, "class": true, "const": true,
+	"continue": true, "default": true, "do": true, "double": true, "else": true,
+	"enum": true, "extends": true, "final": true, "finally": true, "float": true,
+	"for": true, "goto": true, "if": true, "implements": true, "import": true,
+	"instanceof": true, "int": true, "interface": true, "long": true, "native": true,
+	"new": true, "package": true, "private": true, "protected": true, "public": true,
+	"return": true, "short": true, "static": true, "strictfp": true, "super": true,
+	"switch": true, "synchronized": true, "this": true, "throw": true, "throws": true,
+	"transient": true, "try": true, "void": true, "volatile": true, "while": true,
+	"_": true,
+}
+
+// Operators and separators ordered so that longer ones are matched first.
+var punctuation = [...]struct {
+	text string
+	kind Kind
+}{
+	{">>>=", OPERATOR},
+	{"<<=", OPERATOR}, {">>=", OPERATOR}, {">>>", OPERATOR}, {"...", SEPARATOR},
+	{"->", OPERATOR}, {"::", SEPARATOR}, {"==", OPERATOR}, {">=", OPERATOR},
+	{"<=", OPERATOR}, {"!=", OPERATOR}, {"&&", OPERATOR}, {"||", OPERATOR},
+	{"++", OPERATOR}, {"--", OPERATOR}, {"<<", OPERATOR}, {">>", OPERATOR},
+	{"+=", OPERATOR}, {"-=", OPERATOR}, {"*=", OPERATOR}, {"/=", OPERATOR},
+	{"&=", OPERATOR}, {"|=", OPERATOR}, {"^=", OPERATOR}, {"%=", OPERATOR},
+	{"(", SEPARATOR}, {")", SEPARATOR}, {"{", SEPARATOR}, {"}", SEPARATOR},
+	{"[", SEPARATOR}, {"]", SEPARATOR}, {";", SEPARATOR}, {",", SEPARATOR},
+	{".", SEPARATOR}, {"@", SEPARATOR},
+	{"=", OPERATOR}, {">", OPERATOR}, {"<", OPERATOR}, {"!", OPERATOR},
+	{"~", OPERATOR}, {"?", OPERATOR}, {":", OPERATOR}, {"+", OPERATOR},
+	{"-", OPERATOR}, {"*", OPERATOR}, {"/", OPERATOR}, {"&", OPERATOR},
+	{"|", OPERATOR}, {"^", OPERATOR}, {"%", OPERATOR},
+}
+
+// A Lexer splits Java source into tokens as defined by the lexical grammar of the JLS.
+// Unicode escapes are translated before tokenization.
+type Lexer struct {
+	chars []rune // The source after Unicode escapes have been translated.
+	offs  []int  // The byte offset in the source of each translated character, followed by the source length.
+	lines []int  // The byte offsets in the source at which lines start.
+	pos   int    // The index in chars of the next character to scan.
+}
+
+// Returns a lexer of the source.
+// The source must be valid UTF-8 and its Unicode escapes must be well-formed.
+func New(src []byte) (*Lexer, error) {
+	l := &Lexer{lines: []int{0}}
+	backslashes := 0
+	for i := 0; i < len(src); {
+		r, w := utf8.DecodeRune(src[i:])
+		if r == utf8.RuneError && w == 1 {
+			return nil, l.errorAt(i, "invalid UTF-8 encoding")
+		}
+		switch r {
+		case '\n':
+			l.lines = append(l.lines, i+1)
+		case '\r':
+			if i+1 >= len(src) || src[i+1] != '\n' {
+				l.lines = append(l.lines, i+1)
+			}
+		}
+		if r == '\\' && backslashes%2 == 0 {
+			j := i + 1
+			for j < len(src) && src[j] == 'u' {
+				j++
+			}
+			if j > i+1 {
+				var v rune
+				for k := 0; k < 4; k++ {
+					if j+k >= len(src) || digitValue(rune(src[j+k])) >= 16 {
+						return nil, l.errorAt(i, "illegal unicode escape")
+					}
+					v = v<<4 | rune(digitValue(rune(src[j+k])))
+				}
+				if n := len(l.chars); n > 0 && utf16.IsSurrogate(l.chars[n-1]) {
+					if pair := utf16.DecodeRune(l.chars[n-1], v); pair != unicode.ReplacementChar {
+						l.chars[n-1] = pair
+						i = j + 4
+						continue
+					}
+				}
+				l.chars = append(l.chars, v)
+				l.offs = append(l.offs, i)
+				i = j + 4
+				backslashes = 0
+				continue
+			}
+		}
+		if r == '\\' {
+			backslashes++
+		} else {
+			backslashes = 0
+		}
+		l.chars = append(l.chars, r)
+		l.offs = append(l.offs, i)
+		i += w
+	}
+	l.offs = append(l.offs, len(src))
+	return l, nil
+}
+
+// Returns the 1-based line and column of the byte offset in the source.
+func (l *Lexer) position(offset int) (line, column int) {
+	line = sort.Search(len(l.lines), func(i int) bool { return l.lines[i] > offset })
+	column = offset - l.lines[line-1] + 1
+	return
+}
+
+func (l *Lexer) errorAt(offset int, format string, args ...any) error {
+	line, column := l.position(offset)
+	return fmt.Errorf("%d:%d: %s", line, column, fmt.Sprintf(format, args...))
+}
+
+func (l *Lexer) peek(n int) rune {
+	if l.pos+n < len(l.chars) {
+		return l.chars[l.pos+n]
+	}
+	return -1
+}
+
+func (l *Lexer) token(kind Kind, start int) Token {
+	line, column := l.position(l.offs[start])
+	return Token{
+		Kind:   kind,
+		Text:   encode(l.chars[start:l.pos]),
+		Offset: l.offs[start],
+		End:    l.offs[l.pos],
+		Line:   line,
+		Column: column,
+	}
+}
+
+// Returns the next token, including comments.
+// At the end of the input a token of kind [EOF] is returned.
+func (l *Lexer) Next() (Token, error) {
+	for {
+		switch l.peek(0) {
+		case ' ', '\t', '\f', '\n', '\r':
+			l.pos++
+			continue
+		case '\x1a':
+			if l.pos == len(l.chars)-1 {
+				l.pos++
+				continue
+			}
+		}
+		break
+	}
+	start := l.pos
+	ch := l.peek(0)
+	switch {
+	case ch < 0:
+		return l.token(EOF, start), nil
+	case ch == '/' && l.peek(1) == '/':
+		for l.peek(0) >= 0 && l.peek(0) != '\n' && l.peek(0) != '\r' {
+			l.pos++
+		}
+		return l.token(LINE_COMMENT, start), nil
+	case ch == '/' && l.peek(1) == '*':
+		kind := BLOCK_COMMENT
+		if l.peek(2) == '*' && l.peek(3) != '/' {
+			kind = DOC_COMMENT
+		}
+		l.pos += 2
+		for !(l.peek(0) == '*' && l.peek(1) == '/') {
+			if l.peek(0) < 0 {
+				return Token{}, l.errorAt(l.offs[start], "unclosed comment")
+			}
+			l.pos++
+		}
+		l.pos += 2
+		return l.token(kind, start), nil
+	case isIdentifierStart(ch):
+		for isIdentifierPart(l.peek(0)) {
+			l.pos++
+		}
+		tok := l.token(IDENTIFIER, start)
+		switch {
+		case keywords[tok.Text]:
+			tok.Kind = KEYWORD
+		case tok.Text == "true" || tok.Text == "false":
+			tok.Kind = BOOLEAN_LITERAL
+		case tok.Text == "null":
+			tok.Kind = NULL_LITERAL
+		}
+		return tok, nil
+	case isDecimalDigit(ch) || ch == '.' && isDecimalDigit(l.peek(1)):
+		kind, err := l.scanNumber()
+		if err != nil {
+			return Token{}, err
+		}
+		return l.token(kind, start), nil
+	case ch == '\'':
+		l.pos++
+		switch l.peek(0) {
+		case '\'', '\n', '\r', -1:
+			return Token{}, l.errorAt(l.offs[start], "empty character literal")
+		case '\\':
+			if err := l.scanEscape(false); err != nil {
+				return Token{}, err
+			}
+		default:
+			l.pos++
+		}
+		if l.peek(0) != '\'' {
+			return Token{}, l.errorAt(l.offs[start], "unclosed character literal")
+		}
+		l.pos++
+		return l.token(CHAR_LITERAL, start), nil
+	case ch == '"' && l.peek(1) == '"' && l.peek(2) == '"':
+		l.pos += 3
+		for l.peek(0) == ' ' || l.peek(0) == '\t' || l.peek(0) == '\f' {
+			l.pos++
+		}
+		if l.peek(0) != '\n' && l.peek(0) != '\r' {
+			return Token{}, l.errorAt(l.offs[start], "illegal text block open delimiter sequence, missing line terminator")
+		}
+		for !(l.peek(0) == '"' && l.peek(1) == '"' && l.peek(2) == '"') {
+			switch l.peek(0) {
+			case -1:
+				return Token{}, l.errorAt(l.offs[start], "unclosed text block")
+			case '\\':
+				if err := l.scanEscape(true); err != nil {
+					return Token{}, err
+				}
+			default:
+				l.pos++
+			}
+		}
+		l.pos += 3
+		return l.token(TEXT_BLOCK, start), nil
+	case ch == '"':
+		l.pos++
+		for l.peek(0) != '"' {
+			switch l.peek(0) {
+			case '\n', '\r', -1:
+				return Token{}, l.errorAt(l.offs[start], "unclosed string literal")
+			case '\\':
+				if err := l.scanEscape(false); err != nil {
+					return Token{}, err
+				}
+			default:
+				l.pos++
+			}
+		}
+		l.pos++
+		return l.token(STRING_LITERAL, start), nil
+	}
+	for _, p := range punctuation {
+		if l.hasPrefix(p.text) {
+			l.pos += len(p.text)
+			return l.token(p.kind, start), nil
+		}
+	}
+	return Token{}, l.errorAt(l.offs[start], "illegal character %q", ch)
+}
+
+// Returns all tokens of the source, including comments and the final token of kind [EOF].
+func Tokenize(src []byte) ([]Token, error) {
+	l, err := New(src)
+	if err != nil {
+		return nil, err
+	}
+	var tokens []Token
+	for {
+		tok, err := l.Next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, tok)
+		if tok.Kind == EOF {
+			return tokens, nil
+		}
+	}
+}
+
+func (l *Lexer) hasPrefix(prefix string) bool {
+	i := 0
+	for _, r := range prefix {
+		if l.peek(i) != r {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+// Scans an escape sequence starting at a backslash.
+// Line terminators may only be escaped inside text blocks.
+func (l *Lexer) scanEscape(textBlock bool) error {
+	start := l.pos
+	l.pos++
+	switch ch := l.peek(0); {
+	case strings.ContainsRune(`btnfrs"'\`, ch):
+		l.pos++
+	case ch >= '0' && ch <= '7':
+		max := 2
+		if ch > '3' {
+			max = 1
+		}
+		l.pos++
+		for i := 0; i < max && l.peek(0) >= '0' && l.peek(0) <= '7'; i++ {
+			l.pos++
+		}
+	case textBlock && ch == '\n':
+		l.pos++
+	case textBlock && ch == '\r':
+		l.pos++
+		if l.peek(0) == '\n' {
+			l.pos++
+		}
+	default:
+		return l.errorAt(l.offs[start], "illegal escape character")
+	}
+	return nil
+}
+
+// Scans an integer or floating-point literal and returns its kind.
+func (l *Lexer) scanNumber() (Kind, error) {
+	start := l.pos
+	if l.peek(0) == '0' && (l.peek(1) == 'x' || l.peek(1) == 'X') {
+		l.pos += 2
+		digits, err := l.scanDigits(16)
+		if err != nil {
+			return 0, err
+		}
+		float := false
+		if l.peek(0) == '.' {
+			l.pos++
+			fraction, err := l.scanDigits(16)
+			if err != nil {
+				return 0, err
+			}
+			digits += fraction
+			float = true
+		}
+		if digits == 0 {
+			return 0, l.errorAt(l.offs[start], "hexadecimal numbers must contain at least one hexadecimal digit")
+		}
+		if l.peek(0) == 'p' || l.peek(0) == 'P' {
+			if err := l.scanExponent(); err != nil {
+				return 0, err
+			}
+			return l.scanFloatSuffix(), nil
+		} else if float {
+			return 0, l.errorAt(l.offs[start], "malformed floating-point literal")
+		}
+		return l.scanIntegerSuffix(), nil
+	}
+	if l.peek(0) == '0' && (l.peek(1) == 'b' || l.peek(1) == 'B') {
+		l.pos += 2
+		digits, err := l.scanDigits(2)
+		if err != nil {
+			return 0, err
+		}
+		if digits == 0 {
+			return 0, l.errorAt(l.offs[start], "binary numbers must contain at least one binary digit")
+		}
+		return l.scanIntegerSuffix(), nil
+	}
+	if _, err := l.scanDigits(10); err != nil {
+		return 0, err
+	}
+	float := false
+	if l.peek(0) == '.' {
+		l.pos++
+		if _, err := l.scanDigits(10); err != nil {
+			return 0, err
+		}
+		float = true
+	}
+	if l.peek(0) == 'e' || l.peek(0) == 'E' {
+		if err := l.scanExponent(); err != nil {
+			return 0, err
+		}
+		float = true
+	}
+	switch l.peek(0) {
+	case 'f', 'F', 'd', 'D':
+		return l.scanFloatSuffix(), nil
+	}
+	if float {
+		return DOUBLE_LITERAL, nil
+	}
+	if l.chars[start] == '0' {
+		for _, ch := range l.chars[start:l.pos] {
+			if ch == '8' || ch == '9' {
+				return 0, l.errorAt(l.offs[start], "illegal digit in an octal literal")
+			}
+		}
+	}
+	return l.scanIntegerSuffix(), nil
+}
+
+// Scans a run of digits in the radix separated by underscores and returns the number of digits.
+func (l *Lexer) scanDigits(radix int) (int, error) {
+	start, digits := l.pos, 0
+	for {
+		if ch := l.peek(0); ch == '_' {
+			if l.pos == start {
+				return 0, l.errorAt(l.offs[l.pos], "illegal underscore")
+			}
+		} else if digitValue(ch) < radix {
+			digits++
+		} else {
+			break
+		}
+		l.pos++
+	}
+	if l.pos > start && l.chars[l.pos-1] == '_' {
+		return 0, l.errorAt(l.offs[l.pos-1], "illegal underscore")
+	}
+	return digits, nil
+}
+
+func (l *Lexer) scanExponent() error {
+	l.pos++
+	if l.peek(0) == '+' || l.peek(0) == '-' {
+		l.pos++
+	}
+	digits, err := l.scanDigits(10)
+	if err != nil {
+		return err
+	}
+	if digits == 0 {
+		return l.errorAt(l.offs[l.pos-1], "malformed floating-point literal")
+	}
+	return nil
+}
+
+func (l *Lexer) scanIntegerSuffix() Kind {
+	if l.peek(0) == 'l' || l.peek(0) == 'L' {
+		l.pos++
+		return LONG_LITERAL
+	}
+	return INT_LITERAL
+}
+
+func (l *Lexer) scanFloatSuffix() Kind {
+	switch l.peek(0) {
+	case 'f', 'F':
+		l.pos++
+		return FLOAT_LITERAL
+	case 'd', 'D':
+		l.pos++
+	}
+	return DOUBLE_LITERAL
+}
+
+func digitValue(ch rune) int {
+	switch {
+	case '0' <= ch && ch <= '9':
+		return int(ch - '0')
+	case 'a' <= ch && ch <= 'f':
+		return int(ch - 'a' + 10)
+	case 'A' <= ch && ch <= 'F':
+		return int(ch - 'A' + 10)
+	}
+	return 16
+}
+
+func isDecimalDigit(ch rune) bool { return '0' <= ch && ch <= '9' }
+
+func isIdentifierStart(ch rune) bool {
+	return ch == '$' || ch == '_' || unicode.IsLetter(ch) ||
+		unicode.In(ch, unicode.Sc, unicode.Pc, unicode.Nl)
+}
+
+func isIdentifierPart(ch rune) bool {
+	if ch < 0 {
+		return false
+	}
+	return isIdentifierStart(ch) || unicode.IsDigit(ch) ||
+		unicode.In(ch, unicode.Mn, unicode.Mc, unicode.Cf) ||
+		ch <= 0x08 || 0x0e <= ch && ch <= 0x1b || 0x7f <= ch && ch <= 0x9f
+}
+
+// Returns the value of a string literal token.
+// An error is returned if the text is not enclosed in double quotes, or contains an unescaped double quote
+// or line terminator.
+func UnquoteString(text string) (string, error) {
+	if len(text) < 2 || text[0] != '"' || text[len(text)-1] != '"' {
+		return "", fmt.Errorf("string literal %q is not enclosed in double quotes", text)
+	}
+	chars := decode(text[1 : len(text)-1])
+	for i := 0; i < len(chars); i++ {
+		switch chars[i] {
+		case '\\':
+			i++
+		case '"', '\n', '\r':
+			return "", fmt.Errorf("string literal %q contains an unescaped %q", text, chars[i])
+		}
+	}
+	return unescape(chars)
+}
+
+// Returns the value of a text block token with incidental white space stripped.
+// An error is returned if the text is not enclosed in triple double quotes,
+// or if the opening delimiter is not followed by a line terminator.
+func UnquoteTextBlock(text string) (string, error) {
+	if len(text) < 6 || !strings.HasPrefix(text, `"""`) || !strings.HasSuffix(text, `"""`) {
+		return "", fmt.Errorf("text block %q is not enclosed in triple double quotes", text)
+	}
+	content := text[3 : len(text)-3]
+	i := strings.IndexAny(content, "\r\n")
+	if i < 0 || strings.TrimLeft(content[:i], " \t\f") != "" {
+		return "", fmt.Errorf("opening delimiter of text block %q is not followed by a line terminator", text)
+	}
+	content = content[i:]
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.ReplaceAll(content, "\r", "\n")
+	lines := strings.Split(content[1:], "\n")
+	indent := -1
+	for i, line := range lines {
+		blank := strings.TrimLeft(line, " \t\f") == ""
+		if blank && i < len(lines)-1 {
+			continue
+		}
+		if n := len(line) - len(strings.TrimLeft(line, " \t\f")); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	for i, line := range lines {
+		if strings.TrimLeft(line, " \t\f") == "" {
+			lines[i] = ""
+		} else {
+			lines[i] = strings.TrimRight(line[indent:], " \t\f")
+		}
+	}
+	return unescape(decode(strings.Join(lines, "\n")))
+}
+
+// Returns a string literal token with the value.
+// Backslashes, double quotes and control characters are escaped with escape sequences,
+// and other non-printable characters with Unicode escapes. If ascii is true, so are all non-ASCII characters.
+func QuoteString(value string, ascii bool) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, ch := range decode(value) {
+		escape(&b, ch, '"', ascii)
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// Returns a character literal token with the value, escaped as by [QuoteString] except for single quotes instead of double quotes.
+// An error is returned if the value is not a single UTF-16 code unit.
+func QuoteChar(value rune, ascii bool) (string, error) {
+	if value < 0 || value > 0xffff {
+		return "", fmt.Errorf("character %U is not a single UTF-16 code unit", value)
+	}
+	var b strings.Builder
+	b.WriteByte('\'')
+	escape(&b, value, '\'', ascii)
+	b.WriteByte('\'')
+	return b.String(), nil
+}
+
+// Writes the character of a string or character literal delimited by quote.
+// Control characters are written as octal escapes, since Unicode escapes of line terminators would end the literal.
+func escape(b *strings.Builder, ch, quote rune, ascii bool) {
+	switch ch {
+	case '\b':
+		b.WriteString(`\b`)
+	case '\t':
+		b.WriteString(`\t`)
+	case '\n':
+		b.WriteString(`\n`)
+	case '\f':
+		b.WriteString(`\f`)
+	case '\r':
+		b.WriteString(`\r`)
+	case '\\':
+		b.WriteString(`\\`)
+	case quote:
+		b.WriteByte('\\')
+		b.WriteRune(ch)
+	default:
+		switch {
+		case ch < 0x20 || 0x7f <= ch && ch < 0xa0:
+			fmt.Fprintf(b, "\\%03o", ch)
+		case utf16.IsSurrogate(ch):
+			// A lone surrogate is not a character, and can only be written as a Unicode escape.
+			fmt.Fprintf(b, "\\u%04X", ch)
+		case (ascii && ch >= 0x80 || !unicode.IsPrint(ch)) && ch > 0xffff:
+			r1, r2 := utf16.EncodeRune(ch)
+			fmt.Fprintf(b, "\\u%04X\\u%04X", r1, r2)
+		case ascii && ch >= 0x80 || !unicode.IsPrint(ch):
+			fmt.Fprintf(b, "\\u%04X", ch)
+		default:
+			b.WriteRune(ch)
+		}
+	}
+}
+
+// Returns a text block token with the value.
+// The content starts on the line following the opening delimiter and the closing delimiter is on a line of its own,
+// so that no white space of the value is incidental. A value without a final line terminator ends with "\<line terminator>".
+// Quotes which would close the text block and trailing white space are escaped,
+// and other characters as by [QuoteString] except for line terminators, tabs and double quotes.
+func QuoteTextBlock(value string, ascii bool) string {
+	if value == "" {
+		return "\"\"\"\n\"\"\""
+	}
+	var b strings.Builder
+	b.WriteString("\"\"\"\n")
+	lines := strings.Split(value, "\n")
+	for i, line := range lines {
+		if i == len(lines)-1 && line == "" {
+			break
+		}
+		quotes := 0
+		chars := decode(line)
+		for j, ch := range chars {
+			if ch != '"' {
+				quotes = 0
+			}
+			switch {
+			case ch == '"' && quotes == 2:
+				b.WriteString("\\\"")
+				quotes = 0
+			case ch == '"':
+				b.WriteRune(ch)
+				quotes++
+			case ch == ' ' && j == len(chars)-1:
+				b.WriteString(`\s`)
+			case ch == '\t' && j < len(chars)-1:
+				b.WriteRune(ch)
+			default:
+				escape(&b, ch, '"', ascii)
+			}
+		}
+		if i == len(lines)-1 {
+			b.WriteString("\\")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\"\"\"")
+	return b.String()
+}
+
+// Returns the value of a character literal token.
+// An error is returned if the text is not enclosed in single quotes, or its value is not a single UTF-16 code unit.
+func UnquoteChar(text string) (rune, error) {
+	if len(text) < 2 || text[0] != '\'' || text[len(text)-1] != '\'' {
+		return 0, fmt.Errorf("character literal %q is not enclosed in single quotes", text)
+	}
+	value, err := unescape(decode(text[1 : len(text)-1]))
+	if err != nil {
+		return 0, err
+	}
+	r, size := DecodeRuneInString(value)
+	if size == 0 || size != len(value) || r > 0xFFFF {
+		return 0, fmt.Errorf("character literal %q is not a single UTF-16 code unit", text)
+	}
+	return r, nil
+}
+
+func unescape(chars []rune) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(chars); i++ {
+		if chars[i] != '\\' {
+			b.WriteString(EncodeRune(chars[i]))
+			continue
+		}
+		i++
+		if i >= len(chars) {
+			return "", fmt.Errorf("illegal escape character")
+		}
+		switch ch := chars[i]; ch {
+		case 'b':
+			b.WriteByte('\b')
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'f':
+			b.WriteByte('\f')
+		case 'r':
+			b.WriteByte('\r')
+		case 's':
+			b.WriteByte(' ')
+		case '"', '\'', '\\':
+			b.WriteRune(ch)
+		case '\n':
+		default:
+			if ch < '0' || ch > '7' {
+				return "", fmt.Errorf("illegal escape character")
+			}
+			v, max := ch-'0', 2
+			if ch > '3' {
+				max = 1
+			}
+			for j := 0; j < max && i+1 < len(chars) && chars[i+1] >= '0' && chars[i+1] <= '7'; j++ {
+				i++
+				v = v*8 + chars[i] - '0'
+			}
+			b.WriteRune(v)
+		}
+	}
+	return b.String(), nil
+}
+
+// Returns the encoding of the character, which is its UTF-8 encoding unless it is a surrogate code point.
+// Java strings are sequences of UTF-16 code units, which may contain lone surrogates, and those are encoded
+// as if they were characters, as in WTF-8, rather than replaced by U+FFFD, so that they keep their value.
+func EncodeRune(r rune) string {
+	if utf16.IsSurrogate(r) {
+		return string([]byte{0xe0 | byte(r>>12), 0x80 | byte(r>>6)&0x3f, 0x80 | byte(r)&0x3f})
+	}
+	return string(r)
+}
+
+// Decodes the first character of s and returns it and its width in bytes, as [utf8.DecodeRuneInString],
+// except that a surrogate code point encoded by [EncodeRune] is returned rather than an error.
+func DecodeRuneInString(s string) (rune, int) {
+	if len(s) >= 3 && s[0] == 0xed && 0xa0 <= s[1] && s[1] <= 0xbf && 0x80 <= s[2] && s[2] <= 0xbf {
+		return rune(s[0]&0x0f)<<12 | rune(s[1]&0x3f)<<6 | rune(s[2]&0x3f), 3
+	}
+	return utf8.DecodeRuneInString(s)
+}
+
+// Returns the encoding of the characters by [EncodeRune].
+func encode(chars []rune) string {
+	var b strings.Builder
+	for _, ch := range chars {
+		b.WriteString(EncodeRune(ch))
+	}
+	return b.String()
+}
+
+// Returns the characters of s decoded by [DecodeRuneInString].
+func decode(s string) []rune {
+	var chars []rune
+	for len(s) > 0 {
+		ch, w := DecodeRuneInString(s)
+		chars = append(chars, ch)
+		s = s[w:]
+	}
+	return chars
+}
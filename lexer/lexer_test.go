@@ -0,0 +1,262 @@
+package lexer_test
+
+import (
+	"testing"
+
+	"github.com/kapavkin/javast/lexer"
+)
+
+func TestTokenize(t *testing.T) {
+	t.Parallel()
+	src := "/** Doc. */\n" +
+		"var x = a >>>= 0x1.8p1f; // Line.\n" +
+		"/* Block. */ s::length -> \"\\\"q\\\"\" 'c' _ null true 07L;"
+	tokens, err := lexer.Tokenize([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []lexer.Token{
+		{Kind: lexer.DOC_COMMENT, Text: "/** Doc. */", Offset: 0, End: 11, Line: 1, Column: 1},
+		{Kind: lexer.IDENTIFIER, Text: "var", Offset: 12, End: 15, Line: 2, Column: 1},
+		{Kind: lexer.IDENTIFIER, Text: "x", Offset: 16, End: 17, Line: 2, Column: 5},
+		{Kind: lexer.OPERATOR, Text: "=", Offset: 18, End: 19, Line: 2, Column: 7},
+		{Kind: lexer.IDENTIFIER, Text: "a", Offset: 20, End: 21, Line: 2, Column: 9},
+		{Kind: lexer.OPERATOR, Text: ">>>=", Offset: 22, End: 26, Line: 2, Column: 11},
+		{Kind: lexer.FLOAT_LITERAL, Text: "0x1.8p1f", Offset: 27, End: 35, Line: 2, Column: 16},
+		{Kind: lexer.SEPARATOR, Text: ";", Offset: 35, End: 36, Line: 2, Column: 24},
+		{Kind: lexer.LINE_COMMENT, Text: "// Line.", Offset: 37, End: 45, Line: 2, Column: 26},
+		{Kind: lexer.BLOCK_COMMENT, Text: "/* Block. */", Offset: 46, End: 58, Line: 3, Column: 1},
+		{Kind: lexer.IDENTIFIER, Text: "s", Offset: 59, End: 60, Line: 3, Column: 14},
+		{Kind: lexer.SEPARATOR, Text: "::", Offset: 60, End: 62, Line: 3, Column: 15},
+		{Kind: lexer.IDENTIFIER, Text: "length", Offset: 62, End: 68, Line: 3, Column: 17},
+		{Kind: lexer.OPERATOR, Text: "->", Offset: 69, End: 71, Line: 3, Column: 24},
+		{Kind: lexer.STRING_LITERAL, Text: "\"\\\"q\\\"\"", Offset: 72, End: 79, Line: 3, Column: 27},
+		{Kind: lexer.CHAR_LITERAL, Text: "'c'", Offset: 80, End: 83, Line: 3, Column: 35},
+		{Kind: lexer.KEYWORD, Text: "_", Offset: 84, End: 85, Line: 3, Column: 39},
+		{Kind: lexer.NULL_LITERAL, Text: "null", Offset: 86, End: 90, Line: 3, Column: 41},
+		{Kind: lexer.BOOLEAN_LITERAL, Text: "true", Offset: 91, End: 95, Line: 3, Column: 46},
+		{Kind: lexer.LONG_LITERAL, Text: "07L", Offset: 96, End: 99, Line: 3, Column: 51},
+		{Kind: lexer.SEPARATOR, Text: ";", Offset: 99, End: 100, Line: 3, Column: 54},
+		{Kind: lexer.EOF, Text: "", Offset: 100, End: 100, Line: 3, Column: 55},
+	}
+	if len(tokens) != len(want) {
+		t.Fatalf("len(tokens) = %d, want %d", len(tokens), len(want))
+	}
+	for i := range want {
+		if tokens[i] != want[i] {
+			t.Errorf("tokens[%d] = %#v, want %#v", i, tokens[i], want[i])
+		}
+	}
+}
+
+func TestTokenize_NumericLiterals(t *testing.T) {
+	t.Parallel()
+	src := "0 2147483648 0x7fff_ffff 0b1010 0777 1_000L 1e10 1.5F .5 1. 0x1p-3 1D"
+	tokens, err := lexer.Tokenize([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []lexer.Kind{
+		lexer.INT_LITERAL, lexer.INT_LITERAL, lexer.INT_LITERAL, lexer.INT_LITERAL, lexer.INT_LITERAL,
+		lexer.LONG_LITERAL, lexer.DOUBLE_LITERAL, lexer.FLOAT_LITERAL, lexer.DOUBLE_LITERAL,
+		lexer.DOUBLE_LITERAL, lexer.DOUBLE_LITERAL, lexer.DOUBLE_LITERAL, lexer.EOF,
+	}
+	if len(tokens) != len(want) {
+		t.Fatalf("len(tokens) = %d, want %d", len(tokens), len(want))
+	}
+	for i := range want {
+		if tokens[i].Kind != want[i] {
+			t.Errorf("tokens[%d].Kind = %s, want %s", i, tokens[i].Kind, want[i])
+		}
+	}
+}
+
+func TestTokenize_UnicodeEscapes(t *testing.T) {
+	t.Parallel()
+	src := `\u0063har c = '\u0041'; String \uuu0073 = "\\u0041";`
+	tokens, err := lexer.Tokenize([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tokens[0], (lexer.Token{Kind: lexer.KEYWORD, Text: "char", Offset: 0, End: 9, Line: 1, Column: 1}); got != want {
+		t.Errorf("tokens[0] = %#v, want %#v", got, want)
+	}
+	if got, want := tokens[3].Text, "'A'"; got != want {
+		t.Errorf("tokens[3].Text = %s, want %s", got, want)
+	}
+	if got, want := tokens[6].Text, "s"; got != want {
+		t.Errorf("tokens[6].Text = %s, want %s", got, want)
+	}
+	if got, want := tokens[8].Text, `"\\u0041"`; got != want {
+		t.Errorf("tokens[8].Text = %s, want %s", got, want)
+	}
+}
+
+func TestTokenize_Error(t *testing.T) {
+	t.Parallel()
+	for src, want := range map[string]string{
+		"int x = 09;":               "1:9: illegal digit in an octal literal",
+		"int x = 1_;":               "1:10: illegal underscore",
+		"String s = \"abc\n":        "1:12: unclosed string literal",
+		"char c = '';":              "1:10: empty character literal",
+		"/* unclosed":               "1:1: unclosed comment",
+		"String s = \"\"\"x\"\"\";": "1:12: illegal text block open delimiter sequence, missing line terminator",
+		"int #x;":                   "1:5: illegal character '#'",
+		"\\u00g0":                   "1:1: illegal unicode escape",
+	} {
+		_, err := lexer.Tokenize([]byte(src))
+		if err == nil {
+			t.Errorf("Tokenize(%q) error = nil, want %s", src, want)
+		} else if got := err.Error(); got != want {
+			t.Errorf("Tokenize(%q) error = %s, want %s", src, got, want)
+		}
+	}
+}
+
+func TestUnquoteTextBlock(t *testing.T) {
+	t.Parallel()
+	got, err := lexer.UnquoteTextBlock("\"\"\"\n        SELECT *  \n          FROM t\\s\n\n        WHERE \\\n        a = \"b\"\n        \"\"\"")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "SELECT *\n  FROM t \n\nWHERE a = \"b\"\n"
+	if got != want {
+		t.Errorf("lexer.UnquoteTextBlock() = %q, want %q", got, want)
+	}
+}
+
+func TestUnquoteString(t *testing.T) {
+	t.Parallel()
+	got, err := lexer.UnquoteString(`"a\tb\n\\\"\101\0"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "a\tb\n\\\"A\x00"
+	if got != want {
+		t.Errorf("lexer.UnquoteString() = %q, want %q", got, want)
+	}
+}
+
+func TestQuoteTextBlock(t *testing.T) {
+	t.Parallel()
+	values := []string{
+		"",
+		"\n",
+		"SELECT *\n  FROM t\n",
+		"  indented",
+		"quotes \"\"\" and \"\"\"\"\"\"\" end\"",
+		"trailing  \nwhite space\t\n",
+		"back\\slash\r\n",
+	}
+	for _, value := range values {
+		text := lexer.QuoteTextBlock(value, false)
+		if _, err := lexer.Tokenize([]byte(text)); err != nil {
+			t.Errorf("lexer.Tokenize(%q) error = %v", text, err)
+			continue
+		}
+		if got, err := lexer.UnquoteTextBlock(text); err != nil {
+			t.Errorf("lexer.UnquoteTextBlock(%q) error = %v", text, err)
+		} else if got != value {
+			t.Errorf("lexer.UnquoteTextBlock(lexer.QuoteTextBlock(%q)) = %q", value, got)
+		}
+	}
+	got := lexer.QuoteTextBlock("a \"\"\"\n b", false)
+	want := "\"\"\"\na \"\"\\\"\n b\\\n\"\"\""
+	if got != want {
+		t.Errorf("lexer.QuoteTextBlock() = %q, want %q", got, want)
+	}
+}
+
+func TestQuoteString(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		value string
+		ascii bool
+		want  string
+	}{
+		{"a\tb\n\\\"'", false, `"a\tb\n\\\"'"`},
+		{"\x00\x1b7\x7f", false, `"\000\0337\177"`},
+		{"caf\u00e9 \u2028", false, "\"caf\u00e9 \\u2028\""},
+		{"caf\u00e9 \U0001F600", true, `"caf\u00E9 \uD83D\uDE00"`},
+		{"x" + lexer.EncodeRune(0xDC01) + lexer.EncodeRune(0xD800), false, `"x\uDC01\uD800"`},
+		{lexer.EncodeRune(0xDC00) + "\U0001F600", false, "\"\\uDC00\U0001F600\""},
+	}
+	for _, test := range tests {
+		got := lexer.QuoteString(test.value, test.ascii)
+		if got != test.want {
+			t.Errorf("lexer.QuoteString(%q, %t) = %s, want %s", test.value, test.ascii, got, test.want)
+		}
+		if tokens, err := lexer.Tokenize([]byte(got)); err != nil {
+			t.Errorf("lexer.Tokenize(%s) error = %v", got, err)
+		} else if value, err := lexer.UnquoteString(tokens[0].Text); err != nil {
+			t.Errorf("lexer.UnquoteString(%s) error = %v", tokens[0].Text, err)
+		} else if !test.ascii && value != test.value {
+			t.Errorf("lexer.UnquoteString(%s) = %q, want %q", tokens[0].Text, value, test.value)
+		}
+	}
+}
+
+func TestQuoteChar(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		value rune
+		ascii bool
+		want  string
+	}{
+		{'\'', false, `'\''`},
+		{'"', false, `'"'`},
+		{'\n', false, `'\n'`},
+		{'\u00e9', false, "'\u00e9'"},
+		{'\u00e9', true, `'\u00E9'`},
+		{0xD800, false, `'\uD800'`},
+	}
+	for _, test := range tests {
+		got, err := lexer.QuoteChar(test.value, test.ascii)
+		if err != nil {
+			t.Errorf("lexer.QuoteChar(%q, %t) error = %v", test.value, test.ascii, err)
+		} else if got != test.want {
+			t.Errorf("lexer.QuoteChar(%q, %t) = %s, want %s", test.value, test.ascii, got, test.want)
+		}
+	}
+	if _, err := lexer.QuoteChar('\U0001F600', false); err == nil {
+		t.Error("lexer.QuoteChar('\\U0001F600', false) error = nil, want error")
+	}
+}
+
+func TestUnquote_Errors(t *testing.T) {
+	t.Parallel()
+	unquoteChar := func(text string) (string, error) {
+		r, err := lexer.UnquoteChar(text)
+		return string(r), err
+	}
+	tests := []struct {
+		name    string
+		unquote func(string) (string, error)
+		text    string
+		wantErr bool
+	}{
+		{"UnquoteString", lexer.UnquoteString, `""`, false},
+		{"UnquoteString", lexer.UnquoteString, `"`, true},
+		{"UnquoteString", lexer.UnquoteString, ``, true},
+		{"UnquoteString", lexer.UnquoteString, `"""x"""`, true},
+		{"UnquoteString", lexer.UnquoteString, "\"a\nb\"", true},
+		{"UnquoteString", lexer.UnquoteString, `''`, true},
+		{"UnquoteTextBlock", lexer.UnquoteTextBlock, `""`, true},
+		{"UnquoteTextBlock", lexer.UnquoteTextBlock, `"`, true},
+		{"UnquoteTextBlock", lexer.UnquoteTextBlock, `"""x"""`, true},
+		{"UnquoteTextBlock", lexer.UnquoteTextBlock, `""""""`, true},
+		{"UnquoteTextBlock", lexer.UnquoteTextBlock, "\"\"\"x\n\"\"\"", true},
+		{"UnquoteTextBlock", lexer.UnquoteTextBlock, "\"\"\" \n\"\"\"", false},
+		{"UnquoteChar", unquoteChar, `''`, true},
+		{"UnquoteChar", unquoteChar, `'`, true},
+		{"UnquoteChar", unquoteChar, `""`, true},
+		{"UnquoteChar", unquoteChar, `'ab'`, true},
+		{"UnquoteChar", unquoteChar, "'\U0001F600'", true},
+		{"UnquoteChar", unquoteChar, `'a'`, false},
+	}
+	for _, test := range tests {
+		if _, err := test.unquote(test.text); (err != nil) != test.wantErr {
+			t.Errorf("lexer.%s(%q) error = %v, want error %t", test.name, test.text, err, test.wantErr)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package lexer
+
+import (
+	"fmt"
+)
+
+// A Kind enumerates all kinds of tokens.
+type Kind int
+
+const (
+	EOF             Kind = iota // The end of the input.
+	IDENTIFIER                  // An identifier, including contextual keywords such as "var" or "record".
+	KEYWORD                     // A reserved keyword, including "_".
+	INT_LITERAL                 // An integer literal of type "int".
+	LONG_LITERAL                // An integer literal of type "long".
+	FLOAT_LITERAL               // A floating-point literal of type "float".
+	DOUBLE_LITERAL              // A floating-point literal of type "double".
+	BOOLEAN_LITERAL             // The literal "true" or "false".
+	CHAR_LITERAL                // A character literal.
+	STRING_LITERAL              // A string literal.
+	TEXT_BLOCK                  // A text block.
+	NULL_LITERAL                // The literal "null".
+	OPERATOR                    // An operator such as "+" or ">>>=".
+	SEPARATOR                   // A separator such as "(" or "::".
+	LINE_COMMENT                // A "//" comment.
+	BLOCK_COMMENT               // A "/* */" comment.
+	DOC_COMMENT                 // A "/** */" documentation comment.
+)
+
+var kinds = [...]string{
+	EOF:             "EOF",
+	IDENTIFIER:      "IDENTIFIER",
+	KEYWORD:         "KEYWORD",
+	INT_LITERAL:     "INT_LITERAL",
+	LONG_LITERAL:    "LONG_LITERAL",
+	FLOAT_LITERAL:   "FLOAT_LITERAL",
+	DOUBLE_LITERAL:  "DOUBLE_LITERAL",
+	BOOLEAN_LITERAL: "BOOLEAN_LITERAL",
+	CHAR_LITERAL:    "CHAR_LITERAL",
+	STRING_LITERAL:  "STRING_LITERAL",
+	TEXT_BLOCK:      "TEXT_BLOCK",
+	NULL_LITERAL:    "NULL_LITERAL",
+	OPERATOR:        "OPERATOR",
+	SEPARATOR:       "SEPARATOR",
+	LINE_COMMENT:    "LINE_COMMENT",
+	BLOCK_COMMENT:   "BLOCK_COMMENT",
+	DOC_COMMENT:     "DOC_COMMENT",
+}
+
+// Implements [fmt.Stringer] interface for [Kind].
+func (k Kind) String() string {
+	if k >= 0 && int(k) < len(kinds) {
+		return kinds[k]
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Reports whether the kind is a literal.
+func (k Kind) IsLiteral() bool { return INT_LITERAL <= k && k <= NULL_LITERAL }
+
+// Reports whether the kind is a comment.
+func (k Kind) IsComment() bool { return LINE_COMMENT <= k && k <= DOC_COMMENT }
+
+// A Token is a single lexical element of the source.
+type Token struct {
+	Kind   Kind
+	Text   string // The text of the token after Unicode escapes have been translated.
+	Offset int    // The byte offset of the first character of the token in the source.
+	End    int    // The byte offset immediately after the last character of the token in the source.
+	Line   int    // The 1-based line of the first character of the token.
+	Column int    // The 1-based byte column of the first character of the token.
+}
+
+// Implements [fmt.Stringer] interface for [Token].
+func (t Token) String() string {
+	if t.Kind == EOF {
+		return "end of file"
+	}
+	return fmt.Sprintf("%q", t.Text)
+}
@@ -0,0 +1,101 @@
+package javast
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/kapavkin/javast/lexer"
+)
+
+// Returns a string literal with the value, which is escaped when the literal is written.
+// Set [StringLiteral.ASCII] to write non-ASCII characters as Unicode escapes.
+func NewStringLiteral(value string) StringLiteral {
+	return StringLiteral{Value: value}
+}
+
+// Returns a text block literal with the value, which is escaped when the literal is written.
+// Set [TextBlockLiteral.ASCII] to write non-ASCII characters as Unicode escapes.
+func NewTextBlockLiteral(value string) TextBlockLiteral {
+	return TextBlockLiteral{Value: value}
+}
+
+// Returns a character literal with the value, which is escaped when the literal is written.
+// Set [CharLiteral.ASCII] to write non-ASCII characters as Unicode escapes.
+// Writing the literal fails if the value is not a single UTF-16 code unit.
+func NewCharLiteral(value rune) CharLiteral {
+	return CharLiteral{Value: lexer.EncodeRune(value)}
+}
+
+// Returns an int literal with the value, or a unary minus of one if the value is negative.
+func NewIntLiteral(value int32) ExpressionNode {
+	if value < 0 {
+		return UnaryMinus{Expression: IntLiteral{Value: strconv.FormatUint(uint64(-int64(value)), 10)}}
+	}
+	return IntLiteral{Value: strconv.FormatInt(int64(value), 10)}
+}
+
+// Returns a long literal with the value, or a unary minus of one if the value is negative.
+func NewLongLiteral(value int64) ExpressionNode {
+	if value < 0 {
+		return UnaryMinus{Expression: LongLiteral{Value: strconv.FormatUint(-uint64(value), 10) + "L"}}
+	}
+	return LongLiteral{Value: strconv.FormatInt(value, 10) + "L"}
+}
+
+// Returns a float literal with the shortest decimal representation of the value, or a unary minus of one if the value is negative.
+// NaN and infinities are represented by the constants of java.lang.Float, such as Float.NaN.
+func NewFloatLiteral(value float32) ExpressionNode {
+	if x := floatConstant("Float", float64(value)); x != nil {
+		return x
+	}
+	if math.Signbit(float64(value)) {
+		return UnaryMinus{Expression: FloatLiteral{Value: formatFloat(float64(-value), 32) + "f"}}
+	}
+	return FloatLiteral{Value: formatFloat(float64(value), 32) + "f"}
+}
+
+// Returns a double literal with the shortest decimal representation of the value, or a unary minus of one if the value is negative.
+// NaN and infinities are represented by the constants of java.lang.Double, such as Double.NaN.
+func NewDoubleLiteral(value float64) ExpressionNode {
+	if x := floatConstant("Double", value); x != nil {
+		return x
+	}
+	if math.Signbit(value) {
+		return UnaryMinus{Expression: DoubleLiteral{Value: formatFloat(-value, 64)}}
+	}
+	return DoubleLiteral{Value: formatFloat(value, 64)}
+}
+
+// Returns the constant of the class for NaN or an infinity, or nil for other values.
+func floatConstant(class string, value float64) ExpressionNode {
+	var name string
+	switch {
+	case math.IsNaN(value):
+		name = "NaN"
+	case math.IsInf(value, 1):
+		name = "POSITIVE_INFINITY"
+	case math.IsInf(value, -1):
+		name = "NEGATIVE_INFINITY"
+	default:
+		return nil
+	}
+	return MemberSelect{Expression: Identifier{Name: class}, Identifier: name}
+}
+
+// Formats a non-negative finite value of the bit size, such as "1.0", "0.1" or "1.0E-10".
+func formatFloat(value float64, bitSize int) string {
+	s := strconv.FormatFloat(value, 'g', -1, bitSize)
+	mantissa, exponent, found := strings.Cut(s, "e")
+	if !strings.Contains(mantissa, ".") {
+		mantissa += ".0"
+	}
+	if !found {
+		return mantissa
+	}
+	sign, digits := "", strings.TrimPrefix(exponent, "+")
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	return mantissa + "E" + sign + strings.TrimLeft(digits, "0")
+}
@@ -0,0 +1,101 @@
+package javast_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/kapavkin/javast"
+)
+
+func TestLiteral_Escape(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		node javast.Node
+		want string
+	}{
+		{javast.NewStringLiteral("C:\\temp\n\"x\"\x00"), `"C:\\temp\n\"x\"\000"`},
+		{javast.StringLiteral{Value: "na\u00efve", ASCII: true}, `"na\u00EFve"`},
+		{javast.NewCharLiteral('\''), `'\''`},
+		{javast.NewCharLiteral('\\'), `'\\'`},
+		{javast.CharLiteral{Value: "\u00e9", ASCII: true}, `'\u00E9'`},
+	}
+	for _, test := range tests {
+		sw := SpaceWriter{}
+		if _, err := test.node.WriteTo(&sw); err != nil {
+			t.Error(err)
+		}
+		if got := sw.String(); got != test.want {
+			t.Errorf("sw.String() = %s, want %s", got, test.want)
+		}
+	}
+}
+
+func TestNewCharLiteral_Error(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	if _, err := javast.NewCharLiteral('\U0001F600').WriteTo(&sw); err == nil {
+		t.Error("WriteTo() error = nil, want error")
+	}
+}
+
+func TestNewNumericLiteral(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		node javast.Node
+		want string
+	}{
+		{javast.NewIntLiteral(42), "42"},
+		{javast.NewIntLiteral(math.MinInt32), "- 2147483648"},
+		{javast.NewLongLiteral(math.MaxInt64), "9223372036854775807L"},
+		{javast.NewLongLiteral(math.MinInt64), "- 9223372036854775808L"},
+		{javast.NewFloatLiteral(1), "1.0f"},
+		{javast.NewFloatLiteral(0.1), "0.1f"},
+		{javast.NewFloatLiteral(float32(math.Inf(-1))), "Float . NEGATIVE_INFINITY"},
+		{javast.NewDoubleLiteral(1e-10), "1.0E-10"},
+		{javast.NewDoubleLiteral(1e21), "1.0E21"},
+		{javast.NewDoubleLiteral(math.Copysign(0, -1)), "- 0.0"},
+		{javast.NewDoubleLiteral(math.NaN()), "Double . NaN"},
+	}
+	for _, test := range tests {
+		sw := SpaceWriter{}
+		if _, err := test.node.WriteTo(&sw); err != nil {
+			t.Error(err)
+		}
+		if got := sw.String(); got != test.want {
+			t.Errorf("sw.String() = %s, want %s", got, test.want)
+		}
+		if err := javast.ValidateLiterals(test.node); err != nil {
+			t.Errorf("ValidateLiterals(%s) = %v, want nil", test.want, err)
+		}
+	}
+}
+
+func TestLiteral_Surrogates(t *testing.T) {
+	t.Parallel()
+	src := `class Surrogates {
+    char high = '\uD800';
+    String low = "\uDC00x\uD83D\uDE00";
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sw := SpaceWriter{}
+	if _, err := cu.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "class Surrogates { char high = '\\uD800' ; String low = \"\\uDC00x\U0001F600\" ; }"
+	if got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+	sw = SpaceWriter{}
+	if _, err := javast.NewCharLiteral(0xDFFF).WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	if got, want := sw.String(), `'\uDFFF'`; got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+}
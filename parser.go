@@ -0,0 +1,1949 @@
+package javast
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/kapavkin/javast/lexer"
+)
+
+var modifierFlags = map[string]Modifier{
+	"public":       PUBLIC_MODIFIER,
+	"protected":    PROTECTED_MODIFIER,
+	"private":      PRIVATE_MODIFIER,
+	"abstract":     ABSTRACT_MODIFIER,
+	"default":      DEFAULT_MODIFIER,
+	"static":       STATIC_MODIFIER,
+	"final":        FINAL_MODIFIER,
+	"transient":    TRANSIENT_MODIFIER,
+	"volatile":     VOLATILE_MODIFIER,
+	"synchronized": SYNCHRONIZED_MODIFIER,
+	"native":       NATIVE_MODIFIER,
+	"strictfp":     STRICTFP_MODIFIER,
+}
+
+var primitiveTypes = map[string]TypeKind{
+	"boolean": BOOLEAN_TYPE_KIND,
+	"byte":    BYTE_TYPE_KIND,
+	"short":   SHORT_TYPE_KIND,
+	"int":     INT_TYPE_KIND,
+	"long":    LONG_TYPE_KIND,
+	"char":    CHAR_TYPE_KIND,
+	"float":   FLOAT_TYPE_KIND,
+	"double":  DOUBLE_TYPE_KIND,
+}
+
+// Binary operators by precedence, from the loosest to the tightest binding.
+var binaryPrecedence = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"|":  3,
+	"^":  4,
+	"&":  5,
+	"==": 6, "!=": 6,
+	"<": 7, ">": 7, "<=": 7, ">=": 7, "instanceof": 7,
+	"<<": 8, ">>": 8, ">>>": 8,
+	"+": 9, "-": 9,
+	"*": 10, "/": 10, "%": 10,
+}
+
+var binaryOperators = map[string]func(s Span, l, r ExpressionNode) ExpressionNode{
+	"||":  func(s Span, l, r ExpressionNode) ExpressionNode { return ConditionalOr{s, l, r} },
+	"&&":  func(s Span, l, r ExpressionNode) ExpressionNode { return ConditionalAnd{s, l, r} },
+	"|":   func(s Span, l, r ExpressionNode) ExpressionNode { return Or{s, l, r} },
+	"^":   func(s Span, l, r ExpressionNode) ExpressionNode { return Xor{s, l, r} },
+	"&":   func(s Span, l, r ExpressionNode) ExpressionNode { return And{s, l, r} },
+	"==":  func(s Span, l, r ExpressionNode) ExpressionNode { return EqualTo{s, l, r} },
+	"!=":  func(s Span, l, r ExpressionNode) ExpressionNode { return NotEqualTo{s, l, r} },
+	"<":   func(s Span, l, r ExpressionNode) ExpressionNode { return LessThan{s, l, r} },
+	">":   func(s Span, l, r ExpressionNode) ExpressionNode { return GreaterThan{s, l, r} },
+	"<=":  func(s Span, l, r ExpressionNode) ExpressionNode { return LessThanEqual{s, l, r} },
+	">=":  func(s Span, l, r ExpressionNode) ExpressionNode { return GreaterThanEqual{s, l, r} },
+	"<<":  func(s Span, l, r ExpressionNode) ExpressionNode { return LeftShift{s, l, r} },
+	">>":  func(s Span, l, r ExpressionNode) ExpressionNode { return RightShift{s, l, r} },
+	">>>": func(s Span, l, r ExpressionNode) ExpressionNode { return UnsignedRightShift{s, l, r} },
+	"+":   func(s Span, l, r ExpressionNode) ExpressionNode { return Plus{s, l, r} },
+	"-":   func(s Span, l, r ExpressionNode) ExpressionNode { return Minus{s, l, r} },
+	"*":   func(s Span, l, r ExpressionNode) ExpressionNode { return Multiply{s, l, r} },
+	"/":   func(s Span, l, r ExpressionNode) ExpressionNode { return Divide{s, l, r} },
+	"%":   func(s Span, l, r ExpressionNode) ExpressionNode { return Remainder{s, l, r} },
+}
+
+var assignmentOperators = map[string]func(s Span, v, x ExpressionNode) ExpressionNode{
+	"=":    func(s Span, v, x ExpressionNode) ExpressionNode { return Assignment{s, v, x} },
+	"*=":   func(s Span, v, x ExpressionNode) ExpressionNode { return MultiplyAssignment{s, v, x} },
+	"/=":   func(s Span, v, x ExpressionNode) ExpressionNode { return DivideAssignment{s, v, x} },
+	"%=":   func(s Span, v, x ExpressionNode) ExpressionNode { return RemainderAssignment{s, v, x} },
+	"+=":   func(s Span, v, x ExpressionNode) ExpressionNode { return PlusAssignment{s, v, x} },
+	"-=":   func(s Span, v, x ExpressionNode) ExpressionNode { return MinusAssignment{s, v, x} },
+	"<<=":  func(s Span, v, x ExpressionNode) ExpressionNode { return LeftShiftAssignment{s, v, x} },
+	">>=":  func(s Span, v, x ExpressionNode) ExpressionNode { return RightShiftAssignment{s, v, x} },
+	">>>=": func(s Span, v, x ExpressionNode) ExpressionNode { return UnsignedRightShiftAssignment{s, v, x} },
+	"&=":   func(s Span, v, x ExpressionNode) ExpressionNode { return AndAssignment{s, v, x} },
+	"^=":   func(s Span, v, x ExpressionNode) ExpressionNode { return XorAssignment{s, v, x} },
+	"|=":   func(s Span, v, x ExpressionNode) ExpressionNode { return OrAssignment{s, v, x} },
+}
+
+var unaryOperators = map[string]func(s Span, x ExpressionNode) ExpressionNode{
+	"++": func(s Span, x ExpressionNode) ExpressionNode { return PrefixIncrement{s, x} },
+	"--": func(s Span, x ExpressionNode) ExpressionNode { return PrefixDecrement{s, x} },
+	"+":  func(s Span, x ExpressionNode) ExpressionNode { return UnaryPlus{s, x} },
+	"-":  func(s Span, x ExpressionNode) ExpressionNode { return UnaryMinus{s, x} },
+	"!":  func(s Span, x ExpressionNode) ExpressionNode { return LogicalComplement{s, x} },
+	"~":  func(s Span, x ExpressionNode) ExpressionNode { return BitwiseComplement{s, x} },
+}
+
+// Parses the Java source read from r into a compilation unit.
+// The positions of the nodes belong to a file set of their own; use [ParseFile] to resolve them.
+// Comments are attached to the declarations and statements they surround, see [Comments].
+// The remaining comments before the closing brace of a block, a type body or a switch body,
+// including those of an empty body, are kept as the dangling comments of the body.
+// Other comments, such as those within expressions, are dropped.
+func Parse(r io.Reader) (CompilationUnitNode, error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFile(NewFileSet(), "", src)
+}
+
+// Parses the Java source of the named file into a compilation unit.
+// The file is added to the file set, which resolves the positions of the nodes.
+// The filename is only used for positions and error messages.
+func ParseFile(fset *FileSet, filename string, src []byte) (CompilationUnitNode, error) {
+	file := fset.AddFile(filename, len(src))
+	file.SetLinesForContent(src)
+	p, err := newParser(file, src)
+	var cu CompilationUnitNode
+	if err == nil {
+		cu, err = p.parse()
+	}
+	if err != nil && filename != "" {
+		err = fmt.Errorf("%s:%w", filename, err)
+	}
+	return cu, err
+}
+
+// A bailout is raised by the parser on a syntax error and recovered at the top of the parser.
+type bailout struct {
+	err error
+}
+
+// A token is a [lexer.Token] with helpers for the parser.
+type token struct {
+	lexer.Token
+}
+
+// A parser is a recursive descent parser over the tokens of the source.
+type parser struct {
+	tokens   []token // The tokens of the source without comments, terminated by a token of kind [lexer.EOF].
+	comments []token // The comments of the source.
+	attached []bool  // Whether each comment has been attached to a node.
+	attaches []int   // The indices in comments of the attached comments, in the order of attachment.
+	pos      int     // The index in tokens of the current token.
+	noLambda bool    // Whether "x ->" must not be parsed as a lambda expression, as in case labels.
+	file     *File   // The file which the positions of the nodes belong to.
+}
+
+func newParser(file *File, src []byte) (*parser, error) {
+	l, err := lexer.New(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{file: file}
+	for {
+		lt, err := l.Next()
+		if err != nil {
+			return nil, err
+		}
+		tok := token{lt}
+		switch {
+		case tok.Kind.IsComment():
+			p.comments = append(p.comments, tok)
+			continue
+		case tok.Kind == lexer.OPERATOR:
+			// Shift operators are split so that they can close nested type arguments.
+			// Adjacent ">" tokens are joined back by [parser.operator].
+			if tok.Text == ">>" || tok.Text == ">>>" {
+				for i := range tok.Text {
+					p.tokens = append(p.tokens, token{lexer.Token{
+						Kind:   lexer.OPERATOR,
+						Text:   ">",
+						Offset: tok.Offset + i,
+						End:    tok.Offset + i + 1,
+						Line:   tok.Line,
+						Column: tok.Column + i,
+					}})
+				}
+				continue
+			}
+		}
+		p.tokens = append(p.tokens, tok)
+		if tok.Kind == lexer.EOF {
+			p.attached = make([]bool, len(p.comments))
+			return p, nil
+		}
+	}
+}
+
+func (p *parser) parse() (cu CompilationUnitNode, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			b, ok := r.(bailout)
+			if !ok {
+				panic(r)
+			}
+			cu, err = nil, b.err
+		}
+	}()
+	return p.parseCompilationUnit(), nil
+}
+
+// Reports whether the token is the keyword, operator or separator.
+func (t token) is(text string) bool {
+	switch t.Kind {
+	case lexer.KEYWORD, lexer.OPERATOR, lexer.SEPARATOR:
+		return t.Text == text
+	}
+	return false
+}
+
+// Reports whether the token is the contextual keyword.
+func (t token) isIdent(text string) bool { return t.Kind == lexer.IDENTIFIER && t.Text == text }
+
+// Reports whether the token is a primitive type other than void.
+func (t token) isPrimitiveType() bool {
+	_, ok := primitiveTypes[t.Text]
+	return ok && t.Kind == lexer.KEYWORD
+}
+
+func (p *parser) errorf(tok token, format string, args ...any) {
+	panic(bailout{err: fmt.Errorf("%d:%d: %s", tok.Line, tok.Column, fmt.Sprintf(format, args...))})
+}
+
+// Runs f and reports whether it succeeded.
+// If f fails, the parser is reset to the position before f.
+func (p *parser) speculate(f func()) (ok bool) {
+	pos, attaches := p.pos, len(p.attaches)
+	defer func() {
+		if r := recover(); r != nil {
+			if _, isBailout := r.(bailout); !isBailout {
+				panic(r)
+			}
+			p.pos = pos
+			for _, i := range p.attaches[attaches:] {
+				p.attached[i] = false
+			}
+			p.attaches = p.attaches[:attaches]
+			ok = false
+		}
+	}()
+	f()
+	return true
+}
+
+func (p *parser) tok() token { return p.tokens[p.pos] }
+
+// Returns the position of the current token.
+func (p *parser) start() Pos { return p.file.Pos(p.tok().Offset) }
+
+// Returns the span from pos to the end of the last consumed token.
+func (p *parser) span(pos Pos) Span {
+	if p.pos == 0 {
+		return Span{Pos: pos, End: pos}
+	}
+	return Span{Pos: pos, End: p.file.Pos(p.tokens[p.pos-1].End)}
+}
+
+// Returns the comments of a node which starts at pos and ends with the last consumed token.
+// The leading comments are the comments between the previous token and the node.
+// The trailing comments are the comments following the node on the same line,
+// or all comments up to the end of the file if the node is the last one.
+// The other comments before a closing brace are the dangling comments of the body.
+// Comments which are already attached to another node are skipped.
+func (p *parser) attach(pos Pos) Comments {
+	c := Comments{}
+	offset := p.file.Offset(pos)
+	begin := sort.Search(len(p.tokens), func(i int) bool { return p.tokens[i].Offset >= offset })
+	prevEnd := 0
+	if begin > 0 {
+		prevEnd = p.tokens[begin-1].End
+	}
+	for _, i := range p.commentsBetween(prevEnd, offset) {
+		c.Leading = append(c.Leading, p.attachComment(i))
+	}
+	if p.pos == 0 {
+		return c
+	}
+	last, next := p.tokens[p.pos-1], p.tok()
+	eof := next.Kind == lexer.EOF
+	line := p.file.Position(p.file.Pos(last.End)).Line
+	for _, i := range p.commentsBetween(last.End, next.Offset) {
+		if !eof && p.comments[i].Line != line {
+			break
+		}
+		c.Trailing = append(c.Trailing, p.attachComment(i))
+	}
+	return c
+}
+
+// Returns the comments of a declaration which starts at pos and ends with the last consumed token.
+// The last documentation comment among the leading comments becomes the documentation comment of the declaration.
+func (p *parser) attachDoc(pos Pos) Comments {
+	c := p.attach(pos)
+	for i := len(c.Leading) - 1; i >= 0; i-- {
+		if strings.HasPrefix(c.Leading[i].Text, "/**") && c.Leading[i].Text != "/**/" {
+			dc := ParseDocComment(c.Leading[i].Text)
+			dc.Span = c.Leading[i].Span
+			c.Doc = &dc
+			c.Leading = append(c.Leading[:i:i], c.Leading[i+1:]...)
+			break
+		}
+	}
+	return c
+}
+
+// Returns the dangling comments of a body, which are the unattached comments before the current token,
+// its closing brace, and after the last member or statement and its trailing comments.
+func (p *parser) dangling() []Comment {
+	var comments []Comment
+	for _, i := range p.commentsBetween(p.tokens[p.pos-1].End, p.tok().Offset) {
+		comments = append(comments, p.attachComment(i))
+	}
+	return comments
+}
+
+// Returns the indices of the unattached comments between the offsets.
+func (p *parser) commentsBetween(start, end int) []int {
+	var indices []int
+	for i := sort.Search(len(p.comments), func(i int) bool { return p.comments[i].Offset >= start }); i < len(p.comments) && p.comments[i].End <= end; i++ {
+		if !p.attached[i] {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// Marks the comment as attached and returns it.
+func (p *parser) attachComment(i int) Comment {
+	p.attached[i] = true
+	p.attaches = append(p.attaches, i)
+	tok := p.comments[i]
+	return Comment{Span: Span{Pos: p.file.Pos(tok.Offset), End: p.file.Pos(tok.End)}, Text: tok.Text}
+}
+
+func (p *parser) peek(n int) token {
+	if p.pos+n < len(p.tokens) {
+		return p.tokens[p.pos+n]
+	}
+	return p.tokens[len(p.tokens)-1]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.Kind != lexer.EOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) at(text string) bool { return p.tok().is(text) }
+
+func (p *parser) accept(text string) bool {
+	if p.at(text) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(text string) token {
+	if !p.at(text) {
+		p.errorf(p.tok(), "expected %q, found %s", text, p.tok())
+	}
+	return p.next()
+}
+
+func (p *parser) ident() string {
+	if tok := p.tok(); tok.Kind != lexer.IDENTIFIER && !tok.is("_") {
+		p.errorf(tok, "expected identifier, found %s", tok)
+	}
+	return p.next().Text
+}
+
+// Parses an identifier as an [Identifier] node.
+func (p *parser) parseIdentifier() Identifier {
+	pos := p.start()
+	name := p.ident()
+	return Identifier{Span: p.span(pos), Name: name}
+}
+
+// Returns the operator at the current position and the number of tokens it spans.
+func (p *parser) operator() (string, int) {
+	tok := p.tok()
+	if tok.Kind != lexer.OPERATOR {
+		return "", 0
+	}
+	if tok.Text != ">" {
+		return tok.Text, 1
+	}
+	n := 1
+	for n < 3 && p.peek(n).is(">") && p.peek(n).Offset == p.peek(n-1).End {
+		n++
+	}
+	return ">>>"[:n], n
+}
+
+func (p *parser) parseCompilationUnit() CompilationUnit {
+	cu := CompilationUnit{Span: Span{Pos: p.file.Pos(0), End: p.file.Pos(p.file.Size())}}
+	pos, mods, modsTok := p.start(), p.parseModifiers(), p.tok()
+	if p.at("package") {
+		if len(mods.Flags) > 0 {
+			p.errorf(modsTok, "unexpected modifiers on package declaration")
+		}
+		p.next()
+		pkg := Package{Annotations: mods.Annotations, PackageName: p.parseQualifiedName()}
+		p.expect(";")
+		pkg.Span, pkg.Comments = p.span(pos), p.attachDoc(pos)
+		cu.Package = pkg
+		pos, mods, modsTok = p.start(), p.parseModifiers(), p.tok()
+	}
+	for p.at("import") || p.at(";") {
+		if p.accept(";") {
+			continue
+		}
+		p.next()
+		i := Import{Static: p.accept("static")}
+		var name ExpressionNode = p.parseIdentifier()
+		for p.accept(".") {
+			if p.accept("*") {
+				name = MemberSelect{Span: p.span(name.GetPos()), Expression: name, Identifier: "*"}
+				break
+			}
+			ident := p.ident()
+			name = MemberSelect{Span: p.span(name.GetPos()), Expression: name, Identifier: ident}
+		}
+		i.QualifiedIdentifier = name
+		p.expect(";")
+		i.Span, i.Comments = p.span(pos), p.attach(pos)
+		cu.Imports = append(cu.Imports, i)
+		pos, mods, modsTok = p.start(), p.parseModifiers(), p.tok()
+	}
+	if p.tok().isIdent("open") && p.peek(1).isIdent("module") || p.tok().isIdent("module") && p.peek(1).Kind == lexer.IDENTIFIER {
+		if len(mods.Flags) > 0 {
+			p.errorf(modsTok, "unexpected modifiers on module declaration")
+		}
+		cu.Module = p.parseModule(pos, mods.Annotations)
+		if p.tok().Kind != lexer.EOF {
+			p.errorf(p.tok(), "unexpected %s after module declaration", p.tok())
+		}
+		return cu
+	}
+	for first := true; p.tok().Kind != lexer.EOF; first = false {
+		if !first {
+			mods = p.parseModifiers()
+		}
+		if p.accept(";") {
+			continue
+		}
+		cu.TypeDecls = append(cu.TypeDecls, p.parseTypeDeclaration(mods))
+	}
+	return cu
+}
+
+func (p *parser) parseModule(pos Pos, annotations []AnnotationNode) Module {
+	m := Module{Annotations: annotations, ModuleType: STRONG_MODULE_KIND}
+	if p.tok().isIdent("open") {
+		p.next()
+		m.ModuleType = OPEN_MODULE_KIND
+	}
+	p.next()
+	m.Name = p.parseQualifiedName()
+	p.expect("{")
+	for !p.at("}") {
+		pos, tok := p.start(), p.next()
+		var d DirectiveNode
+		switch {
+		case tok.isIdent("requires"):
+			r := Requires{}
+			for {
+				if p.at("static") {
+					p.next()
+					r.Static = true
+				} else if p.tok().isIdent("transitive") && p.peek(1).Kind == lexer.IDENTIFIER {
+					p.next()
+					r.Transitive = true
+				} else {
+					break
+				}
+			}
+			r.ModuleName = p.parseQualifiedName()
+			p.expect(";")
+			r.Span = p.span(pos)
+			d = r
+		case tok.isIdent("exports"), tok.isIdent("opens"):
+			name := p.parseQualifiedName()
+			var modules []ExpressionNode
+			if p.tok().isIdent("to") {
+				p.next()
+				modules = p.parseQualifiedNames()
+			}
+			p.expect(";")
+			if tok.Text == "exports" {
+				d = Exports{Span: p.span(pos), PackageName: name, ModuleNames: modules}
+			} else {
+				d = Opens{Span: p.span(pos), PackageName: name, ModuleNames: modules}
+			}
+		case tok.isIdent("uses"):
+			name := p.parseQualifiedName()
+			p.expect(";")
+			d = Uses{Span: p.span(pos), ServiceName: name}
+		case tok.isIdent("provides"):
+			pr := Provides{ServiceName: p.parseQualifiedName()}
+			if !p.tok().isIdent("with") {
+				p.errorf(p.tok(), "expected \"with\", found %s", p.tok())
+			}
+			p.next()
+			pr.ImplementationNames = p.parseQualifiedNames()
+			p.expect(";")
+			pr.Span = p.span(pos)
+			d = pr
+		default:
+			p.errorf(tok, "expected module directive, found %s", tok)
+		}
+		m.Directives = append(m.Directives, d)
+	}
+	p.next()
+	m.Span = p.span(pos)
+	return m
+}
+
+func (p *parser) parseQualifiedName() ExpressionNode {
+	var name ExpressionNode = p.parseIdentifier()
+	for p.at(".") && p.peek(1).Kind == lexer.IDENTIFIER {
+		p.next()
+		ident := p.ident()
+		name = MemberSelect{Span: p.span(name.GetPos()), Expression: name, Identifier: ident}
+	}
+	return name
+}
+
+func (p *parser) parseQualifiedNames() []ExpressionNode {
+	names := []ExpressionNode{p.parseQualifiedName()}
+	for p.accept(",") {
+		names = append(names, p.parseQualifiedName())
+	}
+	return names
+}
+
+// Parses modifiers and declaration annotations in any order.
+// Empty modifiers have no position.
+func (p *parser) parseModifiers() Modifiers {
+	pos, m := p.start(), Modifiers{}
+	seen := map[Modifier]bool{}
+loop:
+	for {
+		tok := p.tok()
+		var flag Modifier
+		switch {
+		case tok.is("@") && !p.peek(1).is("interface"):
+			m.Annotations = append(m.Annotations, p.parseAnnotation())
+			continue
+		case tok.Kind == lexer.KEYWORD && tok.Text == "default" && (p.peek(1).is(":") || p.peek(1).is("->")):
+			break loop
+		case tok.Kind == lexer.KEYWORD && tok.Text == "synchronized" && p.peek(1).is("("):
+			break loop
+		case tok.Kind == lexer.KEYWORD:
+			f, ok := modifierFlags[tok.Text]
+			if !ok {
+				break loop
+			}
+			flag = f
+		case tok.isIdent("sealed") && p.isModifierFollower(1):
+			flag = SEALED_MODIFIER
+		case tok.isIdent("non") && p.peek(1).is("-") && p.peek(2).isIdent("sealed") &&
+			p.peek(1).Offset == tok.End && p.peek(2).Offset == p.peek(1).End:
+			p.pos += 2
+			flag = NON_SEALED_MODIFIER
+		default:
+			break loop
+		}
+		if seen[flag] {
+			p.errorf(tok, "repeated modifier")
+		}
+		seen[flag] = true
+		m.Flags = append(m.Flags, flag)
+		p.next()
+	}
+	if len(m.Flags) > 0 || len(m.Annotations) > 0 {
+		m.Span = p.span(pos)
+	}
+	return m
+}
+
+// Returns the position of a declaration with the modifiers, which starts at the modifiers if there are any.
+func (p *parser) declarationStart(mods Modifiers) Pos {
+	if mods.Pos.IsValid() {
+		return mods.Pos
+	}
+	return p.start()
+}
+
+// Reports whether the token at offset n may follow a contextual modifier such as "sealed".
+func (p *parser) isModifierFollower(n int) bool {
+	tok := p.peek(n)
+	switch {
+	case tok.is("class"), tok.is("interface"), tok.is("@"):
+		return true
+	case tok.Kind == lexer.KEYWORD:
+		_, ok := modifierFlags[tok.Text]
+		return ok
+	}
+	return tok.isIdent("sealed") || tok.isIdent("non") || tok.isIdent("record")
+}
+
+func (p *parser) parseAnnotation() Annotation {
+	pos := p.start()
+	p.expect("@")
+	a := Annotation{AnnotationType: p.parseQualifiedName()}
+	if p.accept("(") {
+		if !p.at(")") {
+			if p.tok().Kind == lexer.IDENTIFIER && p.peek(1).is("=") {
+				for {
+					name := p.parseIdentifier()
+					p.expect("=")
+					value := p.parseElementValue()
+					a.Arguments = append(a.Arguments, Assignment{Span: p.span(name.Pos), Variable: name, Expression: value})
+					if !p.accept(",") {
+						break
+					}
+				}
+			} else {
+				a.Arguments = append(a.Arguments, p.parseElementValue())
+			}
+		}
+		p.expect(")")
+	}
+	a.Span = p.span(pos)
+	return a
+}
+
+func (p *parser) parseTypeAnnotations() []AnnotationNode {
+	var annotations []AnnotationNode
+	for p.at("@") && !p.peek(1).is("interface") {
+		a := p.parseAnnotation()
+		annotations = append(annotations, TypeAnnotation{Span: a.Span, AnnotationType: a.AnnotationType, Arguments: a.Arguments})
+	}
+	return annotations
+}
+
+func (p *parser) parseElementValue() ExpressionNode {
+	switch {
+	case p.at("@"):
+		return p.parseAnnotation()
+	case p.at("{"):
+		return p.parseArrayInitializer(p.parseElementValue)
+	}
+	return p.parseTernary()
+}
+
+// Parses an array initializer whose elements are parsed by elem.
+func (p *parser) parseArrayInitializer(elem func() ExpressionNode) NewArray {
+	pos := p.start()
+	p.expect("{")
+	na := NewArray{Initializers: []ExpressionNode{}}
+	for !p.at("}") {
+		na.Initializers = append(na.Initializers, elem())
+		if !p.accept(",") {
+			break
+		}
+	}
+	p.expect("}")
+	na.Span = p.span(pos)
+	return na
+}
+
+func (p *parser) isTypeDeclarationStart() bool {
+	return p.at("class") || p.at("interface") || p.at("enum") ||
+		p.at("@") && p.peek(1).is("interface") || p.isRecordStart()
+}
+
+func (p *parser) isRecordStart() bool {
+	return p.tok().isIdent("record") && p.peek(1).Kind == lexer.IDENTIFIER && (p.peek(2).is("(") || p.peek(2).is("<"))
+}
+
+func (p *parser) parseTypeDeclaration(mods Modifiers) ClassNode {
+	switch {
+	case p.at("class"):
+		return p.parseClass(mods)
+	case p.at("interface"):
+		return p.parseInterface(mods)
+	case p.at("enum"):
+		return p.parseEnum(mods)
+	case p.at("@") && p.peek(1).is("interface"):
+		pos := p.declarationStart(mods)
+		p.pos += 2
+		at := AnnotationType{Modifiers: mods, SimpleName: p.ident()}
+		at.Members, at.Dangling = p.parseClassBody(at.SimpleName, ANNOTATION_TYPE)
+		at.Span, at.Comments = p.span(pos), p.attachDoc(pos)
+		return at
+	case p.isRecordStart():
+		return p.parseRecord(mods)
+	}
+	p.errorf(p.tok(), "expected class, interface, enum, or record declaration, found %s", p.tok())
+	return nil
+}
+
+func (p *parser) parseClass(mods Modifiers) Class {
+	pos := p.declarationStart(mods)
+	p.expect("class")
+	c := Class{Modifiers: mods, SimpleName: p.ident()}
+	c.TypeParameters = p.parseTypeParametersOpt()
+	if p.accept("extends") {
+		c.ExtendsClause = p.parseType()
+	}
+	if p.accept("implements") {
+		c.ImplementsClause = p.parseTypeList()
+	}
+	if p.tok().isIdent("permits") {
+		p.next()
+		c.PermitsClause = p.parseTypeList()
+	}
+	c.Members, c.Dangling = p.parseClassBody(c.SimpleName, CLASS)
+	c.Span, c.Comments = p.span(pos), p.attachDoc(pos)
+	return c
+}
+
+func (p *parser) parseInterface(mods Modifiers) Interface {
+	pos := p.declarationStart(mods)
+	p.expect("interface")
+	i := Interface{Modifiers: mods, SimpleName: p.ident()}
+	i.TypeParameters = p.parseTypeParametersOpt()
+	if p.accept("extends") {
+		i.ExtendsClause = p.parseTypeList()
+	}
+	if p.tok().isIdent("permits") {
+		p.next()
+		i.PermitsClause = p.parseTypeList()
+	}
+	i.Members, i.Dangling = p.parseClassBody(i.SimpleName, INTERFACE)
+	i.Span, i.Comments = p.span(pos), p.attachDoc(pos)
+	return i
+}
+
+// Parses an enum declaration.
+func (p *parser) parseEnum(mods Modifiers) Enum {
+	pos := p.declarationStart(mods)
+	p.expect("enum")
+	e := Enum{Modifiers: mods, SimpleName: p.ident()}
+	if p.accept("implements") {
+		e.ImplementsClause = p.parseTypeList()
+	}
+	p.expect("{")
+	e.Members = []Node{}
+	for !p.at(";") && !p.at("}") {
+		ec, more := p.parseEnumConstant()
+		e.Constants = append(e.Constants, ec)
+		if !more {
+			break
+		}
+	}
+	if p.accept(";") {
+		for !p.at("}") {
+			if p.tok().Kind == lexer.EOF {
+				p.errorf(p.tok(), "expected \"}\", found %s", p.tok())
+			}
+			e.Members = append(e.Members, p.parseMember(e.SimpleName, ENUM)...)
+		}
+	}
+	e.Dangling = p.dangling()
+	p.expect("}")
+	e.Span, e.Comments = p.span(pos), p.attachDoc(pos)
+	return e
+}
+
+// Parses an enum constant and the comma following it, if any, and reports whether there was a comma.
+// The comments following the comma belong to the constant.
+func (p *parser) parseEnumConstant() (EnumConstant, bool) {
+	pos, mods := p.start(), p.parseModifiers()
+	if len(mods.Flags) > 0 {
+		p.errorf(p.tok(), "unexpected modifiers on enum constant")
+	}
+	ec := EnumConstant{Annotations: mods.Annotations, Name: p.ident()}
+	if p.at("(") {
+		ec.Arguments = p.parseArguments()
+		if ec.Arguments == nil {
+			ec.Arguments = []ExpressionNode{}
+		}
+	}
+	if bodyPos := p.start(); p.at("{") {
+		body := Class{Modifiers: Modifiers{}}
+		body.Members, body.Dangling = p.parseClassBody("", CLASS)
+		body.Span = p.span(bodyPos)
+		ec.ClassBody = body
+	}
+	ec.Span = p.span(pos)
+	more := p.accept(",")
+	ec.Comments = p.attachDoc(pos)
+	return ec, more
+}
+
+// Parses a record declaration.
+func (p *parser) parseRecord(mods Modifiers) Record {
+	pos := p.declarationStart(mods)
+	p.next()
+	r := Record{Modifiers: mods, SimpleName: p.ident()}
+	r.TypeParameters = p.parseTypeParametersOpt()
+	p.expect("(")
+	r.Components = []VariableNode{}
+	for !p.at(")") {
+		r.Components = append(r.Components, p.parseFormalParameter())
+		if !p.accept(",") {
+			break
+		}
+	}
+	p.expect(")")
+	if p.accept("implements") {
+		r.ImplementsClause = p.parseTypeList()
+	}
+	r.Members, r.Dangling = p.parseClassBody(r.SimpleName, RECORD)
+	r.Span, r.Comments = p.span(pos), p.attachDoc(pos)
+	return r
+}
+
+// Parses the body of a class of the kind with the simple name.
+// Returns its members and the comments before its closing brace.
+func (p *parser) parseClassBody(name string, kind Kind) ([]Node, []Comment) {
+	p.expect("{")
+	members := []Node{}
+	for !p.at("}") {
+		if p.tok().Kind == lexer.EOF {
+			p.errorf(p.tok(), "expected \"}\", found %s", p.tok())
+		}
+		members = append(members, p.parseMember(name, kind)...)
+	}
+	dangling := p.dangling()
+	p.next()
+	return members, dangling
+}
+
+// Parses a member of a class of the kind with the simple name.
+// A field declaration with several declarators results in a single [VariableDeclaration] member of them,
+// and a stray semicolon in no member.
+func (p *parser) parseMember(name string, kind Kind) []Node {
+	if p.accept(";") {
+		return nil
+	}
+	if pos := p.start(); p.at("{") {
+		b := p.parseBlock()
+		b.Comments = p.attach(pos)
+		return []Node{b}
+	}
+	if pos := p.start(); p.at("static") && p.peek(1).is("{") {
+		p.next()
+		b := p.parseBlock()
+		b.Static = true
+		b.Span, b.Comments = p.span(pos), p.attach(pos)
+		return []Node{b}
+	}
+	pos, mods := p.start(), p.parseModifiers()
+	if p.isTypeDeclarationStart() {
+		return []Node{p.parseTypeDeclaration(mods)}
+	}
+	typeParameters := p.parseTypeParametersOpt()
+	if p.tok().Kind == lexer.IDENTIFIER && p.peek(1).is("(") {
+		return []Node{p.parseMethodRest(pos, mods, typeParameters, nil, p.ident())}
+	}
+	if kind == RECORD && typeParameters == nil && p.tok().Kind == lexer.IDENTIFIER && p.peek(1).is("{") {
+		m := Method{Modifiers: mods, Name: p.ident(), Compact: true}
+		m.Body = p.parseBlock()
+		m.Span, m.Comments = p.span(pos), p.attachDoc(pos)
+		return []Node{m}
+	}
+	var typ ExpressionNode
+	if voidPos := p.start(); p.accept("void") {
+		typ = PrimitiveType{Span: p.span(voidPos), PrimitiveTypeKind: VOID_TYPE_KIND}
+	} else {
+		typ = p.parseType()
+	}
+	tok := p.tok()
+	memberName := p.ident()
+	if p.at("(") {
+		return []Node{p.parseMethodRest(pos, mods, typeParameters, typ, memberName)}
+	}
+	if typeParameters != nil || typ.GetKind() == PRIMITIVE_TYPE && typ.(PrimitiveType).PrimitiveTypeKind == VOID_TYPE_KIND {
+		p.errorf(tok, "expected \"(\", found %s", p.tok())
+	}
+	vars := p.parseVariableDeclaratorsRest(pos, mods, typ, memberName)
+	p.expect(";")
+	if len(vars) > 1 {
+		return []Node{p.variableDeclaration(pos, FIELD_CONTEXT, vars, p.attachDoc(pos))}
+	}
+	vars[0].Span, vars[0].Comments = p.span(pos), p.attachDoc(pos)
+	return []Node{vars[0]}
+}
+
+func (p *parser) parseMethodRest(pos Pos, mods Modifiers, typeParameters []TypeParameterNode, returnType Node, name string) Method {
+	m := Method{Modifiers: mods, Name: name, ReturnType: returnType, TypeParameters: typeParameters}
+	p.expect("(")
+	for !p.at(")") {
+		v := p.parseFormalParameter()
+		if v.Name == "this" {
+			m.ReceiverParameter = v
+		} else {
+			m.Parameters = append(m.Parameters, v)
+		}
+		if !p.accept(",") {
+			break
+		}
+	}
+	p.expect(")")
+	for p.at("[") && p.peek(1).is("]") {
+		p.pos += 2
+		m.ReturnType = ArrayType{Span: p.span(m.ReturnType.GetPos()), Type: m.ReturnType}
+	}
+	if p.accept("throws") {
+		m.Throws = append(m.Throws, p.parseType())
+		for p.accept(",") {
+			m.Throws = append(m.Throws, p.parseType())
+		}
+	}
+	if p.accept("default") {
+		m.DefaultValue = p.parseElementValue()
+	}
+	if p.at("{") {
+		m.Body = p.parseBlock()
+	} else {
+		p.expect(";")
+	}
+	m.Span, m.Comments = p.span(pos), p.attachDoc(pos)
+	return m
+}
+
+func (p *parser) parseFormalParameter() Variable {
+	pos := p.start()
+	v := Variable{Modifiers: p.parseModifiers()}
+	typ := p.parseType()
+	if p.accept("...") {
+		typ = ArrayType{Span: p.span(typ.GetPos()), Type: typ}
+		v.Varargs = true
+	}
+	if npos := p.start(); p.accept("this") {
+		v.Name, v.Type, v.NameExpression = "this", typ, This{Span: p.span(npos)}
+		v.Span = p.span(pos)
+		return v
+	}
+	npos := p.start()
+	v.Name = p.ident()
+	if p.at(".") && p.peek(1).is("this") {
+		qualifier := Identifier{Span: p.span(npos), Name: v.Name}
+		p.pos += 2
+		v.Name, v.Type, v.NameExpression = "this", typ, This{Span: p.span(npos), Qualifier: qualifier}
+		v.Span = p.span(pos)
+		return v
+	}
+	v.Type = p.parseDimsOpt(typ)
+	v.Span = p.span(pos)
+	return v
+}
+
+// Returns the declaration of several variables, which starts at pos and ends at the current position.
+func (p *parser) variableDeclaration(pos Pos, context DeclarationContext, vars []Variable, comments Comments) VariableDeclaration {
+	vd := VariableDeclaration{Span: p.span(pos), Comments: comments, Context: context}
+	for _, v := range vars {
+		vd.Variables = append(vd.Variables, v)
+	}
+	return vd
+}
+
+// Parses the rest of variable declarators after the name of the first one.
+// All variables start at pos, the start of the declaration.
+func (p *parser) parseVariableDeclaratorsRest(pos Pos, mods Modifiers, typ ExpressionNode, name string) []Variable {
+	var vars []Variable
+	for {
+		v := Variable{Modifiers: mods, Name: name, Type: p.parseDimsOpt(typ)}
+		if p.accept("=") {
+			v.Initializer = p.parseVariableInitializer()
+		}
+		v.Span = p.span(pos)
+		vars = append(vars, v)
+		if !p.accept(",") {
+			return vars
+		}
+		name = p.ident()
+	}
+}
+
+func (p *parser) parseVariableInitializer() ExpressionNode {
+	if p.at("{") {
+		return p.parseArrayInitializer(p.parseVariableInitializer)
+	}
+	return p.parseExpression()
+}
+
+func (p *parser) parseType() ExpressionNode {
+	return p.parseAnnotatedType(p.parseTypeAnnotations())
+}
+
+func (p *parser) parseAnnotatedType(annotations []AnnotationNode) ExpressionNode {
+	var typ ExpressionNode
+	if pos := p.start(); p.tok().isPrimitiveType() {
+		kind := primitiveTypes[p.next().Text]
+		typ = PrimitiveType{Span: p.span(pos), PrimitiveTypeKind: kind}
+	} else {
+		typ = p.parseClassType()
+	}
+	if len(annotations) > 0 {
+		typ = AnnotatedType{Span: p.span(annotations[0].GetPos()), Annotations: annotations, UnderlyingType: typ}
+	}
+	return p.parseDimsOpt(typ)
+}
+
+func (p *parser) parseClassType() ExpressionNode {
+	var typ ExpressionNode = p.parseIdentifier()
+	pos := typ.GetPos()
+	for {
+		if p.at("<") {
+			args := p.parseTypeArguments(true)
+			typ = ParameterizedType{Span: p.span(pos), Type: typ, TypeArguments: args}
+		}
+		if !p.at(".") || p.peek(1).Kind != lexer.IDENTIFIER && !p.peek(1).is("@") {
+			return typ
+		}
+		p.next()
+		annotations := p.parseTypeAnnotations()
+		ident := p.ident()
+		typ = MemberSelect{Span: p.span(pos), Expression: typ, Identifier: ident}
+		if len(annotations) > 0 {
+			typ = AnnotatedType{Span: typ.(MemberSelect).Span, Annotations: annotations, UnderlyingType: typ}
+		}
+	}
+}
+
+func (p *parser) parseDimsOpt(typ ExpressionNode) ExpressionNode {
+	for p.at("[") && p.peek(1).is("]") {
+		p.pos += 2
+		typ = ArrayType{Span: p.span(typ.GetPos()), Type: typ}
+	}
+	return typ
+}
+
+func (p *parser) parseTypeList() []Node {
+	types := []Node{p.parseType()}
+	for p.accept(",") {
+		types = append(types, p.parseType())
+	}
+	return types
+}
+
+// Parses type arguments.
+// If diamond is set, the empty type arguments "<>" are accepted and result in an empty non-nil slice.
+func (p *parser) parseTypeArguments(diamond bool) []Node {
+	p.expect("<")
+	args := []Node{}
+	if diamond && p.accept(">") {
+		return args
+	}
+	for {
+		annotations := p.parseTypeAnnotations()
+		if pos := p.start(); p.accept("?") {
+			var wildcard ExpressionNode
+			switch {
+			case p.accept("extends"):
+				bound := p.parseType()
+				wildcard = ExtendsWildcard{Span: p.span(pos), Bound: bound}
+			case p.accept("super"):
+				bound := p.parseType()
+				wildcard = SuperWildcard{Span: p.span(pos), Bound: bound}
+			default:
+				wildcard = UnboundedWildcard{Span: p.span(pos)}
+			}
+			if len(annotations) > 0 {
+				wildcard = AnnotatedType{Span: p.span(annotations[0].GetPos()), Annotations: annotations, UnderlyingType: wildcard}
+			}
+			args = append(args, wildcard)
+		} else {
+			args = append(args, p.parseAnnotatedType(annotations))
+		}
+		if !p.accept(",") {
+			break
+		}
+	}
+	p.expect(">")
+	return args
+}
+
+func (p *parser) parseTypeParametersOpt() []TypeParameterNode {
+	if !p.accept("<") {
+		return nil
+	}
+	var tps []TypeParameterNode
+	for {
+		pos := p.start()
+		tp := TypeParameter{Annotations: p.parseTypeAnnotations(), Name: p.ident()}
+		if p.accept("extends") {
+			tp.Bounds = append(tp.Bounds, p.parseType())
+			for p.accept("&") {
+				tp.Bounds = append(tp.Bounds, p.parseType())
+			}
+		}
+		tp.Span = p.span(pos)
+		tps = append(tps, tp)
+		if !p.accept(",") {
+			break
+		}
+	}
+	p.expect(">")
+	return tps
+}
+
+func (p *parser) parseBlock() Block {
+	pos := p.start()
+	p.expect("{")
+	b := Block{Statements: []StatementNode{}}
+	for !p.at("}") {
+		if p.tok().Kind == lexer.EOF {
+			p.errorf(p.tok(), "expected \"}\", found %s", p.tok())
+		}
+		b.Statements = append(b.Statements, p.parseBlockStatement()...)
+	}
+	b.Dangling = p.dangling()
+	p.next()
+	b.Span = p.span(pos)
+	return b
+}
+
+// Parses a statement of a block.
+// A local variable declaration with several declarators results in several statements.
+func (p *parser) parseBlockStatement() []StatementNode {
+	if p.tok().Kind == lexer.IDENTIFIER && p.peek(1).is(":") || p.isYieldStatement() {
+		return []StatementNode{p.parseStatement()}
+	}
+	var mods Modifiers
+	if p.speculate(func() {
+		mods = p.parseModifiers()
+		if !p.isTypeDeclarationStart() {
+			p.errorf(p.tok(), "expected type declaration")
+		}
+	}) {
+		return []StatementNode{p.parseTypeDeclaration(mods)}
+	}
+	if p.isLocalVariableDeclaration() {
+		pos, mods := p.start(), p.parseModifiers()
+		typ := p.parseType()
+		vars := p.parseVariableDeclaratorsRest(pos, mods, typ, p.ident())
+		p.expect(";")
+		if len(vars) > 1 {
+			return []StatementNode{p.variableDeclaration(pos, LOCAL_VARIABLE_CONTEXT, vars, p.attach(pos))}
+		}
+		vars[0].Span, vars[0].Comments = p.span(pos), p.attach(pos)
+		return []StatementNode{vars[0]}
+	}
+	return []StatementNode{p.parseStatement()}
+}
+
+// Reports whether a local variable declaration starts at the current position.
+func (p *parser) isLocalVariableDeclaration() bool {
+	pos := p.pos
+	defer func() { p.pos = pos }()
+	return p.speculate(func() {
+		mods := p.parseModifiers()
+		if len(mods.Flags) > 0 || len(mods.Annotations) > 0 {
+			return
+		}
+		p.parseType()
+		p.ident()
+		if !p.at("=") && !p.at(";") && !p.at(",") && !p.at("[") && !p.at(":") {
+			p.errorf(p.tok(), "expected variable declarator")
+		}
+	})
+}
+
+func (p *parser) isYieldStatement() bool {
+	if !p.tok().isIdent("yield") {
+		return false
+	}
+	next := p.peek(1)
+	if _, ok := assignmentOperators[next.Text]; ok && next.Kind == lexer.OPERATOR {
+		return false
+	}
+	for _, text := range []string{".", "[", "::", ";", ":", ",", ")", "->", "++", "--"} {
+		if next.is(text) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *parser) parseStatement() StatementNode {
+	pos, tok := p.start(), p.tok()
+	switch {
+	case p.at("{"):
+		b := p.parseBlock()
+		b.Comments = p.attach(pos)
+		return b
+	case p.accept(";"):
+		return EmptyStatement{Span: p.span(pos), Comments: p.attach(pos)}
+	case tok.Kind == lexer.IDENTIFIER && p.peek(1).is(":"):
+		p.pos += 2
+		stmt := p.parseStatement()
+		return LabeledStatement{Span: p.span(pos), Comments: p.attach(pos), Label: tok.Text, Statement: stmt}
+	case p.isYieldStatement():
+		p.next()
+		y := Yield{Value: p.parseExpression()}
+		p.expect(";")
+		y.Span, y.Comments = p.span(pos), p.attach(pos)
+		return y
+	case p.accept("if"):
+		i := If{Condition: p.parseParenExpression(), ThenStatement: p.parseStatement()}
+		if p.accept("else") {
+			i.ElseStatement = p.parseStatement()
+		}
+		i.Span, i.Comments = p.span(pos), p.attach(pos)
+		return i
+	case p.accept("while"):
+		wl := WhileLoop{Condition: p.parseParenExpression(), Statement: p.parseStatement()}
+		wl.Span, wl.Comments = p.span(pos), p.attach(pos)
+		return wl
+	case p.accept("do"):
+		dwl := DoWhileLoop{Statement: p.parseStatement()}
+		p.expect("while")
+		dwl.Condition = p.parseParenExpression()
+		p.expect(";")
+		dwl.Span, dwl.Comments = p.span(pos), p.attach(pos)
+		return dwl
+	case p.at("for"):
+		return p.parseFor()
+	case p.at("try"):
+		return p.parseTry()
+	case p.accept("switch"):
+		s := Switch{Expression: p.parseParenExpression()}
+		s.Cases, s.Dangling = p.parseSwitchBody()
+		s.Span, s.Comments = p.span(pos), p.attach(pos)
+		return s
+	case p.accept("synchronized"):
+		s := Synchronized{Expression: p.parseParenExpression(), Block: p.parseBlock()}
+		s.Span, s.Comments = p.span(pos), p.attach(pos)
+		return s
+	case p.accept("return"):
+		r := Return{}
+		if !p.at(";") {
+			r.Expression = p.parseExpression()
+		}
+		p.expect(";")
+		r.Span, r.Comments = p.span(pos), p.attach(pos)
+		return r
+	case p.accept("throw"):
+		t := Throw{Expression: p.parseExpression()}
+		p.expect(";")
+		t.Span, t.Comments = p.span(pos), p.attach(pos)
+		return t
+	case p.accept("break"):
+		b := Break{}
+		if p.tok().Kind == lexer.IDENTIFIER {
+			label := p.ident()
+			b.Label = &label
+		}
+		p.expect(";")
+		b.Span, b.Comments = p.span(pos), p.attach(pos)
+		return b
+	case p.accept("continue"):
+		c := Continue{}
+		if p.tok().Kind == lexer.IDENTIFIER {
+			label := p.ident()
+			c.Label = &label
+		}
+		p.expect(";")
+		c.Span, c.Comments = p.span(pos), p.attach(pos)
+		return c
+	case p.accept("assert"):
+		a := Assert{Condition: p.parseExpression()}
+		if p.accept(":") {
+			a.Detail = p.parseExpression()
+		}
+		p.expect(";")
+		a.Span, a.Comments = p.span(pos), p.attach(pos)
+		return a
+	}
+	xs := ExpressionStatement{Expression: p.parseExpression()}
+	p.expect(";")
+	xs.Span, xs.Comments = p.span(pos), p.attach(pos)
+	return xs
+}
+
+func (p *parser) parseFor() StatementNode {
+	pos := p.start()
+	p.expect("for")
+	p.expect("(")
+	fl := ForLoop{}
+	if p.isLocalVariableDeclaration() {
+		varPos, mods := p.start(), p.parseModifiers()
+		typ := p.parseType()
+		name := p.ident()
+		if p.accept(":") {
+			v := Variable{Modifiers: mods, Name: name, Type: p.parseDimsOpt(typ)}
+			v.Span = p.span(varPos)
+			efl := EnhancedForLoop{Variable: v}
+			efl.Expression = p.parseExpression()
+			p.expect(")")
+			efl.Statement = p.parseStatement()
+			efl.Span, efl.Comments = p.span(pos), p.attach(pos)
+			return efl
+		}
+		for _, v := range p.parseVariableDeclaratorsRest(varPos, mods, typ, name) {
+			fl.Initializer = append(fl.Initializer, v)
+		}
+	} else {
+		for !p.at(";") {
+			fl.Expressions = append(fl.Expressions, p.parseExpression())
+			if !p.accept(",") {
+				break
+			}
+		}
+	}
+	p.expect(";")
+	if !p.at(";") {
+		fl.Condition = p.parseExpression()
+	}
+	p.expect(";")
+	for !p.at(")") {
+		fl.Update = append(fl.Update, p.parseExpression())
+		if !p.accept(",") {
+			break
+		}
+	}
+	p.expect(")")
+	fl.Statement = p.parseStatement()
+	fl.Span, fl.Comments = p.span(pos), p.attach(pos)
+	return fl
+}
+
+func (p *parser) parseTry() StatementNode {
+	pos, tok := p.start(), p.expect("try")
+	t := Try{}
+	if p.accept("(") {
+		for !p.at(")") {
+			if p.isLocalVariableDeclaration() {
+				varPos := p.start()
+				v := Variable{Modifiers: p.parseModifiers(), Type: p.parseType(), Name: p.ident()}
+				p.expect("=")
+				v.Initializer = p.parseExpression()
+				v.Span = p.span(varPos)
+				t.Resources = append(t.Resources, v)
+			} else {
+				t.Resources = append(t.Resources, p.parseExpression())
+			}
+			if !p.accept(";") {
+				break
+			}
+		}
+		p.expect(")")
+	}
+	t.Block = p.parseBlock()
+	for catchPos := p.start(); p.accept("catch"); catchPos = p.start() {
+		p.expect("(")
+		varPos := p.start()
+		v := Variable{Modifiers: p.parseModifiers()}
+		typ := p.parseType()
+		if p.at("|") {
+			alternatives := []Node{typ}
+			for p.accept("|") {
+				alternatives = append(alternatives, p.parseType())
+			}
+			v.Type = UnionType{Span: p.span(typ.GetPos()), TypeAlternatives: alternatives}
+		} else {
+			v.Type = typ
+		}
+		v.Name = p.ident()
+		v.Span = p.span(varPos)
+		p.expect(")")
+		c := Catch{Parameter: v, Block: p.parseBlock()}
+		c.Span = p.span(catchPos)
+		t.Catches = append(t.Catches, c)
+	}
+	if p.accept("finally") {
+		t.FinallyBlock = p.parseBlock()
+	}
+	if t.Resources == nil && t.Catches == nil && t.FinallyBlock == nil {
+		p.errorf(tok, "try without catch, finally or resource declarations")
+	}
+	t.Span, t.Comments = p.span(pos), p.attach(pos)
+	return t
+}
+
+// Parses the body of a switch statement or expression.
+// Returns the cases and the comments before the closing brace.
+func (p *parser) parseSwitchBody() ([]CaseNode, []Comment) {
+	p.expect("{")
+	var cases []CaseNode
+	for !p.at("}") {
+		pos := p.start()
+		var labels []CaseLabelNode
+		if p.accept("default") {
+			labels = append(labels, DefaultCaseLabel{Span: p.span(pos)})
+		} else {
+			p.expect("case")
+			for {
+				labels = append(labels, p.parseCaseLabel())
+				if !p.accept(",") {
+					break
+				}
+			}
+		}
+		var guard ExpressionNode
+		if p.tok().isIdent("when") {
+			p.next()
+			guard = p.parseGuard()
+		}
+		if p.accept("->") {
+			rc := RuleCase{Labels: labels, Guard: guard}
+			switch bodyPos := p.start(); {
+			case p.at("{"):
+				rc.Body = p.parseBlock()
+			case p.at("throw"):
+				rc.Body = p.parseStatement()
+			default:
+				xs := ExpressionStatement{Expression: p.parseExpression()}
+				p.expect(";")
+				xs.Span, xs.Comments = p.span(bodyPos), p.attach(bodyPos)
+				rc.Body = xs
+			}
+			rc.Span = p.span(pos)
+			cases = append(cases, rc)
+			continue
+		}
+		p.expect(":")
+		var statements []StatementNode
+		for !p.at("case") && !p.at("default") && !p.at("}") {
+			if p.tok().Kind == lexer.EOF {
+				p.errorf(p.tok(), "expected \"}\", found %s", p.tok())
+			}
+			statements = append(statements, p.parseBlockStatement()...)
+		}
+		cases = append(cases, StatementCase{Span: p.span(pos), Labels: labels, Guard: guard, Statements: statements})
+	}
+	dangling := p.dangling()
+	p.next()
+	return cases, dangling
+}
+
+func (p *parser) parseCaseLabel() CaseLabelNode {
+	pos := p.start()
+	if p.accept("default") {
+		return DefaultCaseLabel{Span: p.span(pos)}
+	}
+	noLambda := p.noLambda
+	p.noLambda = true
+	defer func() { p.noLambda = noLambda }()
+	var pattern PatternNode
+	if p.speculate(func() { pattern = p.parsePattern() }) {
+		return PatternCaseLabel{Span: p.span(pos), Pattern: pattern}
+	}
+	return ConstantCaseLabel{Span: p.span(pos), ConstantExpression: p.parseTernary()}
+}
+
+// Parses the guard of a case following "when".
+func (p *parser) parseGuard() ExpressionNode {
+	noLambda := p.noLambda
+	p.noLambda = true
+	defer func() { p.noLambda = noLambda }()
+	return p.parseExpression()
+}
+
+func (p *parser) parsePattern() PatternNode {
+	pos, mods := p.start(), p.parseModifiers()
+	return p.parsePatternRest(pos, mods, p.parseType())
+}
+
+// Parses the rest of a binding or record pattern after its modifiers and type.
+func (p *parser) parsePatternRest(pos Pos, mods Modifiers, typ ExpressionNode) PatternNode {
+	if tok := p.tok(); p.accept("(") {
+		if len(mods.Flags) > 0 || len(mods.Annotations) > 0 {
+			p.errorf(tok, "unexpected modifiers on record pattern")
+		}
+		rp := RecordPattern{Deconstructor: typ, NestedPatterns: []PatternNode{}}
+		for !p.at(")") {
+			rp.NestedPatterns = append(rp.NestedPatterns, p.parsePattern())
+			if !p.accept(",") {
+				break
+			}
+		}
+		p.expect(")")
+		rp.Span = p.span(pos)
+		return rp
+	}
+	v := Variable{Modifiers: mods, Name: p.ident(), Type: typ}
+	v.Span = p.span(pos)
+	return BindingPattern{Span: v.Span, Variable: v}
+}
+
+func (p *parser) parseParenExpression() ExpressionNode {
+	p.expect("(")
+	x := p.parseExpression()
+	p.expect(")")
+	return x
+}
+
+func (p *parser) parseArguments() []ExpressionNode {
+	p.expect("(")
+	var args []ExpressionNode
+	for !p.at(")") {
+		args = append(args, p.parseExpression())
+		if !p.accept(",") {
+			break
+		}
+	}
+	p.expect(")")
+	return args
+}
+
+func (p *parser) parseExpression() ExpressionNode {
+	if p.isLambdaStart() {
+		return p.parseLambda()
+	}
+	x := p.parseTernary()
+	if op, n := p.operator(); n == 1 {
+		if assignment, ok := assignmentOperators[op]; ok {
+			p.next()
+			value := p.parseExpression()
+			return assignment(p.span(x.GetPos()), x, value)
+		}
+	}
+	return x
+}
+
+func (p *parser) parseTernary() ExpressionNode {
+	cond := p.parseBinary(1)
+	if !p.accept("?") {
+		return cond
+	}
+	cx := ConditionalExpression{Condition: cond, TrueExpression: p.parseExpression()}
+	p.expect(":")
+	if p.isLambdaStart() {
+		cx.FalseExpression = p.parseLambda()
+	} else {
+		cx.FalseExpression = p.parseTernary()
+	}
+	cx.Span = p.span(cond.GetPos())
+	return cx
+}
+
+// Parses binary expressions with operators of at least the precedence.
+func (p *parser) parseBinary(precedence int) ExpressionNode {
+	x := p.parseUnary()
+	for {
+		op, n := p.operator()
+		if p.at("instanceof") {
+			op = "instanceof"
+		}
+		prec, ok := binaryPrecedence[op]
+		if !ok || prec < precedence {
+			return x
+		}
+		if op == "instanceof" {
+			x = p.parseInstanceOfRest(x)
+			continue
+		}
+		p.pos += n
+		right := p.parseBinary(prec + 1)
+		x = binaryOperators[op](p.span(x.GetPos()), x, right)
+	}
+}
+
+func (p *parser) parseInstanceOfRest(x ExpressionNode) ExpressionNode {
+	p.expect("instanceof")
+	pos, mods := p.start(), p.parseModifiers()
+	typ := p.parseType()
+	io := InstanceOf{Expression: x, Type: typ}
+	if p.at("(") || p.tok().Kind == lexer.IDENTIFIER || p.at("_") {
+		io.Pattern = p.parsePatternRest(pos, mods, typ)
+	}
+	io.Span = p.span(x.GetPos())
+	return io
+}
+
+func (p *parser) parseUnary() ExpressionNode {
+	pos := p.start()
+	if unary, ok := unaryOperators[p.tok().Text]; ok && p.tok().Kind == lexer.OPERATOR {
+		p.next()
+		x := p.parseUnary()
+		return unary(p.span(pos), x)
+	}
+	if p.at("(") {
+		if tc, ok := p.parseCastOpt(); ok {
+			return tc
+		}
+	}
+	x := p.parsePrimary()
+	for {
+		switch op, _ := p.operator(); op {
+		case "++":
+			p.next()
+			x = PostfixIncrement{Span: p.span(pos), Expression: x}
+		case "--":
+			p.next()
+			x = PostfixDecrement{Span: p.span(pos), Expression: x}
+		default:
+			return x
+		}
+	}
+}
+
+// Parses a cast expression if one starts at the current position.
+func (p *parser) parseCastOpt() (ExpressionNode, bool) {
+	pos, start := p.pos, p.start()
+	var typ Node
+	if !p.speculate(func() {
+		p.expect("(")
+		t := p.parseType()
+		if p.at("&") {
+			bounds := []Node{t}
+			for p.accept("&") {
+				bounds = append(bounds, p.parseType())
+			}
+			typ = IntersectionType{Span: p.span(t.GetPos()), Bounds: bounds}
+		} else {
+			typ = t
+		}
+		p.expect(")")
+	}) {
+		return nil, false
+	}
+	// A parenthesized primitive type always starts a cast, while a parenthesized reference type
+	// only does if it is followed by an operand that cannot continue a parenthesized expression.
+	var x ExpressionNode
+	switch {
+	case typ.GetKind() == PRIMITIVE_TYPE:
+		x = p.parseUnary()
+	case p.isLambdaStart():
+		x = p.parseLambda()
+	case p.isOperandStart():
+		x = p.parseUnary()
+	default:
+		p.pos = pos
+		return nil, false
+	}
+	return TypeCast{Span: p.span(start), Type: typ, Expression: x}, true
+}
+
+// Reports whether the current token starts an operand other than a unary plus or minus expression.
+func (p *parser) isOperandStart() bool {
+	tok := p.tok()
+	switch tok.Kind {
+	case lexer.IDENTIFIER, lexer.INT_LITERAL, lexer.LONG_LITERAL, lexer.FLOAT_LITERAL, lexer.DOUBLE_LITERAL,
+		lexer.BOOLEAN_LITERAL, lexer.CHAR_LITERAL, lexer.STRING_LITERAL, lexer.TEXT_BLOCK, lexer.NULL_LITERAL:
+		return true
+	case lexer.KEYWORD:
+		return tok.isPrimitiveType() || tok.Text == "this" || tok.Text == "super" || tok.Text == "new" || tok.Text == "switch" || tok.Text == "void"
+	}
+	return tok.is("(") || tok.is("!") || tok.is("~")
+}
+
+// Reports whether a lambda expression starts at the current position.
+func (p *parser) isLambdaStart() bool {
+	if p.noLambda {
+		return false
+	}
+	tok := p.tok()
+	if (tok.Kind == lexer.IDENTIFIER || tok.is("_")) && p.peek(1).is("->") {
+		return true
+	}
+	if !tok.is("(") {
+		return false
+	}
+	depth := 0
+	for i := p.pos; i < len(p.tokens); i++ {
+		switch t := p.tokens[i]; {
+		case t.is("("):
+			depth++
+		case t.is(")"):
+			if depth--; depth == 0 {
+				return i+1 < len(p.tokens) && p.tokens[i+1].is("->")
+			}
+		}
+	}
+	return false
+}
+
+func (p *parser) parseLambda() ExpressionNode {
+	pos := p.start()
+	var params []VariableNode
+	if p.accept("(") {
+		// Implicitly typed parameters are bare identifiers.
+		implicit := (p.tok().Kind == lexer.IDENTIFIER || p.at("_")) && (p.peek(1).is(",") || p.peek(1).is(")"))
+		for !p.at(")") {
+			if implicit {
+				params = append(params, p.parseImplicitParameter())
+			} else {
+				params = append(params, p.parseFormalParameter())
+			}
+			if !p.accept(",") {
+				break
+			}
+		}
+		p.expect(")")
+	} else {
+		params = append(params, p.parseImplicitParameter())
+	}
+	p.expect("->")
+	if p.at("{") {
+		block := p.parseBlock()
+		return StatementLambdaExpression{Span: p.span(pos), Parameters: params, Block: block}
+	}
+	x := p.parseExpression()
+	return ExpressionLambdaExpression{Span: p.span(pos), Parameters: params, Expression: x}
+}
+
+// Parses an implicitly typed lambda parameter.
+func (p *parser) parseImplicitParameter() Variable {
+	name := p.parseIdentifier()
+	return Variable{Span: name.Span, Modifiers: Modifiers{}, Name: name.Name}
+}
+
+func (p *parser) parsePrimary() ExpressionNode {
+	pos, tok := p.start(), p.tok()
+	// A literal is a single token.
+	span := Span{Pos: pos, End: p.file.Pos(tok.End)}
+	var x ExpressionNode
+	switch tok.Kind {
+	case lexer.INT_LITERAL:
+		x = IntLiteral{Span: span, Value: tok.Text}
+	case lexer.LONG_LITERAL:
+		x = LongLiteral{Span: span, Value: tok.Text}
+	case lexer.FLOAT_LITERAL:
+		x = FloatLiteral{Span: span, Value: tok.Text}
+	case lexer.DOUBLE_LITERAL:
+		x = DoubleLiteral{Span: span, Value: tok.Text}
+	case lexer.BOOLEAN_LITERAL:
+		x = BooleanLiteral{Span: span, Value: tok.Text == "true"}
+	case lexer.NULL_LITERAL:
+		x = NullLiteral{Span: span}
+	case lexer.CHAR_LITERAL:
+		value, err := lexer.UnquoteChar(tok.Text)
+		if err != nil {
+			p.errorf(tok, "%v", err)
+		}
+		x = CharLiteral{Span: span, Value: lexer.EncodeRune(value)}
+	case lexer.STRING_LITERAL:
+		value, err := lexer.UnquoteString(tok.Text)
+		if err != nil {
+			p.errorf(tok, "%v", err)
+		}
+		x = StringLiteral{Span: span, Value: value}
+	case lexer.TEXT_BLOCK:
+		value, err := lexer.UnquoteTextBlock(tok.Text)
+		if err != nil {
+			p.errorf(tok, "%v", err)
+		}
+		x = TextBlockLiteral{Span: span, Value: value}
+	case lexer.IDENTIFIER:
+		return p.parseSelectors(p.parseName())
+	}
+	if x != nil {
+		p.next()
+		return p.parseSelectors(x)
+	}
+	switch {
+	case p.accept("("):
+		noLambda := p.noLambda
+		p.noLambda = false
+		px := Parenthesized{Expression: p.parseExpression()}
+		p.noLambda = noLambda
+		p.expect(")")
+		px.Span = p.span(pos)
+		x = px
+	case p.accept("this"):
+		x = This{Span: p.span(pos)}
+		if p.at("(") {
+			args := p.parseArguments()
+			x = MethodInvocation{Span: p.span(pos), MethodSelect: x, Arguments: args}
+		}
+	case p.accept("super"):
+		x = Super{Span: p.span(pos)}
+		if p.at("(") {
+			args := p.parseArguments()
+			x = MethodInvocation{Span: p.span(pos), MethodSelect: x, Arguments: args}
+		}
+	case p.at("new"):
+		x = p.parseCreator(nil)
+	case p.accept("switch"):
+		sx := SwitchExpression{Expression: p.parseParenExpression()}
+		sx.Cases, sx.Dangling = p.parseSwitchBody()
+		sx.Span = p.span(pos)
+		x = sx
+	case p.at("void"), tok.isPrimitiveType():
+		if p.accept("void") {
+			x = PrimitiveType{Span: p.span(pos), PrimitiveTypeKind: VOID_TYPE_KIND}
+		} else {
+			x = p.parseType()
+		}
+		if !p.at("::") {
+			p.expect(".")
+			p.expect("class")
+			x = ClassLiteral{Span: p.span(pos), Type: x}
+		}
+	default:
+		p.errorf(tok, "illegal start of expression %s", tok)
+	}
+	return p.parseSelectors(x)
+}
+
+// Parses a primary expression starting with an identifier.
+func (p *parser) parseName() ExpressionNode {
+	// A parameterized type in an expression can only be the qualifier of a method reference.
+	if p.peek(1).is("<") {
+		var typ ExpressionNode
+		if p.speculate(func() {
+			typ = p.parseType()
+			if !p.at("::") {
+				p.errorf(p.tok(), "expected \"::\"")
+			}
+		}) {
+			return typ
+		}
+	}
+	x := p.parseIdentifier()
+	if p.at("(") {
+		args := p.parseArguments()
+		return MethodInvocation{Span: p.span(x.Pos), MethodSelect: x, Arguments: args}
+	}
+	return x
+}
+
+func (p *parser) parseSelectors(x ExpressionNode) ExpressionNode {
+	pos := x.GetPos()
+	for {
+		switch {
+		case p.accept("."):
+			switch {
+			case p.at("new"):
+				x = p.parseCreator(x)
+			case p.at("<"):
+				typeArguments := p.parseTypeArguments(false)
+				var ms ExpressionNode
+				if p.accept("super") {
+					ms = Super{Span: p.span(pos), Qualifier: x}
+				} else {
+					ident := p.ident()
+					ms = MemberSelect{Span: p.span(pos), Expression: x, Identifier: ident}
+				}
+				args := p.parseArguments()
+				x = MethodInvocation{Span: p.span(pos), TypeArguments: typeArguments, MethodSelect: ms, Arguments: args}
+			case p.accept("class"):
+				x = ClassLiteral{Span: p.span(pos), Type: x}
+			case p.accept("this"):
+				x = This{Span: p.span(pos), Qualifier: x}
+				if p.at("(") {
+					args := p.parseArguments()
+					x = MethodInvocation{Span: p.span(pos), MethodSelect: x, Arguments: args}
+				}
+			case p.accept("super"):
+				x = Super{Span: p.span(pos), Qualifier: x}
+				if p.at("(") {
+					args := p.parseArguments()
+					x = MethodInvocation{Span: p.span(pos), MethodSelect: x, Arguments: args}
+				}
+			default:
+				ident := p.ident()
+				x = MemberSelect{Span: p.span(pos), Expression: x, Identifier: ident}
+				if p.at("(") {
+					args := p.parseArguments()
+					x = MethodInvocation{Span: p.span(pos), MethodSelect: x, Arguments: args}
+				}
+			}
+		case p.at("[") && p.peek(1).is("]"):
+			x = p.parseDimsOpt(x)
+			if !p.at("::") {
+				p.expect(".")
+				p.expect("class")
+				x = ClassLiteral{Span: p.span(pos), Type: x}
+			}
+		case p.accept("["):
+			index := p.parseExpression()
+			p.expect("]")
+			x = ArrayAccess{Span: p.span(pos), Expression: x, Index: index}
+		case p.accept("::"):
+			var typeArguments []ExpressionNode
+			if tok := p.tok(); p.at("<") {
+				for _, arg := range p.parseTypeArguments(false) {
+					x, ok := arg.(ExpressionNode)
+					if !ok {
+						p.errorf(tok, "unexpected wildcard")
+					}
+					typeArguments = append(typeArguments, x)
+				}
+			}
+			if p.accept("new") {
+				x = NewMemberReference{Span: p.span(pos), QualifierExpression: x, TypeArguments: typeArguments}
+			} else {
+				name := p.ident()
+				x = InvokeMemberReference{Span: p.span(pos), QualifierExpression: x, Name: name, TypeArguments: typeArguments}
+			}
+		default:
+			return x
+		}
+	}
+}
+
+// Parses a class instance or array creation expression.
+// The enclosing expression is set for qualified class instance creation.
+func (p *parser) parseCreator(enclosing ExpressionNode) ExpressionNode {
+	pos := p.start()
+	if enclosing != nil {
+		pos = enclosing.GetPos()
+	}
+	p.expect("new")
+	var typeArguments []Node
+	if p.at("<") {
+		typeArguments = p.parseTypeArguments(false)
+	}
+	annotations := p.parseTypeAnnotations()
+	tok := p.tok()
+	var typ ExpressionNode
+	if tok.isPrimitiveType() {
+		p.next()
+		typ = PrimitiveType{Span: p.span(p.file.Pos(tok.Offset)), PrimitiveTypeKind: primitiveTypes[tok.Text]}
+	} else {
+		typ = p.parseClassType()
+	}
+	if len(annotations) > 0 {
+		typ = AnnotatedType{Span: p.span(annotations[0].GetPos()), Annotations: annotations, UnderlyingType: typ}
+	}
+	if p.at("[") {
+		if enclosing != nil || typeArguments != nil {
+			p.errorf(p.tok(), "unexpected array creation")
+		}
+		na := NewArray{}
+		for p.at("[") && !p.peek(1).is("]") {
+			p.next()
+			na.Dimensions = append(na.Dimensions, p.parseExpression())
+			p.expect("]")
+		}
+		if na.Dimensions == nil {
+			p.expect("[")
+			p.expect("]")
+			na.Type = p.parseDimsOpt(typ)
+			na.Initializers = p.parseArrayInitializer(p.parseVariableInitializer).Initializers
+			na.Span = p.span(pos)
+			return na
+		}
+		na.Type = p.parseDimsOpt(typ)
+		na.Span = p.span(pos)
+		return na
+	}
+	if typ.GetKind() == PRIMITIVE_TYPE {
+		p.errorf(p.tok(), "expected \"[\", found %s", p.tok())
+	}
+	nc := NewClass{EnclosingExpression: enclosing, TypeArguments: typeArguments, Identifier: typ}
+	nc.Arguments = p.parseArguments()
+	if bodyPos := p.start(); p.at("{") {
+		body := Class{Modifiers: Modifiers{}}
+		body.Members, body.Dangling = p.parseClassBody("", CLASS)
+		body.Span = p.span(bodyPos)
+		nc.ClassBody = body
+	}
+	nc.Span = p.span(pos)
+	return nc
+}
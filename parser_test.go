@@ -0,0 +1,679 @@
+package javast_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kapavkin/javast"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	src := `package com.example;
+
+import java.util.*;
+import static java.lang.Math.max;
+
+@SuppressWarnings({"unchecked", "rawtypes"})
+public final class Cache<K extends Comparable<K> & java.io.Serializable, V> extends Base implements Store<K, V> {
+    private final Map<K, List<V>> entries = new HashMap<>();
+    static { init(); }
+
+    public Cache(int capacity) throws IllegalArgumentException {
+        super(capacity);
+    }
+
+    @Override
+    public <R> R get(K key, Function<? super V, ? extends R> fn) {
+        return fn.apply(entries.get(key).get(0));
+    }
+
+    abstract void evict();
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cu.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "package com . example ; " +
+		"import java . util . * ; import static java . lang . Math . max ; " +
+		"@ SuppressWarnings ( { \"unchecked\" , \"rawtypes\" } ) public final class Cache " +
+		"< K extends Comparable < K > & java . io . Serializable , V > extends Base implements Store < K , V > { " +
+		"private final Map < K , List < V > > entries = new HashMap < > ( ) ; " +
+		"static { init ( ) ; } " +
+		"public Cache ( int capacity ) throws IllegalArgumentException { super ( capacity ) ; } " +
+		"@ Override public < R > R get ( K key , Function < ? super V , ? extends R > fn ) " +
+		"{ return fn . apply ( entries . get ( key ) . get ( 0 ) ) ; } " +
+		"abstract void evict ( ) ; }"
+	if got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+}
+
+func TestParse_Statements(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	src := `class Statements {
+    void run(String... args) {
+        int i = 0, j;
+        for (int k = 0; k < 10; k++) { if (k % 2 == 0) continue; else break; }
+        for (String arg : args) System.out.println(arg);
+        outer: while (true) { break outer; }
+        do { i++; } while (i < 10);
+        try { run(); } catch (IOException | RuntimeException e) { throw e; } finally { close(); }
+        switch (i) { case 1: case 2: run(); break; default: stop(); }
+        int y = switch (i) { case 1, 2 -> 3; default -> { yield 4; } };
+        assert i > 0 : "positive";
+        synchronized (this) { notify(); }
+    }
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cu.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "class Statements { void run ( String ... args ) { " +
+		"int i = 0 , j ; " +
+		"for ( int k = 0 ; k < 10 ; k ++ ) { if ( k % 2 == 0 ) continue ; else break ; } " +
+		"for ( String arg : args ) System . out . println ( arg ) ; " +
+		"outer : while ( true ) { break outer ; } " +
+		"do { i ++ ; } while ( i < 10 ) ; " +
+		"try { run ( ) ; } catch ( IOException | RuntimeException e ) { throw e ; } finally { close ( ) ; } " +
+		"switch ( i ) { case 1 : case 2 : run ( ) ; break ; default : stop ( ) ; } " +
+		"int y = switch ( i ) { case 1 , 2 -> 3 ; default -> { yield 4 ; } } ; " +
+		"assert i > 0 : \"positive\" ; " +
+		"synchronized ( this ) { notify ( ) ; } } }"
+	if got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+}
+
+func TestParse_Expressions(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	src := `class Expressions {
+    Object[] values = {
+        a >> 2 >>> 1,
+        (int) 3.5 + (b),
+        (Object) "s",
+        x -> x + 1,
+        (x, y) -> { return x * y; },
+        int[]::new,
+        String[].class,
+        Collections.<String>emptyList(),
+        new Object() { int f; },
+        outer.new Inner(),
+        new int[3][],
+        new String[] {"a", "b"},
+        o instanceof String s && !s.isEmpty(),
+        c ? d : e,
+        0xFFL + 1_000L,
+        '\n',
+    };
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cu.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "class Expressions { Object [] values = { " +
+		"a >> 2 >>> 1 , " +
+		"( int ) 3.5 + ( b ) , " +
+		"( Object ) \"s\" , " +
+		"( x ) -> x + 1 , " +
+		"( x , y ) -> { return x * y ; } , " +
+		"int [] :: new , " +
+		"String [] . class , " +
+		"Collections . < String > emptyList ( ) , " +
+		"new Object ( ) { int f ; } , " +
+		"outer . new Inner ( ) , " +
+		"new int [ 3 ] [] , " +
+		"new String [] { \"a\" , \"b\" } , " +
+		"o instanceof String s && ! s . isEmpty ( ) , " +
+		"c ? d : e , " +
+		"0xFFL + 1_000L , " +
+		"'\\n' } ; }"
+	if got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+}
+
+func TestParse_Declarations(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	src := `class Declarations {
+    private int a = 1, b, c[] = {};
+    void run() {
+        String[] x, y[];
+        for (final int i = 0, j = 0; i < j; i++) {}
+        try (Reader r = open(); Writer w = create()) {}
+    }
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cu.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "class Declarations { private int a = 1 , b , c [] = { } ; " +
+		"void run ( ) { String [] x , y [] ; " +
+		"for ( final int i = 0 , j = 0 ; i < j ; i ++ ) { } " +
+		"try ( Reader r = open ( ) ; Writer w = create ( ) ) { } } }"
+	if got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+}
+
+func TestParse_Varargs(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	src := `record Names(String... names) {
+    static String join(String separator, Object... parts) { return null; }
+    Consumer<int[]> consumer = (int... values) -> {};
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cu.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "record Names ( String ... names ) { " +
+		"static String join ( String separator , Object ... parts ) { return null ; } " +
+		"Consumer < int [] > consumer = ( int ... values ) -> { } ; }"
+	if got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+}
+
+func TestParse_ThisAndSuper(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	src := `class Inner extends Base {
+    Inner(Outer Outer.this) { outer.super(void.class); }
+    Inner() { this(int[].class); }
+    Object get() { return Outer.this.get() + super.get() + Outer.super.get(); }
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cu.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "class Inner extends Base { " +
+		"Inner ( Outer Outer . this ) { outer . super ( void . class ) ; } " +
+		"Inner ( ) { this ( int [] . class ) ; } " +
+		"Object get ( ) { return Outer . this . get ( ) + super . get ( ) + Outer . super . get ( ) ; } }"
+	if got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+	kinds := map[javast.Kind]int{}
+	javast.Walk(cu, func(node javast.Node) bool {
+		kinds[node.GetKind()]++
+		return true
+	})
+	if kinds[javast.CLASS_LITERAL] != 2 || kinds[javast.THIS] != 3 || kinds[javast.SUPER] != 3 {
+		t.Errorf("kinds = %v, want 2 class literals, 3 this and 3 super", kinds)
+	}
+}
+
+func TestParse_Module(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	src := `open module com.example.app {
+    requires transitive java.sql;
+    exports com.example.api to com.example.impl;
+    uses com.example.spi.Service;
+    provides com.example.spi.Service with com.example.impl.ServiceImpl;
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cu.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "open module com . example . app { " +
+		"requires transitive java . sql ; " +
+		"exports com . example . api to com . example . impl ; " +
+		"uses com . example . spi . Service ; " +
+		"provides com . example . spi . Service with com . example . impl . ServiceImpl ; }"
+	if got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+}
+
+func TestParse_Error(t *testing.T) {
+	t.Parallel()
+	src := `class Broken {
+    void run() {
+        int i = ;
+    }
+}
+`
+	_, err := javast.Parse(strings.NewReader(src))
+	if err == nil {
+		t.Fatal("Parse() error = nil, want error")
+	}
+	got := err.Error()
+	want := `3:17: illegal start of expression ";"`
+	if got != want {
+		t.Errorf("err.Error() = %s, want %s", got, want)
+	}
+}
+
+func TestParseFile_Positions(t *testing.T) {
+	t.Parallel()
+	fset := javast.NewFileSet()
+	src := `class Main {
+    int x;
+
+    void run() {
+        x = a + b;
+    }
+}
+`
+	cu, err := javast.ParseFile(fset, "Main.java", []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	javast.Walk(cu, func(node javast.Node) bool {
+		switch node.GetKind() {
+		case javast.METHOD, javast.ASSIGNMENT, javast.PLUS:
+			got = append(got, fset.Position(node.GetPos()).String()+"-"+fset.Position(node.GetEnd()).String())
+		}
+		return true
+	})
+	want := []string{
+		"Main.java:4:5-Main.java:6:6",
+		"Main.java:5:9-Main.java:5:18",
+		"Main.java:5:13-Main.java:5:18",
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("positions = %v, want %v", got, want)
+	}
+}
+
+func TestParseFile_Error(t *testing.T) {
+	t.Parallel()
+	_, err := javast.ParseFile(javast.NewFileSet(), "Broken.java", []byte("class Broken { int i = ; }"))
+	if err == nil {
+		t.Fatal("ParseFile() error = nil, want error")
+	}
+	got := err.Error()
+	want := `Broken.java:1:24: illegal start of expression ";"`
+	if got != want {
+		t.Errorf("err.Error() = %s, want %s", got, want)
+	}
+}
+
+func TestParse_Enum(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	src := `enum Color {
+    @Deprecated RED("r"), // Red.
+    GREEN() { int rgb() { return 0x00ff00; } },
+    BLUE;
+
+    int rgb() { return 0; }
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	constants := cu.GetTypeDecls()[0].(javast.ClassNode).GetEnumConstants()
+	if got, want := len(constants), 3; got != want {
+		t.Fatalf("len(constants) = %d, want %d", got, want)
+	}
+	if got := constants[2].GetArguments(); got != nil {
+		t.Errorf("constants[2].GetArguments() = %v, want nil", got)
+	}
+	if _, err := cu.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "enum Color { @ Deprecated RED ( \"r\" ) , // Red.\n GREEN ( ) { int rgb ( ) { return 0x00ff00 ; } } , BLUE ; " +
+		"int rgb ( ) { return 0 ; } }"
+	if got != want {
+		t.Errorf("sw.String() = %q, want %q", got, want)
+	}
+}
+
+func TestParse_Record(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	src := `record Range<T>(@NonNull T low, T high) implements Comparable<Range<T>> {
+    Range {
+        Objects.requireNonNull(high);
+    }
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := cu.GetTypeDecls()[0].(javast.ClassNode)
+	if got, want := len(r.GetRecordComponents()), 2; got != want {
+		t.Errorf("len(r.GetRecordComponents()) = %d, want %d", got, want)
+	}
+	if !r.GetMembers()[0].(javast.MethodNode).IsCompact() {
+		t.Error("IsCompact() = false, want true")
+	}
+	if _, err := cu.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "record Range < T > ( @ NonNull T low , T high ) implements Comparable < Range < T > > { " +
+		"Range { Objects . requireNonNull ( high ) ; } }"
+	if got != want {
+		t.Errorf("sw.String() = %q, want %q", got, want)
+	}
+}
+
+func TestParse_Permits(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	src := `public abstract sealed class Shape permits Circle, shapes.Square {}`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cu.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "public abstract sealed class Shape permits Circle , shapes . Square { }"
+	if got != want {
+		t.Errorf("sw.String() = %q, want %q", got, want)
+	}
+}
+
+func TestParse_Patterns(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	src := `class Shapes {
+    double area(Shape s) {
+        if (s instanceof Square(var side)) { return side * side; }
+        return switch (s) {
+            case Circle c when c.radius() > 0 -> Math.PI * c.radius() * c.radius();
+            case Rectangle(Point(int x1, int y1), Point p) -> 0;
+            case null, default -> -1;
+        };
+    }
+    void print(Object o) {
+        switch (o) {
+            case Integer i when i > 0:
+                System.out.println(i);
+                break;
+            default:
+        }
+    }
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var guards int
+	javast.Walk(cu, func(node javast.Node) bool {
+		if c, ok := node.(javast.CaseNode); ok && c.GetGuard() != nil {
+			guards++
+		}
+		return true
+	})
+	if got, want := guards, 2; got != want {
+		t.Errorf("guards = %d, want %d", got, want)
+	}
+	if _, err := cu.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "class Shapes { double area ( Shape s ) { " +
+		"if ( s instanceof Square ( var side ) ) { return side * side ; } " +
+		"return switch ( s ) { " +
+		"case Circle c when c . radius ( ) > 0 -> Math . PI * c . radius ( ) * c . radius ( ) ; " +
+		"case Rectangle ( Point ( int x1 , int y1 ) , Point p ) -> 0 ; " +
+		"case null , default -> - 1 ; } ; } " +
+		"void print ( Object o ) { switch ( o ) { " +
+		"case Integer i when i > 0 : System . out . println ( i ) ; break ; default : } } }"
+	if got != want {
+		t.Errorf("sw.String() = %q, want %q", got, want)
+	}
+}
+
+func TestParse_Comments(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	src := `// License.
+package p;
+
+/** A counter. */
+class Counter {
+    private int count; // Never negative.
+
+    /**
+     * Adds to the count.
+     *
+     * @param n the amount
+     */
+    void add(int n) {
+        // Add.
+        count += n; /* Done. */
+    }
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	method := cu.GetTypeDecls()[0].(javast.ClassNode).GetMembers()[1].(javast.MethodNode)
+	doc := method.(javast.CommentedNode).GetComments().Doc
+	if doc == nil {
+		t.Fatal("doc = nil, want doc comment")
+	}
+	if got, want := doc.Tags[0], (javast.DocTag{Name: "param", Argument: "n", Text: "the amount"}); got != want {
+		t.Errorf("doc.Tags[0] = %v, want %v", got, want)
+	}
+	if _, err := cu.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "// License.\n package p ; /** A counter. */ class Counter { private int count ; // Never negative.\n " +
+		"/**\n * Adds to the count.\n *\n * @param n the amount\n */ void add ( int n ) { " +
+		"// Add.\n count += n ; /* Done. */ } }"
+	if got != want {
+		t.Errorf("sw.String() = %q, want %q", got, want)
+	}
+}
+
+func TestParse_DanglingComments(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	src := `class Counter {
+    void add(int n) {
+        count += n;
+        // Done.
+    }
+
+    void reset() { /* Nothing. */ }
+
+    int sign(int n) {
+        switch (n) {
+        case 0:
+            return 0;
+            // Zero.
+        }
+        return 1;
+    }
+    // More members.
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	class := cu.GetTypeDecls()[0].(javast.Class)
+	if got, want := len(class.Dangling), 1; got != want {
+		t.Errorf("len(class.Dangling) = %d, want %d", got, want)
+	}
+	add := class.Members[0].(javast.Method)
+	if got, want := len(add.Body.(javast.Block).Statements[0].(javast.CommentedNode).GetComments().Trailing), 0; got != want {
+		t.Errorf("len(Trailing) = %d, want %d", got, want)
+	}
+	if _, err := cu.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "class Counter { void add ( int n ) { count += n ; // Done.\n } " +
+		"void reset ( ) { /* Nothing. */ } " +
+		"int sign ( int n ) { switch ( n ) { case 0 : return 0 ; // Zero.\n } return 1 ; } // More members.\n }"
+	if got != want {
+		t.Errorf("sw.String() = %q, want %q", got, want)
+	}
+}
+
+func TestParse_StatementCaseLabels(t *testing.T) {
+	t.Parallel()
+	src := `class Days {
+    int count(Day day, String s) {
+        switch (day) {
+        case SATURDAY, SUNDAY:
+            return 0;
+        default:
+            return 1;
+        }
+        switch (s) {
+        case "a", "b":
+        case null, default:
+            return 2;
+        }
+    }
+}`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sw := SpaceWriter{}
+	if _, err := cu.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "class Days { int count ( Day day , String s ) { " +
+		"switch ( day ) { case SATURDAY , SUNDAY : return 0 ; default : return 1 ; } " +
+		"switch ( s ) { case \"a\" , \"b\" : case null , default : return 2 ; } } }"
+	if got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+	cu, err = javast.Parse(strings.NewReader(got))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sw = SpaceWriter{}
+	if _, err := cu.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	if got := sw.String(); got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+}
+
+func TestStatementCase_GetExpressions(t *testing.T) {
+	t.Parallel()
+	src := `class Days {
+    void count(Object o) {
+        switch (o) {
+        case 1, 2:
+            break;
+        case String s when s.isEmpty():
+            break;
+        default:
+            break;
+        }
+    }
+}`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	method := cu.GetTypeDecls()[0].(javast.ClassNode).GetMembers()[0].(javast.MethodNode)
+	cases := method.GetBody().GetStatements()[0].(javast.SwitchNode).GetCases()
+	for i, want := range []string{"1 2", "s . isEmpty ( )", ""} {
+		sw := SpaceWriter{}
+		for _, x := range cases[i].GetExpressions() {
+			if _, err := x.WriteTo(&sw); err != nil {
+				t.Error(err)
+			}
+		}
+		if got := sw.String(); got != want {
+			t.Errorf("sw.String() = %s, want %s", got, want)
+		}
+	}
+}
+
+func TestParse_Supertypes(t *testing.T) {
+	t.Parallel()
+	src := `interface Store<K> extends Map<K, String>, Closeable {}
+enum Level implements Supplier<String>, Comparable<Level> { LOW, HIGH }
+class Loops {
+    void f(List<@NonNull ? extends Number> xs, Map<@A ?, @B ? super T> m) {
+        for (i = 0, j = n; i < j; i++, j--) swap(i, j);
+        for (;;) break;
+    }
+}`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := cu.GetTypeDecls()[0].(javast.ClassNode)
+	if got, want := len(store.GetImplementsClause()), 2; got != want {
+		t.Errorf("len(GetImplementsClause()) = %d, want %d", got, want)
+	}
+	sw := SpaceWriter{}
+	if _, err := cu.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "interface Store < K > extends Map < K , String > , Closeable { } " +
+		"enum Level implements Supplier < String > , Comparable < Level > { LOW , HIGH } " +
+		"class Loops { void f ( List < @ NonNull ? extends Number > xs , Map < @ A ? , @ B ? super T > m ) { " +
+		"for ( i = 0 , j = n ; i < j ; i ++ , j -- ) swap ( i , j ) ; for ( ; ; ) break ; } }"
+	if got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+	cu, err = javast.Parse(strings.NewReader(got))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sw = SpaceWriter{}
+	if _, err := cu.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	if got := sw.String(); got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+}
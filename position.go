@@ -0,0 +1,173 @@
+package javast
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// A Pos is a compact encoding of a source position within a [FileSet].
+// It can be converted into a [Position] with [FileSet.Position].
+// The zero value [NoPos] is not associated with any file.
+type Pos int
+
+// The zero value of [Pos], used for nodes which do not originate from source.
+const NoPos Pos = 0
+
+// Reports whether the position is valid.
+func (p Pos) IsValid() bool { return p != NoPos }
+
+// A Position describes a location in a source file.
+type Position struct {
+	Filename string // The name of the file, if any.
+	Offset   int    // The 0-based byte offset in the file.
+	Line     int    // The 1-based line number.
+	Column   int    // The 1-based byte column number.
+}
+
+// Reports whether the position is valid.
+func (pos Position) IsValid() bool { return pos.Line > 0 }
+
+// Returns the position in one of the forms:
+//
+//	file:line:column
+//	line:column
+//	file
+//	-
+func (pos Position) String() string {
+	s := pos.Filename
+	if pos.IsValid() {
+		if s != "" {
+			s += ":"
+		}
+		s += fmt.Sprintf("%d:%d", pos.Line, pos.Column)
+	}
+	if s == "" {
+		s = "-"
+	}
+	return s
+}
+
+// A Span is the range of source positions covered by a node.
+// It is embedded in every node.
+type Span struct {
+	Pos Pos // The position of the first character of the node.
+	End Pos // The position immediately after the last character of the node.
+}
+
+// Returns the position of the first character of the node.
+func (s Span) GetPos() Pos { return s.Pos }
+
+// Returns the position immediately after the last character of the node.
+func (s Span) GetEnd() Pos { return s.End }
+
+// A File is a source file registered in a [FileSet].
+type File struct {
+	name  string
+	base  int
+	size  int
+	mutex sync.Mutex
+	lines []int // The offsets at which lines start.
+}
+
+// Returns the name of the file.
+func (f *File) Name() string { return f.name }
+
+// Returns the position of the first byte of the file.
+func (f *File) Base() int { return f.base }
+
+// Returns the size of the file in bytes.
+func (f *File) Size() int { return f.size }
+
+// Returns the position of the byte offset in the file.
+// The offset must be between 0 and the size of the file.
+func (f *File) Pos(offset int) Pos {
+	if offset < 0 || offset > f.size {
+		panic(fmt.Sprintf("invalid file offset %d (should be <= %d)", offset, f.size))
+	}
+	return Pos(f.base + offset)
+}
+
+// Returns the byte offset of the position in the file.
+func (f *File) Offset(p Pos) int {
+	if int(p) < f.base || int(p) > f.base+f.size {
+		panic(fmt.Sprintf("invalid Pos value %d (should be in [%d, %d])", p, f.base, f.base+f.size))
+	}
+	return int(p) - f.base
+}
+
+// Sets the line offsets from the content of the file.
+// Lines are terminated by "\n", "\r" or "\r\n" as defined by the JLS.
+func (f *File) SetLinesForContent(content []byte) {
+	lines := []int{0}
+	for i, b := range content {
+		switch {
+		case b == '\n':
+			lines = append(lines, i+1)
+		case b == '\r' && (i+1 >= len(content) || content[i+1] != '\n'):
+			lines = append(lines, i+1)
+		}
+	}
+	f.mutex.Lock()
+	f.lines = lines
+	f.mutex.Unlock()
+}
+
+// Returns the position of the [Pos] in the file.
+func (f *File) Position(p Pos) Position {
+	offset := f.Offset(p)
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	line := sort.Search(len(f.lines), func(i int) bool { return f.lines[i] > offset })
+	if line == 0 {
+		return Position{Filename: f.name, Offset: offset}
+	}
+	return Position{Filename: f.name, Offset: offset, Line: line, Column: offset - f.lines[line-1] + 1}
+}
+
+// A FileSet is a registry of source files which maps a [Pos] to its file, line and column.
+// Each file occupies its own range of positions, so that positions of different files never collide.
+type FileSet struct {
+	mutex sync.RWMutex
+	base  int
+	files []*File
+}
+
+// Returns an empty file set.
+func NewFileSet() *FileSet {
+	return &FileSet{base: 1}
+}
+
+// Registers a file with the name and size and returns it.
+// Positions of the file start at the base of the file set, which is then advanced past the file.
+func (s *FileSet) AddFile(filename string, size int) *File {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	f := &File{name: filename, base: s.base, size: size, lines: []int{0}}
+	s.base += size + 1
+	s.files = append(s.files, f)
+	return f
+}
+
+// Returns the file containing the position, or nil if there is none.
+func (s *FileSet) File(p Pos) *File {
+	if !p.IsValid() {
+		return nil
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	i := sort.Search(len(s.files), func(i int) bool { return s.files[i].base > int(p) }) - 1
+	if i >= 0 && int(p) <= s.files[i].base+s.files[i].size {
+		return s.files[i]
+	}
+	return nil
+}
+
+// Returns the position of the [Pos] in its file.
+// The zero [Position] is returned if the position does not belong to any file of the set.
+func (s *FileSet) Position(p Pos) Position {
+	if f := s.File(p); f != nil {
+		return f.Position(p)
+	}
+	return Position{}
+}
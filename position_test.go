@@ -0,0 +1,34 @@
+package javast_test
+
+import (
+	"testing"
+
+	"github.com/kapavkin/javast"
+)
+
+func TestFileSet_Position(t *testing.T) {
+	t.Parallel()
+	fset := javast.NewFileSet()
+	a := fset.AddFile("A.java", 10)
+	a.SetLinesForContent([]byte("ab\ncd\r\nef\r"))
+	b := fset.AddFile("B.java", 3)
+	b.SetLinesForContent([]byte("xyz"))
+	for _, tt := range []struct {
+		pos  javast.Pos
+		want string
+	}{
+		{a.Pos(0), "A.java:1:1"},
+		{a.Pos(4), "A.java:2:2"},
+		{a.Pos(7), "A.java:3:1"},
+		{a.Pos(10), "A.java:4:1"},
+		{b.Pos(2), "B.java:1:3"},
+		{javast.NoPos, "-"},
+	} {
+		if got := fset.Position(tt.pos).String(); got != tt.want {
+			t.Errorf("fset.Position(%d) = %s, want %s", tt.pos, got, tt.want)
+		}
+	}
+	if got := fset.File(b.Pos(0)); got != b {
+		t.Errorf("fset.File() = %v, want %v", got, b)
+	}
+}
@@ -0,0 +1,90 @@
+package javast
+
+import "io"
+
+// Precedence levels of Java expressions, from the lowest to the highest.
+const (
+	assignmentPrecedence = iota // Assignments and lambda expressions.
+	conditionalPrecedence
+	conditionalOrPrecedence
+	conditionalAndPrecedence
+	orPrecedence
+	xorPrecedence
+	andPrecedence
+	equalityPrecedence
+	relationalPrecedence // Relational operators and instanceof.
+	shiftPrecedence
+	additivePrecedence
+	multiplicativePrecedence
+	unaryPrecedence // Prefix operators, casts and switch expressions.
+	postfixPrecedence
+	primaryPrecedence
+)
+
+// Returns the precedence level of an expression.
+// Nodes which are not operator expressions, such as literals, names, invocations and parenthesized expressions,
+// have the primary precedence level.
+func precedence(node Node) int {
+	switch node.GetKind() {
+	case ASSIGNMENT, MULTIPLY_ASSIGNMENT, DIVIDE_ASSIGNMENT, REMAINDER_ASSIGNMENT, PLUS_ASSIGNMENT, MINUS_ASSIGNMENT,
+		LEFT_SHIFT_ASSIGNMENT, RIGHT_SHIFT_ASSIGNMENT, UNSIGNED_RIGHT_SHIFT_ASSIGNMENT, AND_ASSIGNMENT, XOR_ASSIGNMENT,
+		OR_ASSIGNMENT, LAMBDA_EXPRESSION:
+		return assignmentPrecedence
+	case CONDITIONAL_EXPRESSION:
+		return conditionalPrecedence
+	case CONDITIONAL_OR:
+		return conditionalOrPrecedence
+	case CONDITIONAL_AND:
+		return conditionalAndPrecedence
+	case OR:
+		return orPrecedence
+	case XOR:
+		return xorPrecedence
+	case AND:
+		return andPrecedence
+	case EQUAL_TO, NOT_EQUAL_TO:
+		return equalityPrecedence
+	case LESS_THAN, GREATER_THAN, LESS_THAN_EQUAL, GREATER_THAN_EQUAL, INSTANCE_OF:
+		return relationalPrecedence
+	case LEFT_SHIFT, RIGHT_SHIFT, UNSIGNED_RIGHT_SHIFT:
+		return shiftPrecedence
+	case PLUS, MINUS:
+		return additivePrecedence
+	case MULTIPLY, DIVIDE, REMAINDER:
+		return multiplicativePrecedence
+	case PREFIX_INCREMENT, PREFIX_DECREMENT, UNARY_PLUS, UNARY_MINUS, BITWISE_COMPLEMENT, LOGICAL_COMPLEMENT,
+		TYPE_CAST, SWITCH_EXPRESSION:
+		return unaryPrecedence
+	case POSTFIX_INCREMENT, POSTFIX_DECREMENT:
+		return postfixPrecedence
+	}
+	return primaryPrecedence
+}
+
+// Writes the operand of an expression, enclosed in parentheses if its precedence level is lower than min.
+func writeOperand(w io.Writer, operand Node, min int) (n int64, err error) {
+	parenthesized := precedence(operand) < min
+	if parenthesized {
+		if on, oerr := w.Write([]byte(`(`)); oerr != nil {
+			err = oerr
+			return
+		} else {
+			n += int64(on)
+		}
+	}
+	if xn, xerr := writeNode(w, operand); xerr != nil {
+		err = xerr
+		return
+	} else {
+		n += xn
+	}
+	if parenthesized {
+		if cn, cerr := w.Write([]byte(`)`)); cerr != nil {
+			err = cerr
+			return
+		} else {
+			n += int64(cn)
+		}
+	}
+	return
+}
@@ -0,0 +1,53 @@
+package javast_test
+
+import (
+	"testing"
+
+	"github.com/kapavkin/javast"
+)
+
+func TestPrecedence_WriteTo(t *testing.T) {
+	t.Parallel()
+	a, b, c := javast.Identifier{Name: "a"}, javast.Identifier{Name: "b"}, javast.Identifier{Name: "c"}
+	tests := []struct {
+		node javast.Node
+		want string
+	}{
+		{javast.Multiply{LeftOperand: javast.Plus{LeftOperand: a, RightOperand: b}, RightOperand: c}, "( a + b ) * c"},
+		{javast.Plus{LeftOperand: a, RightOperand: javast.Multiply{LeftOperand: b, RightOperand: c}}, "a + b * c"},
+		{javast.Minus{LeftOperand: javast.Minus{LeftOperand: a, RightOperand: b}, RightOperand: c}, "a - b - c"},
+		{javast.Minus{LeftOperand: a, RightOperand: javast.Minus{LeftOperand: b, RightOperand: c}}, "a - ( b - c )"},
+		{javast.ConditionalAnd{LeftOperand: javast.ConditionalOr{LeftOperand: a, RightOperand: b}, RightOperand: c}, "( a || b ) && c"},
+		{javast.LogicalComplement{Expression: javast.InstanceOf{Expression: a, Type: b}}, "! ( a instanceof b )"},
+		{javast.InstanceOf{Expression: javast.ConditionalExpression{Condition: a, TrueExpression: b, FalseExpression: c}, Type: b}, "( a ? b : c ) instanceof b"},
+		{javast.UnaryMinus{Expression: javast.UnaryMinus{Expression: a}}, "- - a"},
+		{javast.PostfixIncrement{Expression: javast.UnaryMinus{Expression: a}}, "( - a ) ++"},
+		{javast.MemberSelect{Expression: javast.TypeCast{Type: a, Expression: b}, Identifier: "c"}, "( ( a ) b ) . c"},
+		{javast.ArrayAccess{Expression: javast.Plus{LeftOperand: a, RightOperand: b}, Index: javast.Plus{LeftOperand: b, RightOperand: c}}, "( a + b ) [ b + c ]"},
+		{javast.TypeCast{Type: javast.PrimitiveType{PrimitiveTypeKind: javast.INT_TYPE_KIND}, Expression: javast.UnaryMinus{Expression: a}}, "( int ) - a"},
+		{javast.TypeCast{Type: a, Expression: javast.UnaryMinus{Expression: b}}, "( a ) ( - b )"},
+		{javast.TypeCast{Type: a, Expression: javast.Plus{LeftOperand: b, RightOperand: c}}, "( a ) ( b + c )"},
+		{javast.TypeCast{Type: a, Expression: javast.ExpressionLambdaExpression{Expression: b}}, "( a ) ( ) -> b"},
+		{javast.ConditionalExpression{
+			Condition:       javast.Assignment{Variable: a, Expression: b},
+			TrueExpression:  javast.Assignment{Variable: a, Expression: c},
+			FalseExpression: javast.ConditionalExpression{Condition: a, TrueExpression: b, FalseExpression: c},
+		}, "( a = b ) ? a = c : a ? b : c"},
+		{javast.ConditionalExpression{
+			Condition:       javast.ConditionalExpression{Condition: a, TrueExpression: b, FalseExpression: c},
+			TrueExpression:  b,
+			FalseExpression: javast.Assignment{Variable: a, Expression: c},
+		}, "( a ? b : c ) ? b : ( a = c )"},
+		{javast.Assignment{Variable: a, Expression: javast.Assignment{Variable: b, Expression: c}}, "a = b = c"},
+		{javast.Plus{LeftOperand: a, RightOperand: javast.Parenthesized{Expression: b}}, "a + ( b )"},
+	}
+	for _, test := range tests {
+		sw := SpaceWriter{}
+		if _, err := test.node.WriteTo(&sw); err != nil {
+			t.Error(err)
+		}
+		if got := sw.String(); got != test.want {
+			t.Errorf("sw.String() = %s, want %s", got, test.want)
+		}
+	}
+}
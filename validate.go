@@ -0,0 +1,462 @@
+package javast
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Checks that the sealed and non-sealed type declarations of a compilation unit are consistent:
+//
+//   - a type is not both sealed and non-sealed, and neither of them is combined with final;
+//   - a sealed type has a permits clause or subclasses declared in the compilation unit;
+//   - the permitted subclasses declared in the compilation unit extend the sealed type directly,
+//     and are final, sealed or non-sealed;
+//   - the types declared in the compilation unit which extend a sealed type with a permits clause are listed in it;
+//   - a non-sealed type has a sealed direct supertype.
+//
+// Types are matched by their simple names, and types declared outside the compilation unit are assumed to be valid.
+// All violations are returned, joined with [errors.Join], or nil if there are none.
+func ValidateSealed(cu CompilationUnitNode) error {
+	var classes []ClassNode
+	declared := map[string]ClassNode{}
+	Walk(cu, func(node Node) bool {
+		if c, ok := node.(ClassNode); ok && c.GetSimpleName() != "" {
+			classes = append(classes, c)
+			declared[c.GetSimpleName()] = c
+		}
+		return true
+	})
+	var errs []error
+	for _, c := range classes {
+		name, sealed, nonSealed := c.GetSimpleName(), hasModifier(c, SEALED_MODIFIER), hasModifier(c, NON_SEALED_MODIFIER)
+		switch {
+		case sealed && nonSealed:
+			errs = append(errs, fmt.Errorf("%s is both sealed and non-sealed", name))
+		case (sealed || nonSealed) && hasModifier(c, FINAL_MODIFIER):
+			errs = append(errs, fmt.Errorf("%s is final and cannot be sealed or non-sealed", name))
+		}
+		if sealed {
+			permits := c.GetPermitsClause()
+			if len(permits) == 0 {
+				subclasses := false
+				for _, sub := range classes {
+					subclasses = subclasses || extends(sub, name)
+				}
+				if !subclasses {
+					errs = append(errs, fmt.Errorf("sealed %s has no permitted subclasses", name))
+				}
+			}
+			for _, permit := range permits {
+				sub, ok := declared[simpleTypeName(permit)]
+				if !ok {
+					continue
+				}
+				if !extends(sub, name) {
+					errs = append(errs, fmt.Errorf("%s is permitted by sealed %s but does not extend it", sub.GetSimpleName(), name))
+				}
+			}
+		}
+		if nonSealed {
+			count, known := 0, 0
+			sealedSupertype := false
+			for _, supertype := range supertypes(c) {
+				count++
+				if s, ok := declared[simpleTypeName(supertype)]; ok {
+					known++
+					sealedSupertype = sealedSupertype || hasModifier(s, SEALED_MODIFIER)
+				}
+			}
+			if count == known && !sealedSupertype {
+				errs = append(errs, fmt.Errorf("non-sealed %s has no sealed direct supertype", name))
+			}
+		}
+		for _, supertype := range supertypes(c) {
+			s, ok := declared[simpleTypeName(supertype)]
+			if !ok || !hasModifier(s, SEALED_MODIFIER) {
+				continue
+			}
+			if permits := s.GetPermitsClause(); len(permits) > 0 && !permitted(permits, name) {
+				errs = append(errs, fmt.Errorf("%s is not permitted to extend sealed %s", name, s.GetSimpleName()))
+			}
+			if !isFinal(c) && !sealed && !nonSealed {
+				errs = append(errs, fmt.Errorf("%s extends sealed %s and must be final, sealed or non-sealed", name, s.GetSimpleName()))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Reports whether the type declaration has the modifier.
+func hasModifier(c ClassNode, flag Modifier) bool {
+	return hasFlag(c.GetModifiers(), flag)
+}
+
+// Reports whether the modifiers, which may be nil, have the flag.
+func hasFlag(mods ModifiersNode, flag Modifier) bool {
+	if mods == nil {
+		return false
+	}
+	for _, f := range mods.GetFlags() {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// Reports whether the type declaration is final, explicitly or implicitly as enums and records are.
+func isFinal(c ClassNode) bool {
+	switch c.GetKind() {
+	case ENUM, RECORD:
+		return true
+	}
+	return hasModifier(c, FINAL_MODIFIER)
+}
+
+// Returns the direct supertypes of the type declaration.
+func supertypes(c ClassNode) []Node {
+	var nodes []Node
+	if c.GetExtendsClause() != nil {
+		nodes = append(nodes, c.GetExtendsClause())
+	}
+	return append(nodes, c.GetImplementsClause()...)
+}
+
+// Reports whether the type declaration directly extends or implements the type with the simple name.
+func extends(c ClassNode, name string) bool {
+	for _, supertype := range supertypes(c) {
+		if simpleTypeName(supertype) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Reports whether the permits clause lists the type with the simple name.
+func permitted(permits []Node, name string) bool {
+	for _, permit := range permits {
+		if simpleTypeName(permit) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the simple name of a type, such as "Map" for java.util.Map<K, V>, or an empty string if it has none.
+func simpleTypeName(node Node) string {
+	switch t := node.(type) {
+	case IdentifierNode:
+		return t.GetName()
+	case MemberSelectNode:
+		return t.GetIdentifier()
+	case ParameterizedTypeNode:
+		return simpleTypeName(t.GetType())
+	case AnnotatedTypeNode:
+		return simpleTypeName(t.GetUnderlyingType())
+	}
+	return ""
+}
+
+// Checks that the numeric literals of the tree rooted at node are valid according to the JLS:
+//
+//   - the digits match the decimal, hexadecimal, octal or binary form of the literal;
+//   - underscores only separate digits;
+//   - long and float literals have their suffix, and other literals do not have a wrong one;
+//   - int and long literals are in range, and float and double literals are neither too large nor rounded to zero.
+//
+// The int literal 2147483648 and the long literal 9223372036854775808L are only valid as operands of unary minus.
+// All violations are returned, joined with [errors.Join], or nil if there are none.
+func ValidateLiterals(node Node) error {
+	var errs []error
+	validate := func(node Node, negated bool) {
+		var err error
+		switch l := node.(type) {
+		case IntLiteral:
+			err = validateInteger(l.Value, false, negated)
+		case LongLiteral:
+			err = validateInteger(l.Value, true, negated)
+		case FloatLiteral:
+			err = validateFloatingPoint(l.Value, true)
+		case DoubleLiteral:
+			err = validateFloatingPoint(l.Value, false)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	Walk(node, func(node Node) bool {
+		if node.GetKind() == UNARY_MINUS {
+			if x, ok := node.(UnaryNode).GetExpression().(LiteralNode); ok {
+				validate(x, true)
+				return false
+			}
+		}
+		validate(node, false)
+		return true
+	})
+	return errors.Join(errs...)
+}
+
+var (
+	numberForms = strings.NewReplacer(
+		"D", `[0-9](?:[0-9_]*[0-9])?`,
+		"H", `[0-9a-fA-F](?:[0-9a-fA-F_]*[0-9a-fA-F])?`,
+		"E", `(?:[eE][+-]?[0-9](?:[0-9_]*[0-9])?)`,
+	)
+	decimalInteger = regexp.MustCompile(`^(?:0|[1-9](?:[0-9_]*[0-9])?)$`)
+	hexInteger     = regexp.MustCompile(numberForms.Replace(`^0[xX]H$`))
+	octalInteger   = regexp.MustCompile(`^0[0-7_]*[0-7]$`)
+	binaryInteger  = regexp.MustCompile(`^0[bB][01](?:[01_]*[01])?$`)
+	decimalFloat   = regexp.MustCompile(numberForms.Replace(`^(?:D\.(?:D)?E?|\.DE?|DE?)$`))
+	hexFloat       = regexp.MustCompile(numberForms.Replace(`^0[xX](?:H\.?|(?:H)?\.H)[pP][+-]?D$`))
+)
+
+// Checks the text of an int or long literal.
+func validateInteger(text string, long, negated bool) error {
+	kind, digits := "int", text
+	if long {
+		kind = "long"
+		if !strings.HasSuffix(text, "l") && !strings.HasSuffix(text, "L") {
+			return fmt.Errorf("long literal %s has no L suffix", text)
+		}
+		digits = text[:len(text)-1]
+	}
+	var base int
+	switch {
+	case hexInteger.MatchString(digits):
+		base, digits = 16, digits[2:]
+	case binaryInteger.MatchString(digits):
+		base, digits = 2, digits[2:]
+	case octalInteger.MatchString(digits):
+		base, digits = 8, digits[1:]
+	case decimalInteger.MatchString(digits):
+		base = 10
+	default:
+		return fmt.Errorf("malformed %s literal %s", kind, text)
+	}
+	value, err := strconv.ParseUint(strings.ReplaceAll(digits, "_", ""), base, 64)
+	limit := uint64(math.MaxUint32)
+	switch {
+	case base == 10 && long:
+		limit = math.MaxInt64
+	case base == 10:
+		limit = math.MaxInt32
+	case long:
+		limit = math.MaxUint64
+	}
+	if base == 10 && negated {
+		limit++
+	}
+	if err != nil || value > limit {
+		return fmt.Errorf("%s literal %s is out of range", kind, text)
+	}
+	return nil
+}
+
+// Checks the text of a float or double literal.
+func validateFloatingPoint(text string, float bool) error {
+	kind, digits, suffix := "double", text, false
+	if float {
+		kind = "float"
+		if !strings.HasSuffix(text, "f") && !strings.HasSuffix(text, "F") {
+			return fmt.Errorf("float literal %s has no F suffix", text)
+		}
+		digits, suffix = text[:len(text)-1], true
+	} else if strings.HasSuffix(text, "d") || strings.HasSuffix(text, "D") {
+		digits, suffix = text[:len(text)-1], true
+	}
+	hex := hexFloat.MatchString(digits)
+	if !hex && !decimalFloat.MatchString(digits) || !hex && !suffix && !strings.ContainsAny(digits, ".eE") {
+		return fmt.Errorf("malformed %s literal %s", kind, text)
+	}
+	bitSize := 64
+	if float {
+		bitSize = 32
+	}
+	digits = strings.ReplaceAll(digits, "_", "")
+	value, err := strconv.ParseFloat(digits, bitSize)
+	if err != nil {
+		return fmt.Errorf("%s literal %s is too large", kind, text)
+	}
+	mantissa := strings.FieldsFunc(digits, func(r rune) bool { return r == 'p' || r == 'P' || !hex && (r == 'e' || r == 'E') })[0]
+	if hex {
+		mantissa = mantissa[2:]
+	}
+	if value == 0 && strings.ContainsAny(mantissa, "123456789abcdefABCDEF") {
+		return fmt.Errorf("%s literal %s is too small", kind, text)
+	}
+	return nil
+}
+
+// Checks that only the last parameter of a method, a lambda expression or a record header is a variable arity
+// parameter, and that no other variable of the tree rooted at node is.
+// All violations are returned, joined with [errors.Join], or nil if there are none.
+func ValidateVarargs(node Node) error {
+	var errs []error
+	// The parameters of a method, a lambda expression or a record header, which its variable children are
+	// in the order in which they are visited, once skip other variables (a receiver parameter) have been.
+	type owner struct {
+		skip   int
+		params []VariableNode
+	}
+	// The owner of each node on the path to the visited node, or nil for the nodes which have no parameters.
+	var stack []*owner
+	check := func(name string, params []VariableNode) *owner {
+		for i, param := range params {
+			if param.IsVarargs() && i < len(params)-1 {
+				errs = append(errs, fmt.Errorf("varargs parameter %s of %s is not the last parameter", param.GetName(), name))
+			}
+		}
+		return &owner{params: params}
+	}
+	WalkPrePost(node, func(node Node) bool {
+		parameter := false
+		if len(stack) > 0 {
+			if o := stack[len(stack)-1]; o != nil {
+				if _, ok := node.(VariableNode); ok {
+					if o.skip > 0 {
+						o.skip--
+					} else if len(o.params) > 0 {
+						o.params = o.params[1:]
+						parameter = true
+					}
+				}
+			}
+		}
+		var o *owner
+		switch n := node.(type) {
+		case MethodNode:
+			o = check("method "+n.GetName(), n.GetParameters())
+			if n.GetReceiverParameter() != nil {
+				o.skip = 1
+			}
+		case LambdaExpressionNode:
+			o = check("lambda expression", n.GetParameters())
+		case ClassNode:
+			o = check("record "+n.GetSimpleName(), n.GetRecordComponents())
+		case VariableNode:
+			if n.IsVarargs() && !parameter {
+				errs = append(errs, fmt.Errorf("variable %s is not a parameter and cannot be varargs", n.GetName()))
+			}
+		}
+		stack = append(stack, o)
+		return true
+	}, func(Node) {
+		stack = stack[:len(stack)-1]
+	})
+	return errors.Join(errs...)
+}
+
+// Checks that the explicit constructor invocations of the tree rooted at node are valid according to the JLS:
+//
+//   - this(...) and super(...) are only invoked by the first statement of a constructor body,
+//     which is not the body of a compact canonical constructor of a record;
+//   - this(...) is not qualified;
+//   - the constructors of enums do not invoke super(...).
+//
+// All violations are returned, joined with [errors.Join], or nil if there are none.
+func ValidateConstructorInvocations(node Node) error {
+	var errs []error
+	// The place of a node relative to the enclosing constructor: its body, the first statement of its body,
+	// or the expression of that statement.
+	const (
+		elsewhere = iota
+		body
+		statement
+		invocation
+	)
+	var validate func(node Node, place int, enum bool)
+	validate = func(node Node, place int, enum bool) {
+		if keyword := constructorInvocation(node); keyword != "" {
+			if place != invocation {
+				errs = append(errs, fmt.Errorf("%s(...) is not the first statement of a constructor", keyword))
+			}
+			switch ms := node.(MethodInvocationNode).GetMethodSelect(); {
+			case ms.GetKind() == THIS && ms.(ThisNode).GetQualifier() != nil:
+				errs = append(errs, fmt.Errorf("this(...) cannot be qualified"))
+			case ms.GetKind() == SUPER && enum && place == invocation:
+				errs = append(errs, fmt.Errorf("super(...) cannot be invoked by a constructor of an enum"))
+			}
+		}
+		m, constructor := node.(MethodNode)
+		constructor = constructor && m.GetReturnType() == nil && !m.IsCompact()
+		if c, ok := node.(ClassNode); ok {
+			enum = c.GetKind() == ENUM
+		}
+		for i, child := range Children(node) {
+			next := elsewhere
+			switch {
+			case constructor && child.GetKind() == BLOCK:
+				next = body
+			case place == body && i == 0 && child.GetKind() == EXPRESSION_STATEMENT:
+				next = statement
+			case place == statement:
+				next = invocation
+			}
+			validate(child, next, enum)
+		}
+	}
+	validate(node, elsewhere, false)
+	return errors.Join(errs...)
+}
+
+// Returns the keyword of an explicit constructor invocation, "this" or "super",
+// or an empty string if the node is not one.
+func constructorInvocation(node Node) string {
+	if mi, ok := node.(MethodInvocationNode); ok {
+		switch kindOf(mi.GetMethodSelect()) {
+		case THIS:
+			return "this"
+		case SUPER:
+			return "super"
+		}
+	}
+	return ""
+}
+
+// Checks that the initializers of the tree rooted at node are declared where the JLS allows them:
+//
+//   - static blocks are only declared as members of type declarations;
+//   - records do not declare instance initializers;
+//   - interfaces and annotation types do not declare initializers.
+//
+// All violations are returned, joined with [errors.Join], or nil if there are none.
+func ValidateInitializers(node Node) error {
+	var errs []error
+	var validate func(node Node, member bool)
+	validate = func(node Node, member bool) {
+		if b, ok := node.(BlockNode); ok && b.IsStatic() && !member {
+			errs = append(errs, fmt.Errorf("static block is not a member of a type declaration"))
+		}
+		c, ok := node.(ClassNode)
+		for _, child := range Children(node) {
+			// The only blocks among the children of a type declaration are its initializers.
+			initializer, isBlock := child.(BlockNode)
+			if !ok || !isBlock {
+				validate(child, false)
+				continue
+			}
+			kind := "an instance initializer"
+			if initializer.IsStatic() {
+				kind = "a static initializer"
+			}
+			switch c.GetKind() {
+			case RECORD:
+				if !initializer.IsStatic() {
+					errs = append(errs, fmt.Errorf("record %s cannot declare %s", c.GetSimpleName(), kind))
+				}
+			case INTERFACE:
+				errs = append(errs, fmt.Errorf("interface %s cannot declare %s", c.GetSimpleName(), kind))
+			case ANNOTATION_TYPE:
+				errs = append(errs, fmt.Errorf("annotation type %s cannot declare %s", c.GetSimpleName(), kind))
+			}
+			validate(child, true)
+		}
+	}
+	validate(node, false)
+	return errors.Join(errs...)
+}
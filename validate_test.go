@@ -0,0 +1,275 @@
+package javast_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kapavkin/javast"
+)
+
+func TestValidateSealed(t *testing.T) {
+	t.Parallel()
+	src := `sealed interface Shape permits Circle, Square {}
+final class Circle implements Shape {}
+non-sealed class Square implements Shape {}
+sealed interface Vehicle {}
+record Car() implements Vehicle {}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := javast.ValidateSealed(cu); err != nil {
+		t.Errorf("ValidateSealed() = %v, want nil", err)
+	}
+}
+
+func TestValidateSealed_Error(t *testing.T) {
+	t.Parallel()
+	src := `sealed interface Shape permits Circle {}
+final class Circle {}
+class Square implements Shape {}
+non-sealed class Triangle {}
+sealed final class Empty {}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = javast.ValidateSealed(cu)
+	if err == nil {
+		t.Fatal("ValidateSealed() = nil, want error")
+	}
+	got := err.Error()
+	want := "Circle is permitted by sealed Shape but does not extend it\n" +
+		"Square is not permitted to extend sealed Shape\n" +
+		"Square extends sealed Shape and must be final, sealed or non-sealed\n" +
+		"non-sealed Triangle has no sealed direct supertype\n" +
+		"Empty is final and cannot be sealed or non-sealed\n" +
+		"sealed Empty has no permitted subclasses"
+	if got != want {
+		t.Errorf("ValidateSealed() = %q, want %q", got, want)
+	}
+}
+
+func TestValidateLiterals(t *testing.T) {
+	t.Parallel()
+	valid := []javast.Node{
+		javast.IntLiteral{Value: "0"},
+		javast.IntLiteral{Value: "1_000_000"},
+		javast.IntLiteral{Value: "0xFFFF_FFFF"},
+		javast.IntLiteral{Value: "0_777"},
+		javast.IntLiteral{Value: "0b1010"},
+		javast.UnaryMinus{Expression: javast.IntLiteral{Value: "2147483648"}},
+		javast.LongLiteral{Value: "0x7fffffffffffffffL"},
+		javast.UnaryMinus{Expression: javast.LongLiteral{Value: "9223372036854775808L"}},
+		javast.FloatLiteral{Value: "3.4028235e38f"},
+		javast.FloatLiteral{Value: "1f"},
+		javast.DoubleLiteral{Value: ".5"},
+		javast.DoubleLiteral{Value: "1e1_0"},
+		javast.DoubleLiteral{Value: "0x1.8p1"},
+		javast.DoubleLiteral{Value: "1D"},
+		javast.DoubleLiteral{Value: "4.9e-324"},
+	}
+	for _, node := range valid {
+		if err := javast.ValidateLiterals(node); err != nil {
+			t.Errorf("ValidateLiterals() = %v, want nil", err)
+		}
+	}
+	invalid := map[javast.Node]string{
+		javast.IntLiteral{Value: "9999999999"}:    "int literal 9999999999 is out of range",
+		javast.IntLiteral{Value: "2147483648"}:    "int literal 2147483648 is out of range",
+		javast.IntLiteral{Value: "0x1_0000_0000"}: "int literal 0x1_0000_0000 is out of range",
+		javast.IntLiteral{Value: "1_"}:            "malformed int literal 1_",
+		javast.IntLiteral{Value: "0x_1"}:          "malformed int literal 0x_1",
+		javast.IntLiteral{Value: "09"}:            "malformed int literal 09",
+		javast.IntLiteral{Value: "0b2"}:           "malformed int literal 0b2",
+		javast.LongLiteral{Value: "10"}:           "long literal 10 has no L suffix",
+		javast.LongLiteral{Value: "1_L"}:          "malformed long literal 1_L",
+		javast.FloatLiteral{Value: "1.0"}:         "float literal 1.0 has no F suffix",
+		javast.FloatLiteral{Value: "1e39f"}:       "float literal 1e39f is too large",
+		javast.FloatLiteral{Value: "1e-50f"}:      "float literal 1e-50f is too small",
+		javast.DoubleLiteral{Value: "1"}:          "malformed double literal 1",
+		javast.DoubleLiteral{Value: "0x1.8"}:      "malformed double literal 0x1.8",
+		javast.DoubleLiteral{Value: "1._5"}:       "malformed double literal 1._5",
+	}
+	for node, want := range invalid {
+		if err := javast.ValidateLiterals(node); err == nil {
+			t.Errorf("ValidateLiterals() = nil, want %s", want)
+		} else if got := err.Error(); got != want {
+			t.Errorf("ValidateLiterals() = %s, want %s", got, want)
+		}
+	}
+}
+
+func TestValidateVarargs(t *testing.T) {
+	t.Parallel()
+	src := `class Format {
+    static String format(String pattern, Object... args) { return null; }
+    Function<String[], String> join = (String... parts) -> null;
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := javast.ValidateVarargs(cu); err != nil {
+		t.Errorf("ValidateVarargs() = %v, want nil", err)
+	}
+	varargs := func(name string) javast.Variable {
+		return javast.Variable{
+			Name:    name,
+			Type:    javast.ArrayType{Type: javast.Identifier{Name: "Object"}},
+			Varargs: true,
+		}
+	}
+	m := javast.Method{
+		Name:       "format",
+		ReturnType: javast.Identifier{Name: "String"},
+		Parameters: []javast.VariableNode{
+			varargs("args"),
+			javast.Variable{Name: "pattern", Type: javast.Identifier{Name: "String"}},
+		},
+		Body: javast.Block{
+			Statements: []javast.StatementNode{
+				varargs("values"),
+			},
+		},
+	}
+	err = javast.ValidateVarargs(m)
+	if err == nil {
+		t.Fatal("ValidateVarargs() = nil, want error")
+	}
+	got := err.Error()
+	want := "varargs parameter args of method format is not the last parameter\n" +
+		"variable values is not a parameter and cannot be varargs"
+	if got != want {
+		t.Errorf("ValidateVarargs() = %q, want %q", got, want)
+	}
+}
+
+func TestValidateVarargs_Shadowing(t *testing.T) {
+	t.Parallel()
+	varargs := func(name string) javast.Variable {
+		return javast.Variable{
+			Name:    name,
+			Type:    javast.ArrayType{Type: javast.Identifier{Name: "Object"}},
+			Varargs: true,
+		}
+	}
+	m := javast.Method{
+		Name:       "f",
+		ReturnType: javast.PrimitiveType{PrimitiveTypeKind: javast.VOID_TYPE_KIND},
+		Parameters: []javast.VariableNode{varargs("xs")},
+		Body: javast.Block{
+			Statements: []javast.StatementNode{
+				varargs("xs"),
+				javast.ExpressionStatement{
+					Expression: javast.ExpressionLambdaExpression{
+						Parameters: []javast.VariableNode{
+							varargs("xs"),
+							javast.Variable{Name: "n", Type: javast.PrimitiveType{PrimitiveTypeKind: javast.INT_TYPE_KIND}},
+						},
+						Expression: javast.Identifier{Name: "n"},
+					},
+				},
+			},
+		},
+	}
+	err := javast.ValidateVarargs(m)
+	if err == nil {
+		t.Fatal("ValidateVarargs() = nil, want error")
+	}
+	got := err.Error()
+	want := "variable xs is not a parameter and cannot be varargs\n" +
+		"varargs parameter xs of lambda expression is not the last parameter"
+	if got != want {
+		t.Errorf("ValidateVarargs() = %q, want %q", got, want)
+	}
+}
+
+func TestValidateConstructorInvocations(t *testing.T) {
+	t.Parallel()
+	src := `class Point {
+    Point() { this(0, 0); }
+    Point(int x, int y) { super(); }
+    enum Axis { X, Y; Axis() { this(1); } Axis(int d) {} }
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := javast.ValidateConstructorInvocations(cu); err != nil {
+		t.Errorf("ValidateConstructorInvocations() = %v, want nil", err)
+	}
+	src = `class Point {
+    Point() { init(); this(0, 0); }
+    void init() { super(); }
+    Point(int x) { Outer.this(x, 0); }
+    enum Axis { X; Axis() { super(); } }
+}
+record Pair(int a, int b) { Pair { this(a, b); } }
+`
+	cu, err = javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = javast.ValidateConstructorInvocations(cu)
+	if err == nil {
+		t.Fatal("ValidateConstructorInvocations() = nil, want error")
+	}
+	got := err.Error()
+	want := "this(...) is not the first statement of a constructor\n" +
+		"super(...) is not the first statement of a constructor\n" +
+		"this(...) cannot be qualified\n" +
+		"super(...) cannot be invoked by a constructor of an enum\n" +
+		"this(...) is not the first statement of a constructor"
+	if got != want {
+		t.Errorf("ValidateConstructorInvocations() = %q, want %q", got, want)
+	}
+}
+
+func TestValidateInitializers(t *testing.T) {
+	t.Parallel()
+	src := `class Registry {
+    static { load(); }
+    { count = 0; }
+    enum Kind { A; static { init(); } { check(); } }
+    record Entry(String key) { static { init(); } }
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := javast.ValidateInitializers(cu); err != nil {
+		t.Errorf("ValidateInitializers() = %v, want nil", err)
+	}
+	src = `record Entry(String key) { { check(); } }
+interface Store { static { init(); } }
+@interface Marker { { init(); } }
+`
+	cu, err = javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = javast.ValidateInitializers(javast.CompilationUnit{
+		TypeDecls: append(cu.GetTypeDecls(), javast.Method{
+			Name:       "run",
+			ReturnType: javast.PrimitiveType{PrimitiveTypeKind: javast.VOID_TYPE_KIND},
+			Body:       javast.Block{Statements: []javast.StatementNode{javast.Block{Static: true}}},
+		}),
+	})
+	if err == nil {
+		t.Fatal("ValidateInitializers() = nil, want error")
+	}
+	got := err.Error()
+	want := "record Entry cannot declare an instance initializer\n" +
+		"interface Store cannot declare a static initializer\n" +
+		"annotation type Marker cannot declare an instance initializer\n" +
+		"static block is not a member of a type declaration"
+	if got != want {
+		t.Errorf("ValidateInitializers() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,553 @@
+package javast
+
+// A Visitor visits nodes of a syntax tree by the interfaces they implement.
+// Each node calls the method of the visitor for its interface from [Node.Accept],
+// so that an analysis can handle nodes without a type switch over all node structs.
+//
+// Embed [BaseVisitor] to implement only the methods of interest.
+type Visitor interface {
+	VisitArrayType(node ArrayTypeNode)                         // Visits an [ArrayTypeNode].
+	VisitDefaultCaseLabel(node DefaultCaseLabelNode)           // Visits a [DefaultCaseLabelNode].
+	VisitAnnotatedType(node AnnotatedTypeNode)                 // Visits an [AnnotatedTypeNode].
+	VisitAnnotation(node AnnotationNode)                       // Visits an [AnnotationNode].
+	VisitArrayAccess(node ArrayAccessNode)                     // Visits an [ArrayAccessNode].
+	VisitAssignment(node AssignmentNode)                       // Visits an [AssignmentNode].
+	VisitBinary(node BinaryNode)                               // Visits a [BinaryNode].
+	VisitCompoundAssignment(node CompoundAssignmentNode)       // Visits a [CompoundAssignmentNode].
+	VisitConditionalExpression(node ConditionalExpressionNode) // Visits a [ConditionalExpressionNode].
+	VisitErroneous(node ErroneousNode)                         // Visits an [ErroneousNode].
+	VisitIdentifier(node IdentifierNode)                       // Visits an [IdentifierNode].
+	VisitInstanceOf(node InstanceOfNode)                       // Visits an [InstanceOfNode].
+	VisitLambdaExpression(node LambdaExpressionNode)           // Visits a [LambdaExpressionNode].
+	VisitLiteral(node LiteralNode)                             // Visits a [LiteralNode].
+	VisitMemberReference(node MemberReferenceNode)             // Visits a [MemberReferenceNode].
+	VisitMemberSelect(node MemberSelectNode)                   // Visits a [MemberSelectNode].
+	VisitMethodInvocation(node MethodInvocationNode)           // Visits a [MethodInvocationNode].
+	VisitNewArray(node NewArrayNode)                           // Visits a [NewArrayNode].
+	VisitNewClass(node NewClassNode)                           // Visits a [NewClassNode].
+	VisitParenthesized(node ParenthesizedNode)                 // Visits a [ParenthesizedNode].
+	VisitSwitchExpression(node SwitchExpressionNode)           // Visits a [SwitchExpressionNode].
+	VisitTypeCast(node TypeCastNode)                           // Visits a [TypeCastNode].
+	VisitUnary(node UnaryNode)                                 // Visits an [UnaryNode].
+	VisitBindingPattern(node BindingPatternNode)               // Visits a [BindingPatternNode].
+	VisitGuardedPattern(node GuardedPatternNode)               // Visits a [GuardedPatternNode].
+	VisitParenthesizedPattern(node ParenthesizedPatternNode)   // Visits a [ParenthesizedPatternNode].
+	VisitCase(node CaseNode)                                   // Visits a [CaseNode].
+	VisitCatch(node CatchNode)                                 // Visits a [CatchNode].
+	VisitCompilationUnit(node CompilationUnitNode)             // Visits a [CompilationUnitNode].
+	VisitExports(node ExportsNode)                             // Visits an [ExportsNode].
+	VisitOpens(node OpensNode)                                 // Visits an [OpensNode].
+	VisitProvides(node ProvidesNode)                           // Visits a [ProvidesNode].
+	VisitRequires(node RequiresNode)                           // Visits a [RequiresNode].
+	VisitUses(node UsesNode)                                   // Visits an [UsesNode].
+	VisitImport(node ImportNode)                               // Visits an [ImportNode].
+	VisitIntersectionType(node IntersectionTypeNode)           // Visits an [IntersectionTypeNode].
+	VisitMethod(node MethodNode)                               // Visits a [MethodNode].
+	VisitModifiers(node ModifiersNode)                         // Visits a [ModifiersNode].
+	VisitModule(node ModuleNode)                               // Visits a [ModuleNode].
+	VisitPackage(node PackageNode)                             // Visits a [PackageNode].
+	VisitParameterizedType(node ParameterizedTypeNode)         // Visits a [ParameterizedTypeNode].
+	VisitPrimitiveType(node PrimitiveTypeNode)                 // Visits a [PrimitiveTypeNode].
+	VisitAssert(node AssertNode)                               // Visits an [AssertNode].
+	VisitBlock(node BlockNode)                                 // Visits a [BlockNode].
+	VisitBreak(node BreakNode)                                 // Visits a [BreakNode].
+	VisitClass(node ClassNode)                                 // Visits a [ClassNode].
+	VisitContinue(node ContinueNode)                           // Visits a [ContinueNode].
+	VisitDoWhileLoop(node DoWhileLoopNode)                     // Visits a [DoWhileLoopNode].
+	VisitEmptyStatement(node EmptyStatementNode)               // Visits an [EmptyStatementNode].
+	VisitEmptyExpression(node EmptyExpressionNode)             // Visits an [EmptyExpressionNode].
+	VisitEnhancedForLoop(node EnhancedForLoopNode)             // Visits an [EnhancedForLoopNode].
+	VisitExpressionStatement(node ExpressionStatementNode)     // Visits an [ExpressionStatementNode].
+	VisitForLoop(node ForLoopNode)                             // Visits a [ForLoopNode].
+	VisitIf(node IfNode)                                       // Visits an [IfNode].
+	VisitLabeledStatement(node LabeledStatementNode)           // Visits a [LabeledStatementNode].
+	VisitReturn(node ReturnNode)                               // Visits a [ReturnNode].
+	VisitSwitch(node SwitchNode)                               // Visits a [SwitchNode].
+	VisitSynchronized(node SynchronizedNode)                   // Visits a [SynchronizedNode].
+	VisitThrow(node ThrowNode)                                 // Visits a [ThrowNode].
+	VisitTry(node TryNode)                                     // Visits a [TryNode].
+	VisitVariable(node VariableNode)                           // Visits a [VariableNode].
+	VisitWhileLoop(node WhileLoopNode)                         // Visits a [WhileLoopNode].
+	VisitYield(node YieldNode)                                 // Visits a [YieldNode].
+	VisitTypeParameter(node TypeParameterNode)                 // Visits a [TypeParameterNode].
+	VisitUnionType(node UnionTypeNode)                         // Visits an [UnionTypeNode].
+	VisitWildcard(node WildcardNode)                           // Visits a [WildcardNode].
+	VisitEnumConstant(node EnumConstantNode)                   // Visits an [EnumConstantNode].
+	VisitRecordPattern(node RecordPatternNode)                 // Visits a [RecordPatternNode].
+	VisitConstantCaseLabel(node ConstantCaseLabelNode)         // Visits a [ConstantCaseLabelNode].
+	VisitPatternCaseLabel(node PatternCaseLabelNode)           // Visits a [PatternCaseLabelNode].
+	VisitVariableDeclaration(node VariableDeclarationNode)     // Visits a [VariableDeclarationNode].
+	VisitClassLiteral(node ClassLiteralNode)                   // Visits a [ClassLiteralNode].
+	VisitThis(node ThisNode)                                   // Visits a [ThisNode].
+	VisitSuper(node SuperNode)                                 // Visits a [SuperNode].
+}
+
+// A BaseVisitor implements [Visitor] by calling Default for every node.
+// If Default is nil, nodes are ignored.
+type BaseVisitor struct {
+	Default func(node Node) // The action for nodes whose method is not overridden.
+}
+
+func (bv BaseVisitor) visit(node Node) {
+	if bv.Default != nil {
+		bv.Default(node)
+	}
+}
+
+func (bv BaseVisitor) VisitArrayType(node ArrayTypeNode)                         { bv.visit(node) }
+func (bv BaseVisitor) VisitDefaultCaseLabel(node DefaultCaseLabelNode)           { bv.visit(node) }
+func (bv BaseVisitor) VisitAnnotatedType(node AnnotatedTypeNode)                 { bv.visit(node) }
+func (bv BaseVisitor) VisitAnnotation(node AnnotationNode)                       { bv.visit(node) }
+func (bv BaseVisitor) VisitArrayAccess(node ArrayAccessNode)                     { bv.visit(node) }
+func (bv BaseVisitor) VisitAssignment(node AssignmentNode)                       { bv.visit(node) }
+func (bv BaseVisitor) VisitBinary(node BinaryNode)                               { bv.visit(node) }
+func (bv BaseVisitor) VisitCompoundAssignment(node CompoundAssignmentNode)       { bv.visit(node) }
+func (bv BaseVisitor) VisitConditionalExpression(node ConditionalExpressionNode) { bv.visit(node) }
+func (bv BaseVisitor) VisitErroneous(node ErroneousNode)                         { bv.visit(node) }
+func (bv BaseVisitor) VisitIdentifier(node IdentifierNode)                       { bv.visit(node) }
+func (bv BaseVisitor) VisitInstanceOf(node InstanceOfNode)                       { bv.visit(node) }
+func (bv BaseVisitor) VisitLambdaExpression(node LambdaExpressionNode)           { bv.visit(node) }
+func (bv BaseVisitor) VisitLiteral(node LiteralNode)                             { bv.visit(node) }
+func (bv BaseVisitor) VisitMemberReference(node MemberReferenceNode)             { bv.visit(node) }
+func (bv BaseVisitor) VisitMemberSelect(node MemberSelectNode)                   { bv.visit(node) }
+func (bv BaseVisitor) VisitMethodInvocation(node MethodInvocationNode)           { bv.visit(node) }
+func (bv BaseVisitor) VisitNewArray(node NewArrayNode)                           { bv.visit(node) }
+func (bv BaseVisitor) VisitNewClass(node NewClassNode)                           { bv.visit(node) }
+func (bv BaseVisitor) VisitParenthesized(node ParenthesizedNode)                 { bv.visit(node) }
+func (bv BaseVisitor) VisitSwitchExpression(node SwitchExpressionNode)           { bv.visit(node) }
+func (bv BaseVisitor) VisitTypeCast(node TypeCastNode)                           { bv.visit(node) }
+func (bv BaseVisitor) VisitUnary(node UnaryNode)                                 { bv.visit(node) }
+func (bv BaseVisitor) VisitBindingPattern(node BindingPatternNode)               { bv.visit(node) }
+func (bv BaseVisitor) VisitGuardedPattern(node GuardedPatternNode)               { bv.visit(node) }
+func (bv BaseVisitor) VisitParenthesizedPattern(node ParenthesizedPatternNode)   { bv.visit(node) }
+func (bv BaseVisitor) VisitCase(node CaseNode)                                   { bv.visit(node) }
+func (bv BaseVisitor) VisitCatch(node CatchNode)                                 { bv.visit(node) }
+func (bv BaseVisitor) VisitCompilationUnit(node CompilationUnitNode)             { bv.visit(node) }
+func (bv BaseVisitor) VisitExports(node ExportsNode)                             { bv.visit(node) }
+func (bv BaseVisitor) VisitOpens(node OpensNode)                                 { bv.visit(node) }
+func (bv BaseVisitor) VisitProvides(node ProvidesNode)                           { bv.visit(node) }
+func (bv BaseVisitor) VisitRequires(node RequiresNode)                           { bv.visit(node) }
+func (bv BaseVisitor) VisitUses(node UsesNode)                                   { bv.visit(node) }
+func (bv BaseVisitor) VisitImport(node ImportNode)                               { bv.visit(node) }
+func (bv BaseVisitor) VisitIntersectionType(node IntersectionTypeNode)           { bv.visit(node) }
+func (bv BaseVisitor) VisitMethod(node MethodNode)                               { bv.visit(node) }
+func (bv BaseVisitor) VisitModifiers(node ModifiersNode)                         { bv.visit(node) }
+func (bv BaseVisitor) VisitModule(node ModuleNode)                               { bv.visit(node) }
+func (bv BaseVisitor) VisitPackage(node PackageNode)                             { bv.visit(node) }
+func (bv BaseVisitor) VisitParameterizedType(node ParameterizedTypeNode)         { bv.visit(node) }
+func (bv BaseVisitor) VisitPrimitiveType(node PrimitiveTypeNode)                 { bv.visit(node) }
+func (bv BaseVisitor) VisitAssert(node AssertNode)                               { bv.visit(node) }
+func (bv BaseVisitor) VisitBlock(node BlockNode)                                 { bv.visit(node) }
+func (bv BaseVisitor) VisitBreak(node BreakNode)                                 { bv.visit(node) }
+func (bv BaseVisitor) VisitClass(node ClassNode)                                 { bv.visit(node) }
+func (bv BaseVisitor) VisitContinue(node ContinueNode)                           { bv.visit(node) }
+func (bv BaseVisitor) VisitDoWhileLoop(node DoWhileLoopNode)                     { bv.visit(node) }
+func (bv BaseVisitor) VisitEmptyStatement(node EmptyStatementNode)               { bv.visit(node) }
+func (bv BaseVisitor) VisitEmptyExpression(node EmptyExpressionNode)             { bv.visit(node) }
+func (bv BaseVisitor) VisitEnhancedForLoop(node EnhancedForLoopNode)             { bv.visit(node) }
+func (bv BaseVisitor) VisitExpressionStatement(node ExpressionStatementNode)     { bv.visit(node) }
+func (bv BaseVisitor) VisitForLoop(node ForLoopNode)                             { bv.visit(node) }
+func (bv BaseVisitor) VisitIf(node IfNode)                                       { bv.visit(node) }
+func (bv BaseVisitor) VisitLabeledStatement(node LabeledStatementNode)           { bv.visit(node) }
+func (bv BaseVisitor) VisitReturn(node ReturnNode)                               { bv.visit(node) }
+func (bv BaseVisitor) VisitSwitch(node SwitchNode)                               { bv.visit(node) }
+func (bv BaseVisitor) VisitSynchronized(node SynchronizedNode)                   { bv.visit(node) }
+func (bv BaseVisitor) VisitThrow(node ThrowNode)                                 { bv.visit(node) }
+func (bv BaseVisitor) VisitTry(node TryNode)                                     { bv.visit(node) }
+func (bv BaseVisitor) VisitVariable(node VariableNode)                           { bv.visit(node) }
+func (bv BaseVisitor) VisitWhileLoop(node WhileLoopNode)                         { bv.visit(node) }
+func (bv BaseVisitor) VisitYield(node YieldNode)                                 { bv.visit(node) }
+func (bv BaseVisitor) VisitTypeParameter(node TypeParameterNode)                 { bv.visit(node) }
+func (bv BaseVisitor) VisitUnionType(node UnionTypeNode)                         { bv.visit(node) }
+func (bv BaseVisitor) VisitWildcard(node WildcardNode)                           { bv.visit(node) }
+func (bv BaseVisitor) VisitEnumConstant(node EnumConstantNode)                   { bv.visit(node) }
+func (bv BaseVisitor) VisitRecordPattern(node RecordPatternNode)                 { bv.visit(node) }
+func (bv BaseVisitor) VisitConstantCaseLabel(node ConstantCaseLabelNode)         { bv.visit(node) }
+func (bv BaseVisitor) VisitPatternCaseLabel(node PatternCaseLabelNode)           { bv.visit(node) }
+func (bv BaseVisitor) VisitVariableDeclaration(node VariableDeclarationNode)     { bv.visit(node) }
+func (bv BaseVisitor) VisitClassLiteral(node ClassLiteralNode)                   { bv.visit(node) }
+func (bv BaseVisitor) VisitThis(node ThisNode)                                   { bv.visit(node) }
+func (bv BaseVisitor) VisitSuper(node SuperNode)                                 { bv.visit(node) }
+
+// Implements [Node] interface for [AnnotatedType] by calling [Visitor.VisitAnnotatedType].
+func (at AnnotatedType) Accept(visitor Visitor) { visitor.VisitAnnotatedType(at) }
+
+// Implements [Node] interface for [Annotation] by calling [Visitor.VisitAnnotation].
+func (a Annotation) Accept(visitor Visitor) { visitor.VisitAnnotation(a) }
+
+// Implements [Node] interface for [TypeAnnotation] by calling [Visitor.VisitAnnotation].
+func (ta TypeAnnotation) Accept(visitor Visitor) { visitor.VisitAnnotation(ta) }
+
+// Implements [Node] interface for [ArrayAccess] by calling [Visitor.VisitArrayAccess].
+func (aa ArrayAccess) Accept(visitor Visitor) { visitor.VisitArrayAccess(aa) }
+
+// Implements [Node] interface for [ArrayType] by calling [Visitor.VisitArrayType].
+func (at ArrayType) Accept(visitor Visitor) { visitor.VisitArrayType(at) }
+
+// Implements [Node] interface for [Assert] by calling [Visitor.VisitAssert].
+func (a Assert) Accept(visitor Visitor) { visitor.VisitAssert(a) }
+
+// Implements [Node] interface for [Assignment] by calling [Visitor.VisitAssignment].
+func (a Assignment) Accept(visitor Visitor) { visitor.VisitAssignment(a) }
+
+// Implements [Node] interface for [Block] by calling [Visitor.VisitBlock].
+func (b Block) Accept(visitor Visitor) { visitor.VisitBlock(b) }
+
+// Implements [Node] interface for [Break] by calling [Visitor.VisitBreak].
+func (b Break) Accept(visitor Visitor) { visitor.VisitBreak(b) }
+
+// Implements [Node] interface for [StatementCase] by calling [Visitor.VisitCase].
+func (sc StatementCase) Accept(visitor Visitor) { visitor.VisitCase(sc) }
+
+// Implements [Node] interface for [RuleCase] by calling [Visitor.VisitCase].
+func (rc RuleCase) Accept(visitor Visitor) { visitor.VisitCase(rc) }
+
+// Implements [Node] interface for [Catch] by calling [Visitor.VisitCatch].
+func (c Catch) Accept(visitor Visitor) { visitor.VisitCatch(c) }
+
+// Implements [Node] interface for [Class] by calling [Visitor.VisitClass].
+func (c Class) Accept(visitor Visitor) { visitor.VisitClass(c) }
+
+// Implements [Node] interface for [CompilationUnit] by calling [Visitor.VisitCompilationUnit].
+func (cu CompilationUnit) Accept(visitor Visitor) { visitor.VisitCompilationUnit(cu) }
+
+// Implements [Node] interface for [ConditionalExpression] by calling [Visitor.VisitConditionalExpression].
+func (cx ConditionalExpression) Accept(visitor Visitor) { visitor.VisitConditionalExpression(cx) }
+
+// Implements [Node] interface for [Continue] by calling [Visitor.VisitContinue].
+func (c Continue) Accept(visitor Visitor) { visitor.VisitContinue(c) }
+
+// Implements [Node] interface for [DoWhileLoop] by calling [Visitor.VisitDoWhileLoop].
+func (dwl DoWhileLoop) Accept(visitor Visitor) { visitor.VisitDoWhileLoop(dwl) }
+
+// Implements [Node] interface for [EnhancedForLoop] by calling [Visitor.VisitEnhancedForLoop].
+func (efl EnhancedForLoop) Accept(visitor Visitor) { visitor.VisitEnhancedForLoop(efl) }
+
+// Implements [Node] interface for [ExpressionStatement] by calling [Visitor.VisitExpressionStatement].
+func (xs ExpressionStatement) Accept(visitor Visitor) { visitor.VisitExpressionStatement(xs) }
+
+// Implements [Node] interface for [MemberSelect] by calling [Visitor.VisitMemberSelect].
+func (ms MemberSelect) Accept(visitor Visitor) { visitor.VisitMemberSelect(ms) }
+
+// Implements [Node] interface for [InvokeMemberReference] by calling [Visitor.VisitMemberReference].
+func (imr InvokeMemberReference) Accept(visitor Visitor) { visitor.VisitMemberReference(imr) }
+
+// Implements [Node] interface for [NewMemberReference] by calling [Visitor.VisitMemberReference].
+func (nmr NewMemberReference) Accept(visitor Visitor) { visitor.VisitMemberReference(nmr) }
+
+// Implements [Node] interface for [ForLoop] by calling [Visitor.VisitForLoop].
+func (fl ForLoop) Accept(visitor Visitor) { visitor.VisitForLoop(fl) }
+
+// Implements [Node] interface for [Identifier] by calling [Visitor.VisitIdentifier].
+func (i Identifier) Accept(visitor Visitor) { visitor.VisitIdentifier(i) }
+
+// Implements [Node] interface for [If] by calling [Visitor.VisitIf].
+func (i If) Accept(visitor Visitor) { visitor.VisitIf(i) }
+
+// Implements [Node] interface for [Import] by calling [Visitor.VisitImport].
+func (i Import) Accept(visitor Visitor) { visitor.VisitImport(i) }
+
+// Implements [Node] interface for [InstanceOf] by calling [Visitor.VisitInstanceOf].
+func (io InstanceOf) Accept(visitor Visitor) { visitor.VisitInstanceOf(io) }
+
+// Implements [Node] interface for [LabeledStatement] by calling [Visitor.VisitLabeledStatement].
+func (ls LabeledStatement) Accept(visitor Visitor) { visitor.VisitLabeledStatement(ls) }
+
+// Implements [Node] interface for [Method] by calling [Visitor.VisitMethod].
+func (m Method) Accept(visitor Visitor) { visitor.VisitMethod(m) }
+
+// Implements [Node] interface for [MethodInvocation] by calling [Visitor.VisitMethodInvocation].
+func (mi MethodInvocation) Accept(visitor Visitor) { visitor.VisitMethodInvocation(mi) }
+
+// Implements [Node] interface for [Modifiers] by calling [Visitor.VisitModifiers].
+func (m Modifiers) Accept(visitor Visitor) { visitor.VisitModifiers(m) }
+
+// Implements [Node] interface for [NewArray] by calling [Visitor.VisitNewArray].
+func (na NewArray) Accept(visitor Visitor) { visitor.VisitNewArray(na) }
+
+// Implements [Node] interface for [NewClass] by calling [Visitor.VisitNewClass].
+func (nc NewClass) Accept(visitor Visitor) { visitor.VisitNewClass(nc) }
+
+// Implements [Node] interface for [ExpressionLambdaExpression] by calling [Visitor.VisitLambdaExpression].
+func (xlx ExpressionLambdaExpression) Accept(visitor Visitor) { visitor.VisitLambdaExpression(xlx) }
+
+// Implements [Node] interface for [StatementLambdaExpression] by calling [Visitor.VisitLambdaExpression].
+func (slx StatementLambdaExpression) Accept(visitor Visitor) { visitor.VisitLambdaExpression(slx) }
+
+// Implements [Node] interface for [Package] by calling [Visitor.VisitPackage].
+func (p Package) Accept(visitor Visitor) { visitor.VisitPackage(p) }
+
+// Implements [Node] interface for [Parenthesized] by calling [Visitor.VisitParenthesized].
+func (p Parenthesized) Accept(visitor Visitor) { visitor.VisitParenthesized(p) }
+
+// Implements [Node] interface for [BindingPattern] by calling [Visitor.VisitBindingPattern].
+func (bp BindingPattern) Accept(visitor Visitor) { visitor.VisitBindingPattern(bp) }
+
+// Implements [Node] interface for [GuardedPattern] by calling [Visitor.VisitGuardedPattern].
+func (gp GuardedPattern) Accept(visitor Visitor) { visitor.VisitGuardedPattern(gp) }
+
+// Implements [Node] interface for [ParenthesizedPattern] by calling [Visitor.VisitParenthesizedPattern].
+func (pp ParenthesizedPattern) Accept(visitor Visitor) { visitor.VisitParenthesizedPattern(pp) }
+
+// Implements [Node] interface for [DefaultCaseLabel] by calling [Visitor.VisitDefaultCaseLabel].
+func (dcl DefaultCaseLabel) Accept(visitor Visitor) { visitor.VisitDefaultCaseLabel(dcl) }
+
+// Implements [Node] interface for [RecordPattern] by calling [Visitor.VisitRecordPattern].
+func (rp RecordPattern) Accept(visitor Visitor) { visitor.VisitRecordPattern(rp) }
+
+// Implements [Node] interface for [ConstantCaseLabel] by calling [Visitor.VisitConstantCaseLabel].
+func (ccl ConstantCaseLabel) Accept(visitor Visitor) { visitor.VisitConstantCaseLabel(ccl) }
+
+// Implements [Node] interface for [PatternCaseLabel] by calling [Visitor.VisitPatternCaseLabel].
+func (pcl PatternCaseLabel) Accept(visitor Visitor) { visitor.VisitPatternCaseLabel(pcl) }
+
+// Implements [Node] interface for [PrimitiveType] by calling [Visitor.VisitPrimitiveType].
+func (pt PrimitiveType) Accept(visitor Visitor) { visitor.VisitPrimitiveType(pt) }
+
+// Implements [Node] interface for [Return] by calling [Visitor.VisitReturn].
+func (r Return) Accept(visitor Visitor) { visitor.VisitReturn(r) }
+
+// Implements [Node] interface for [EmptyStatement] by calling [Visitor.VisitEmptyStatement].
+func (es EmptyStatement) Accept(visitor Visitor) { visitor.VisitEmptyStatement(es) }
+
+// Implements [Node] interface for [EmptyExpression] by calling [Visitor.VisitEmptyExpression].
+func (ee EmptyExpression) Accept(visitor Visitor) { visitor.VisitEmptyExpression(ee) }
+
+// Implements [Node] interface for [Switch] by calling [Visitor.VisitSwitch].
+func (s Switch) Accept(visitor Visitor) { visitor.VisitSwitch(s) }
+
+// Implements [Node] interface for [SwitchExpression] by calling [Visitor.VisitSwitchExpression].
+func (sx SwitchExpression) Accept(visitor Visitor) { visitor.VisitSwitchExpression(sx) }
+
+// Implements [Node] interface for [Synchronized] by calling [Visitor.VisitSynchronized].
+func (s Synchronized) Accept(visitor Visitor) { visitor.VisitSynchronized(s) }
+
+// Implements [Node] interface for [Throw] by calling [Visitor.VisitThrow].
+func (t Throw) Accept(visitor Visitor) { visitor.VisitThrow(t) }
+
+// Implements [Node] interface for [Try] by calling [Visitor.VisitTry].
+func (t Try) Accept(visitor Visitor) { visitor.VisitTry(t) }
+
+// Implements [Node] interface for [ParameterizedType] by calling [Visitor.VisitParameterizedType].
+func (pt ParameterizedType) Accept(visitor Visitor) { visitor.VisitParameterizedType(pt) }
+
+// Implements [Node] interface for [UnionType] by calling [Visitor.VisitUnionType].
+func (ut UnionType) Accept(visitor Visitor) { visitor.VisitUnionType(ut) }
+
+// Implements [Node] interface for [IntersectionType] by calling [Visitor.VisitIntersectionType].
+func (it IntersectionType) Accept(visitor Visitor) { visitor.VisitIntersectionType(it) }
+
+// Implements [Node] interface for [TypeCast] by calling [Visitor.VisitTypeCast].
+func (tc TypeCast) Accept(visitor Visitor) { visitor.VisitTypeCast(tc) }
+
+// Implements [Node] interface for [TypeParameter] by calling [Visitor.VisitTypeParameter].
+func (tp TypeParameter) Accept(visitor Visitor) { visitor.VisitTypeParameter(tp) }
+
+// Implements [Node] interface for [Variable] by calling [Visitor.VisitVariable].
+func (v Variable) Accept(visitor Visitor) { visitor.VisitVariable(v) }
+
+// Implements [Node] interface for [WhileLoop] by calling [Visitor.VisitWhileLoop].
+func (wl WhileLoop) Accept(visitor Visitor) { visitor.VisitWhileLoop(wl) }
+
+// Implements [Node] interface for [PostfixIncrement] by calling [Visitor.VisitUnary].
+func (pi PostfixIncrement) Accept(visitor Visitor) { visitor.VisitUnary(pi) }
+
+// Implements [Node] interface for [PostfixDecrement] by calling [Visitor.VisitUnary].
+func (pd PostfixDecrement) Accept(visitor Visitor) { visitor.VisitUnary(pd) }
+
+// Implements [Node] interface for [PrefixIncrement] by calling [Visitor.VisitUnary].
+func (pi PrefixIncrement) Accept(visitor Visitor) { visitor.VisitUnary(pi) }
+
+// Implements [Node] interface for [PrefixDecrement] by calling [Visitor.VisitUnary].
+func (pd PrefixDecrement) Accept(visitor Visitor) { visitor.VisitUnary(pd) }
+
+// Implements [Node] interface for [UnaryPlus] by calling [Visitor.VisitUnary].
+func (up UnaryPlus) Accept(visitor Visitor) { visitor.VisitUnary(up) }
+
+// Implements [Node] interface for [UnaryMinus] by calling [Visitor.VisitUnary].
+func (um UnaryMinus) Accept(visitor Visitor) { visitor.VisitUnary(um) }
+
+// Implements [Node] interface for [BitwiseComplement] by calling [Visitor.VisitUnary].
+func (bc BitwiseComplement) Accept(visitor Visitor) { visitor.VisitUnary(bc) }
+
+// Implements [Node] interface for [LogicalComplement] by calling [Visitor.VisitUnary].
+func (lc LogicalComplement) Accept(visitor Visitor) { visitor.VisitUnary(lc) }
+
+// Implements [Node] interface for [Multiply] by calling [Visitor.VisitBinary].
+func (m Multiply) Accept(visitor Visitor) { visitor.VisitBinary(m) }
+
+// Implements [Node] interface for [Divide] by calling [Visitor.VisitBinary].
+func (d Divide) Accept(visitor Visitor) { visitor.VisitBinary(d) }
+
+// Implements [Node] interface for [Remainder] by calling [Visitor.VisitBinary].
+func (r Remainder) Accept(visitor Visitor) { visitor.VisitBinary(r) }
+
+// Implements [Node] interface for [Plus] by calling [Visitor.VisitBinary].
+func (p Plus) Accept(visitor Visitor) { visitor.VisitBinary(p) }
+
+// Implements [Node] interface for [Minus] by calling [Visitor.VisitBinary].
+func (m Minus) Accept(visitor Visitor) { visitor.VisitBinary(m) }
+
+// Implements [Node] interface for [LeftShift] by calling [Visitor.VisitBinary].
+func (ls LeftShift) Accept(visitor Visitor) { visitor.VisitBinary(ls) }
+
+// Implements [Node] interface for [RightShift] by calling [Visitor.VisitBinary].
+func (rs RightShift) Accept(visitor Visitor) { visitor.VisitBinary(rs) }
+
+// Implements [Node] interface for [UnsignedRightShift] by calling [Visitor.VisitBinary].
+func (urs UnsignedRightShift) Accept(visitor Visitor) { visitor.VisitBinary(urs) }
+
+// Implements [Node] interface for [LessThan] by calling [Visitor.VisitBinary].
+func (lt LessThan) Accept(visitor Visitor) { visitor.VisitBinary(lt) }
+
+// Implements [Node] interface for [GreaterThan] by calling [Visitor.VisitBinary].
+func (gt GreaterThan) Accept(visitor Visitor) { visitor.VisitBinary(gt) }
+
+// Implements [Node] interface for [LessThanEqual] by calling [Visitor.VisitBinary].
+func (lte LessThanEqual) Accept(visitor Visitor) { visitor.VisitBinary(lte) }
+
+// Implements [Node] interface for [GreaterThanEqual] by calling [Visitor.VisitBinary].
+func (gte GreaterThanEqual) Accept(visitor Visitor) { visitor.VisitBinary(gte) }
+
+// Implements [Node] interface for [EqualTo] by calling [Visitor.VisitBinary].
+func (eq EqualTo) Accept(visitor Visitor) { visitor.VisitBinary(eq) }
+
+// Implements [Node] interface for [NotEqualTo] by calling [Visitor.VisitBinary].
+func (neq NotEqualTo) Accept(visitor Visitor) { visitor.VisitBinary(neq) }
+
+// Implements [Node] interface for [And] by calling [Visitor.VisitBinary].
+func (a And) Accept(visitor Visitor) { visitor.VisitBinary(a) }
+
+// Implements [Node] interface for [Xor] by calling [Visitor.VisitBinary].
+func (x Xor) Accept(visitor Visitor) { visitor.VisitBinary(x) }
+
+// Implements [Node] interface for [Or] by calling [Visitor.VisitBinary].
+func (or Or) Accept(visitor Visitor) { visitor.VisitBinary(or) }
+
+// Implements [Node] interface for [ConditionalAnd] by calling [Visitor.VisitBinary].
+func (ca ConditionalAnd) Accept(visitor Visitor) { visitor.VisitBinary(ca) }
+
+// Implements [Node] interface for [ConditionalOr] by calling [Visitor.VisitBinary].
+func (or ConditionalOr) Accept(visitor Visitor) { visitor.VisitBinary(or) }
+
+// Implements [Node] interface for [MultiplyAssignment] by calling [Visitor.VisitCompoundAssignment].
+func (ma MultiplyAssignment) Accept(visitor Visitor) { visitor.VisitCompoundAssignment(ma) }
+
+// Implements [Node] interface for [DivideAssignment] by calling [Visitor.VisitCompoundAssignment].
+func (da DivideAssignment) Accept(visitor Visitor) { visitor.VisitCompoundAssignment(da) }
+
+// Implements [Node] interface for [RemainderAssignment] by calling [Visitor.VisitCompoundAssignment].
+func (ra RemainderAssignment) Accept(visitor Visitor) { visitor.VisitCompoundAssignment(ra) }
+
+// Implements [Node] interface for [PlusAssignment] by calling [Visitor.VisitCompoundAssignment].
+func (pa PlusAssignment) Accept(visitor Visitor) { visitor.VisitCompoundAssignment(pa) }
+
+// Implements [Node] interface for [MinusAssignment] by calling [Visitor.VisitCompoundAssignment].
+func (ma MinusAssignment) Accept(visitor Visitor) { visitor.VisitCompoundAssignment(ma) }
+
+// Implements [Node] interface for [LeftShiftAssignment] by calling [Visitor.VisitCompoundAssignment].
+func (lsa LeftShiftAssignment) Accept(visitor Visitor) { visitor.VisitCompoundAssignment(lsa) }
+
+// Implements [Node] interface for [RightShiftAssignment] by calling [Visitor.VisitCompoundAssignment].
+func (rsa RightShiftAssignment) Accept(visitor Visitor) { visitor.VisitCompoundAssignment(rsa) }
+
+// Implements [Node] interface for [UnsignedRightShiftAssignment] by calling [Visitor.VisitCompoundAssignment].
+func (ursa UnsignedRightShiftAssignment) Accept(visitor Visitor) {
+	visitor.VisitCompoundAssignment(ursa)
+}
+
+// Implements [Node] interface for [AndAssignment] by calling [Visitor.VisitCompoundAssignment].
+func (aa AndAssignment) Accept(visitor Visitor) { visitor.VisitCompoundAssignment(aa) }
+
+// Implements [Node] interface for [XorAssignment] by calling [Visitor.VisitCompoundAssignment].
+func (xa XorAssignment) Accept(visitor Visitor) { visitor.VisitCompoundAssignment(xa) }
+
+// Implements [Node] interface for [OrAssignment] by calling [Visitor.VisitCompoundAssignment].
+func (oa OrAssignment) Accept(visitor Visitor) { visitor.VisitCompoundAssignment(oa) }
+
+// Implements [Node] interface for [IntLiteral] by calling [Visitor.VisitLiteral].
+func (il IntLiteral) Accept(visitor Visitor) { visitor.VisitLiteral(il) }
+
+// Implements [Node] interface for [LongLiteral] by calling [Visitor.VisitLiteral].
+func (ll LongLiteral) Accept(visitor Visitor) { visitor.VisitLiteral(ll) }
+
+// Implements [Node] interface for [FloatLiteral] by calling [Visitor.VisitLiteral].
+func (fl FloatLiteral) Accept(visitor Visitor) { visitor.VisitLiteral(fl) }
+
+// Implements [Node] interface for [DoubleLiteral] by calling [Visitor.VisitLiteral].
+func (dl DoubleLiteral) Accept(visitor Visitor) { visitor.VisitLiteral(dl) }
+
+// Implements [Node] interface for [BooleanLiteral] by calling [Visitor.VisitLiteral].
+func (bl BooleanLiteral) Accept(visitor Visitor) { visitor.VisitLiteral(bl) }
+
+// Implements [Node] interface for [CharLiteral] by calling [Visitor.VisitLiteral].
+func (cl CharLiteral) Accept(visitor Visitor) { visitor.VisitLiteral(cl) }
+
+// Implements [Node] interface for [StringLiteral] by calling [Visitor.VisitLiteral].
+func (sl StringLiteral) Accept(visitor Visitor) { visitor.VisitLiteral(sl) }
+
+// Implements [Node] interface for [TextBlockLiteral] by calling [Visitor.VisitLiteral].
+func (tbl TextBlockLiteral) Accept(visitor Visitor) { visitor.VisitLiteral(tbl) }
+
+// Implements [Node] interface for [NullLiteral] by calling [Visitor.VisitLiteral].
+func (nl NullLiteral) Accept(visitor Visitor) { visitor.VisitLiteral(nl) }
+
+// Implements [Node] interface for [UnboundedWildcard] by calling [Visitor.VisitWildcard].
+func (uw UnboundedWildcard) Accept(visitor Visitor) { visitor.VisitWildcard(uw) }
+
+// Implements [Node] interface for [ExtendsWildcard] by calling [Visitor.VisitWildcard].
+func (xw ExtendsWildcard) Accept(visitor Visitor) { visitor.VisitWildcard(xw) }
+
+// Implements [Node] interface for [SuperWildcard] by calling [Visitor.VisitWildcard].
+func (sw SuperWildcard) Accept(visitor Visitor) { visitor.VisitWildcard(sw) }
+
+// Implements [Node] interface for [Erroneous] by calling [Visitor.VisitErroneous].
+func (e Erroneous) Accept(visitor Visitor) { visitor.VisitErroneous(e) }
+
+// Implements [Node] interface for [Interface] by calling [Visitor.VisitClass].
+func (i Interface) Accept(visitor Visitor) { visitor.VisitClass(i) }
+
+// Implements [Node] interface for [Enum] by calling [Visitor.VisitClass].
+func (e Enum) Accept(visitor Visitor) { visitor.VisitClass(e) }
+
+// Implements [Node] interface for [AnnotationType] by calling [Visitor.VisitClass].
+func (at AnnotationType) Accept(visitor Visitor) { visitor.VisitClass(at) }
+
+// Implements [Node] interface for [Module] by calling [Visitor.VisitModule].
+func (m Module) Accept(visitor Visitor) { visitor.VisitModule(m) }
+
+// Implements [Node] interface for [Exports] by calling [Visitor.VisitExports].
+func (x Exports) Accept(visitor Visitor) { visitor.VisitExports(x) }
+
+// Implements [Node] interface for [Opens] by calling [Visitor.VisitOpens].
+func (o Opens) Accept(visitor Visitor) { visitor.VisitOpens(o) }
+
+// Implements [Node] interface for [Provides] by calling [Visitor.VisitProvides].
+func (p Provides) Accept(visitor Visitor) { visitor.VisitProvides(p) }
+
+// Implements [Node] interface for [Record] by calling [Visitor.VisitClass].
+func (r Record) Accept(visitor Visitor) { visitor.VisitClass(r) }
+
+// Implements [Node] interface for [Requires] by calling [Visitor.VisitRequires].
+func (r Requires) Accept(visitor Visitor) { visitor.VisitRequires(r) }
+
+// Implements [Node] interface for [Uses] by calling [Visitor.VisitUses].
+func (u Uses) Accept(visitor Visitor) { visitor.VisitUses(u) }
+
+// Implements [Node] interface for [Yield] by calling [Visitor.VisitYield].
+func (y Yield) Accept(visitor Visitor) { visitor.VisitYield(y) }
+
+// Implements [Node] interface for [EnumConstant] by calling [Visitor.VisitEnumConstant].
+func (ec EnumConstant) Accept(visitor Visitor) { visitor.VisitEnumConstant(ec) }
+
+// Implements [Node] interface for [VariableDeclaration] by calling [Visitor.VisitVariableDeclaration].
+func (vd VariableDeclaration) Accept(visitor Visitor) { visitor.VisitVariableDeclaration(vd) }
+
+// Implements [Node] interface for [ClassLiteral] by calling [Visitor.VisitClassLiteral].
+func (cl ClassLiteral) Accept(visitor Visitor) { visitor.VisitClassLiteral(cl) }
+
+// Implements [Node] interface for [This] by calling [Visitor.VisitThis].
+func (t This) Accept(visitor Visitor) { visitor.VisitThis(t) }
+
+// Implements [Node] interface for [Super] by calling [Visitor.VisitSuper].
+func (s Super) Accept(visitor Visitor) { visitor.VisitSuper(s) }
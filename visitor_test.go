@@ -0,0 +1,52 @@
+package javast_test
+
+import (
+	"testing"
+
+	"github.com/kapavkin/javast"
+)
+
+type NameVisitor struct {
+	javast.BaseVisitor
+	names []string
+}
+
+func (nv *NameVisitor) VisitBinary(node javast.BinaryNode) {
+	nv.names = append(nv.names, "binary")
+}
+
+func (nv *NameVisitor) VisitClass(node javast.ClassNode) {
+	nv.names = append(nv.names, node.GetSimpleName())
+}
+
+func TestNode_Accept(t *testing.T) {
+	t.Parallel()
+	nv := &NameVisitor{}
+	nv.Default = func(node javast.Node) {
+		nv.names = append(nv.names, "default")
+	}
+	nodes := []javast.Node{
+		javast.Plus{
+			LeftOperand:  javast.Identifier{Name: "a"},
+			RightOperand: javast.Identifier{Name: "b"},
+		},
+		javast.Class{SimpleName: "Main"},
+		javast.Interface{SimpleName: "Runnable"},
+		&javast.Record{SimpleName: "Point"},
+		javast.Identifier{Name: "c"},
+	}
+	for _, node := range nodes {
+		node.Accept(nv)
+	}
+	got := nv.names
+	want := []string{"binary", "Main", "Runnable", "Point", "default"}
+	if len(got) != len(want) {
+		t.Fatalf("nv.names = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("nv.names = %v, want %v", got, want)
+			break
+		}
+	}
+}
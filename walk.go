@@ -0,0 +1,456 @@
+package javast
+
+import "reflect"
+
+// Traverses the tree rooted at node in depth-first order.
+// The function fn is called for each node before its children.
+// If fn returns false, the children of the node are skipped.
+//
+// For example, all method invocations of a compilation unit are found by:
+//
+//	Walk(cu, func(node Node) bool {
+//		if mi, ok := node.(MethodInvocationNode); ok {
+//			invocations = append(invocations, mi)
+//		}
+//		return true
+//	})
+func Walk(node Node, fn func(Node) bool) {
+	WalkPrePost(node, fn, nil)
+}
+
+// Traverses the tree rooted at node in depth-first order.
+// The function pre is called for each node before its children, and post after them.
+// If pre returns false, neither the children of the node nor post are visited for it.
+// Either function may be nil.
+func WalkPrePost(node Node, pre func(Node) bool, post func(Node)) {
+	if node == nil {
+		return
+	}
+	if pre != nil && !pre(node) {
+		return
+	}
+	for _, child := range Children(node) {
+		WalkPrePost(child, pre, post)
+	}
+	if post != nil {
+		post(node)
+	}
+}
+
+// Returns the non-nil children of the node in source order.
+func Children(node Node) []Node {
+	cc := &childCollector{}
+	node.Accept(cc)
+	return cc.children
+}
+
+// A childCollector collects the children of the visited node.
+type childCollector struct {
+	children []Node
+}
+
+func (cc *childCollector) add(nodes ...Node) {
+	for _, node := range nodes {
+		if node != nil {
+			cc.children = append(cc.children, node)
+		}
+	}
+}
+
+func addAll[T Node](cc *childCollector, nodes []T) {
+	for _, node := range nodes {
+		cc.add(node)
+	}
+}
+
+func (cc *childCollector) VisitArrayType(node ArrayTypeNode) {
+	cc.add(node.GetType())
+}
+
+func (cc *childCollector) VisitDefaultCaseLabel(node DefaultCaseLabelNode) {}
+
+func (cc *childCollector) VisitAnnotatedType(node AnnotatedTypeNode) {
+	addAll(cc, node.GetAnnotations())
+	cc.add(node.GetUnderlyingType())
+}
+
+func (cc *childCollector) VisitAnnotation(node AnnotationNode) {
+	cc.add(node.GetAnnotationType())
+	addAll(cc, node.GetArguments())
+}
+
+func (cc *childCollector) VisitArrayAccess(node ArrayAccessNode) {
+	cc.add(node.GetExpression(), node.GetIndex())
+}
+
+func (cc *childCollector) VisitAssignment(node AssignmentNode) {
+	cc.add(node.GetVariable(), node.GetExpression())
+}
+
+func (cc *childCollector) VisitBinary(node BinaryNode) {
+	cc.add(node.GetLeftOperand(), node.GetRightOperand())
+}
+
+func (cc *childCollector) VisitCompoundAssignment(node CompoundAssignmentNode) {
+	cc.add(node.GetVariable(), node.GetExpression())
+}
+
+func (cc *childCollector) VisitConditionalExpression(node ConditionalExpressionNode) {
+	cc.add(node.GetCondition(), node.GetTrueExpression(), node.GetFalseExpression())
+}
+
+func (cc *childCollector) VisitErroneous(node ErroneousNode) {
+	addAll(cc, node.GetErrorNodes())
+}
+
+func (cc *childCollector) VisitIdentifier(node IdentifierNode) {}
+
+func (cc *childCollector) VisitInstanceOf(node InstanceOfNode) {
+	cc.add(node.GetExpression())
+	if node.GetPattern() != nil {
+		cc.add(node.GetPattern())
+	} else {
+		cc.add(node.GetType())
+	}
+}
+
+func (cc *childCollector) VisitLambdaExpression(node LambdaExpressionNode) {
+	addAll(cc, node.GetParameters())
+	cc.add(node.GetBody())
+}
+
+func (cc *childCollector) VisitLiteral(node LiteralNode) {}
+
+func (cc *childCollector) VisitMemberReference(node MemberReferenceNode) {
+	cc.add(node.GetQualifierExpression())
+	addAll(cc, node.GetTypeArguments())
+}
+
+func (cc *childCollector) VisitMemberSelect(node MemberSelectNode) {
+	cc.add(node.GetExpression())
+}
+
+func (cc *childCollector) VisitMethodInvocation(node MethodInvocationNode) {
+	addAll(cc, node.GetTypeArguments())
+	cc.add(node.GetMethodSelect())
+	addAll(cc, node.GetArguments())
+}
+
+func (cc *childCollector) VisitNewArray(node NewArrayNode) {
+	addAll(cc, node.GetAnnotations())
+	cc.add(node.GetType())
+	dimAnnotations := node.GetDimAnnotations()
+	for i, dimension := range node.GetDimensions() {
+		if i < len(dimAnnotations) {
+			addAll(cc, dimAnnotations[i])
+		}
+		cc.add(dimension)
+	}
+	for i := len(node.GetDimensions()); i < len(dimAnnotations); i++ {
+		addAll(cc, dimAnnotations[i])
+	}
+	addAll(cc, node.GetInitializers())
+}
+
+func (cc *childCollector) VisitNewClass(node NewClassNode) {
+	cc.add(node.GetEnclosingExpression())
+	addAll(cc, node.GetTypeArguments())
+	cc.add(node.GetIdentifier())
+	addAll(cc, node.GetArguments())
+	cc.add(node.GetClassBody())
+}
+
+func (cc *childCollector) VisitParenthesized(node ParenthesizedNode) {
+	cc.add(node.GetExpression())
+}
+
+func (cc *childCollector) VisitSwitchExpression(node SwitchExpressionNode) {
+	cc.add(node.GetExpression())
+	addAll(cc, node.GetCases())
+}
+
+func (cc *childCollector) VisitTypeCast(node TypeCastNode) {
+	cc.add(node.GetType(), node.GetExpression())
+}
+
+func (cc *childCollector) VisitUnary(node UnaryNode) {
+	cc.add(node.GetExpression())
+}
+
+func (cc *childCollector) VisitBindingPattern(node BindingPatternNode) {
+	cc.add(node.GetVariable())
+}
+
+func (cc *childCollector) VisitGuardedPattern(node GuardedPatternNode) {
+	cc.add(node.GetPattern(), node.GetExpression())
+}
+
+func (cc *childCollector) VisitParenthesizedPattern(node ParenthesizedPatternNode) {
+	cc.add(node.GetPattern())
+}
+
+// The expressions of a case are not collected, since they belong to its labels and its guard.
+func (cc *childCollector) VisitCase(node CaseNode) {
+	addAll(cc, node.GetLabels())
+	cc.add(node.GetGuard())
+	addAll(cc, node.GetStatements())
+	cc.add(node.GetBody())
+}
+
+func (cc *childCollector) VisitCatch(node CatchNode) {
+	cc.add(node.GetParameter(), node.GetBlock())
+}
+
+func (cc *childCollector) VisitCompilationUnit(node CompilationUnitNode) {
+	cc.add(node.GetPackage())
+	addAll(cc, node.GetImports())
+	cc.add(node.GetModule())
+	addAll(cc, node.GetTypeDecls())
+}
+
+func (cc *childCollector) VisitExports(node ExportsNode) {
+	cc.add(node.GetPackageName())
+	addAll(cc, node.GetModuleNames())
+}
+
+func (cc *childCollector) VisitOpens(node OpensNode) {
+	cc.add(node.GetPackageName())
+	addAll(cc, node.GetModuleNames())
+}
+
+func (cc *childCollector) VisitProvides(node ProvidesNode) {
+	cc.add(node.GetServiceName())
+	addAll(cc, node.GetImplementationNames())
+}
+
+func (cc *childCollector) VisitRequires(node RequiresNode) {
+	cc.add(node.GetModuleName())
+}
+
+func (cc *childCollector) VisitUses(node UsesNode) {
+	cc.add(node.GetServiceName())
+}
+
+func (cc *childCollector) VisitImport(node ImportNode) {
+	cc.add(node.GetQualifiedIdentifier())
+}
+
+func (cc *childCollector) VisitIntersectionType(node IntersectionTypeNode) {
+	addAll(cc, node.GetBounds())
+}
+
+func (cc *childCollector) VisitMethod(node MethodNode) {
+	cc.add(node.GetModifiers())
+	addAll(cc, node.GetTypeParameters())
+	cc.add(node.GetReturnType(), node.GetReceiverParameter())
+	addAll(cc, node.GetParameters())
+	addAll(cc, node.GetThrows())
+	cc.add(node.GetBody(), node.GetDefaultValue())
+}
+
+func (cc *childCollector) VisitModifiers(node ModifiersNode) {
+	addAll(cc, node.GetAnnotations())
+}
+
+func (cc *childCollector) VisitModule(node ModuleNode) {
+	addAll(cc, node.GetAnnotations())
+	cc.add(node.GetName())
+	addAll(cc, node.GetDirectives())
+}
+
+func (cc *childCollector) VisitPackage(node PackageNode) {
+	addAll(cc, node.GetAnnotations())
+	cc.add(node.GetPackageName())
+}
+
+func (cc *childCollector) VisitParameterizedType(node ParameterizedTypeNode) {
+	cc.add(node.GetType())
+	addAll(cc, node.GetTypeArguments())
+}
+
+func (cc *childCollector) VisitPrimitiveType(node PrimitiveTypeNode) {}
+
+func (cc *childCollector) VisitAssert(node AssertNode) {
+	cc.add(node.GetCondition(), node.GetDetail())
+}
+
+func (cc *childCollector) VisitBlock(node BlockNode) {
+	addAll(cc, node.GetStatements())
+}
+
+func (cc *childCollector) VisitBreak(node BreakNode) {}
+
+func (cc *childCollector) VisitClass(node ClassNode) {
+	cc.add(node.GetModifiers())
+	addAll(cc, node.GetTypeParameters())
+	cc.add(node.GetExtendsClause())
+	addAll(cc, node.GetImplementsClause())
+	addAll(cc, node.GetPermitsClause())
+	addAll(cc, node.GetRecordComponents())
+	addAll(cc, node.GetEnumConstants())
+	addAll(cc, node.GetMembers())
+}
+
+func (cc *childCollector) VisitContinue(node ContinueNode) {}
+
+func (cc *childCollector) VisitDoWhileLoop(node DoWhileLoopNode) {
+	cc.add(node.GetStatement(), node.GetCondition())
+}
+
+func (cc *childCollector) VisitEmptyStatement(node EmptyStatementNode) {}
+
+func (cc *childCollector) VisitEmptyExpression(node EmptyExpressionNode) {}
+
+func (cc *childCollector) VisitEnhancedForLoop(node EnhancedForLoopNode) {
+	cc.add(node.GetVariable(), node.GetExpression(), node.GetStatement())
+}
+
+func (cc *childCollector) VisitExpressionStatement(node ExpressionStatementNode) {
+	cc.add(node.GetExpression())
+}
+
+func (cc *childCollector) VisitForLoop(node ForLoopNode) {
+	addAll(cc, node.GetInitializer())
+	addAll(cc, node.GetExpressions())
+	cc.add(node.GetCondition())
+	addAll(cc, node.GetUpdate())
+	cc.add(node.GetStatement())
+}
+
+func (cc *childCollector) VisitIf(node IfNode) {
+	cc.add(node.GetCondition(), node.GetThenStatement(), node.GetElseStatement())
+}
+
+func (cc *childCollector) VisitLabeledStatement(node LabeledStatementNode) {
+	cc.add(node.GetStatement())
+}
+
+func (cc *childCollector) VisitReturn(node ReturnNode) {
+	cc.add(node.GetExpression())
+}
+
+func (cc *childCollector) VisitSwitch(node SwitchNode) {
+	cc.add(node.GetExpression())
+	addAll(cc, node.GetCases())
+}
+
+func (cc *childCollector) VisitSynchronized(node SynchronizedNode) {
+	cc.add(node.GetExpression(), node.GetBlock())
+}
+
+func (cc *childCollector) VisitThrow(node ThrowNode) {
+	cc.add(node.GetExpression())
+}
+
+func (cc *childCollector) VisitTry(node TryNode) {
+	addAll(cc, node.GetResources())
+	cc.add(node.GetBlock())
+	addAll(cc, node.GetCatches())
+	cc.add(node.GetFinallyBlock())
+}
+
+func (cc *childCollector) VisitVariable(node VariableNode) {
+	cc.add(node.GetModifiers(), node.GetType(), node.GetNameExpression(), node.GetInitializer())
+}
+
+func (cc *childCollector) VisitWhileLoop(node WhileLoopNode) {
+	cc.add(node.GetCondition(), node.GetStatement())
+}
+
+func (cc *childCollector) VisitYield(node YieldNode) {
+	cc.add(node.GetValue())
+}
+
+func (cc *childCollector) VisitTypeParameter(node TypeParameterNode) {
+	addAll(cc, node.GetAnnotations())
+	addAll(cc, node.GetBounds())
+}
+
+func (cc *childCollector) VisitUnionType(node UnionTypeNode) {
+	addAll(cc, node.GetTypeAlternatives())
+}
+
+func (cc *childCollector) VisitWildcard(node WildcardNode) {
+	cc.add(node.GetBound())
+}
+
+func (cc *childCollector) VisitRecordPattern(node RecordPatternNode) {
+	cc.add(node.GetDeconstructor())
+	addAll(cc, node.GetNestedPatterns())
+}
+
+func (cc *childCollector) VisitConstantCaseLabel(node ConstantCaseLabelNode) {
+	cc.add(node.GetConstantExpression())
+}
+
+func (cc *childCollector) VisitPatternCaseLabel(node PatternCaseLabelNode) {
+	cc.add(node.GetPattern())
+}
+
+func (cc *childCollector) VisitVariableDeclaration(node VariableDeclarationNode) {
+	addAll(cc, node.GetVariables())
+}
+
+func (cc *childCollector) VisitClassLiteral(node ClassLiteralNode) {
+	cc.add(node.GetType())
+}
+
+func (cc *childCollector) VisitThis(node ThisNode) {
+	cc.add(node.GetQualifier())
+}
+
+func (cc *childCollector) VisitSuper(node SuperNode) {
+	cc.add(node.GetQualifier())
+}
+
+func (cc *childCollector) VisitEnumConstant(node EnumConstantNode) {
+	addAll(cc, node.GetAnnotations())
+	addAll(cc, node.GetArguments())
+	cc.add(node.GetClassBody())
+}
+
+// Returns a copy of the tree rooted at node, in which the nodes are replaced by the function fn.
+// The function fn is called for each node before its children, and returns the replacement of the node
+// and whether the children of the replacement are rewritten. The original tree is not modified.
+func rewrite(node Node, fn func(Node) (Node, bool)) Node {
+	if node == nil {
+		return nil
+	}
+	node, descend := fn(node)
+	v := reflect.ValueOf(node)
+	if !descend || v.Kind() != reflect.Struct {
+		return node
+	}
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	rewriteValue(c, fn)
+	return c.Interface().(Node)
+}
+
+// Rewrites the nodes held by the settable value, which is a node field, a slice of them or a struct.
+func rewriteValue(v reflect.Value, fn func(Node) (Node, bool)) {
+	switch v.Kind() {
+	case reflect.Interface:
+		if node, ok := v.Interface().(Node); ok && !v.IsNil() {
+			if r := reflect.ValueOf(rewrite(node, fn)); r.IsValid() && r.Type().AssignableTo(v.Type()) {
+				v.Set(r)
+			}
+		}
+	case reflect.Slice:
+		if v.Len() == 0 {
+			return
+		}
+		s := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(s, v)
+		for i := 0; i < s.Len(); i++ {
+			rewriteValue(s.Index(i), fn)
+		}
+		v.Set(s)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				rewriteValue(v.Field(i), fn)
+			}
+		}
+	}
+}
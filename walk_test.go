@@ -0,0 +1,101 @@
+package javast_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kapavkin/javast"
+)
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+	src := `class Main {
+    static { init(); }
+    int[] sizes = new int[size()];
+    void run() {
+        try (var in = open()) { read(in); } catch (IOException e) { log(e); }
+        Runnable r = () -> notify();
+    }
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	javast.Walk(cu, func(node javast.Node) bool {
+		if mi, ok := node.(javast.MethodInvocationNode); ok {
+			names = append(names, mi.GetMethodSelect().(javast.IdentifierNode).GetName())
+		}
+		return true
+	})
+	got := strings.Join(names, " ")
+	want := "init size open read log notify"
+	if got != want {
+		t.Errorf("names = %s, want %s", got, want)
+	}
+}
+
+func TestWalk_Prune(t *testing.T) {
+	t.Parallel()
+	src := `class Main {
+    void run() { first(); }
+    class Inner { void run() { second(); } }
+}
+`
+	cu, err := javast.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	javast.Walk(cu, func(node javast.Node) bool {
+		if mi, ok := node.(javast.MethodInvocationNode); ok {
+			names = append(names, mi.GetMethodSelect().(javast.IdentifierNode).GetName())
+		}
+		if c, ok := node.(javast.ClassNode); ok && c.GetSimpleName() == "Inner" {
+			return false
+		}
+		return true
+	})
+	got := strings.Join(names, " ")
+	want := "first"
+	if got != want {
+		t.Errorf("names = %s, want %s", got, want)
+	}
+}
+
+func TestWalkPrePost(t *testing.T) {
+	t.Parallel()
+	node := javast.Plus{
+		LeftOperand: javast.Multiply{
+			LeftOperand:  javast.Identifier{Name: "a"},
+			RightOperand: javast.Identifier{Name: "b"},
+		},
+		RightOperand: &javast.Identifier{Name: "c"},
+	}
+	var order []string
+	name := func(node javast.Node) string {
+		switch node.GetKind() {
+		case javast.PLUS:
+			return "+"
+		case javast.MULTIPLY:
+			return "*"
+		}
+		return node.(javast.IdentifierNode).GetName()
+	}
+	javast.WalkPrePost(
+		node,
+		func(node javast.Node) bool {
+			order = append(order, "pre "+name(node))
+			return node.GetKind() != javast.MULTIPLY
+		},
+		func(node javast.Node) {
+			order = append(order, "post "+name(node))
+		},
+	)
+	got := strings.Join(order, ", ")
+	want := "pre +, pre *, pre c, post c, post +"
+	if got != want {
+		t.Errorf("order = %s, want %s", got, want)
+	}
+}
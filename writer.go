@@ -3,7 +3,8 @@ package javast
 import (
 	"fmt"
 	"io"
-	"strings"
+
+	"github.com/kapavkin/javast/lexer"
 )
 
 var modifiers = [...]string{
@@ -27,17 +28,41 @@ type WriterFunc func([]byte) (int, error)
 
 func (f WriterFunc) Write(p []byte) (int, error) { return f(p) }
 
+// A NodeWriter is a writer which lays out tokens by the nodes they belong to.
+// Nodes write their child nodes between [NodeWriter.BeginNode] and [NodeWriter.EndNode] if the writer implements it,
+// so that each token is written in the context of the innermost node it belongs to.
+type NodeWriter interface {
+	io.Writer
+	BeginNode(node Node) // Called before the tokens of a node are written.
+	EndNode(node Node)   // Called after the tokens of a node are written.
+}
+
+// Writes a child node, between [NodeWriter.BeginNode] and [NodeWriter.EndNode] if the writer implements it.
+func writeNode(w io.Writer, node Node) (n int64, err error) {
+	nw, ok := w.(NodeWriter)
+	if ok {
+		nw.BeginNode(node)
+	}
+	if n, err = node.WriteTo(w); err != nil {
+		return
+	}
+	if ok {
+		nw.EndNode(node)
+	}
+	return
+}
+
 // Implements [io.WriterTo] interface for [AnnotatedType].
 func (at AnnotatedType) WriteTo(w io.Writer) (n int64, err error) {
 	for _, annotation := range at.Annotations {
-		if an, aerr := annotation.WriteTo(w); aerr != nil {
+		if an, aerr := writeNode(w, annotation); aerr != nil {
 			err = aerr
 			return
 		} else {
 			n += an
 		}
 	}
-	if utn, uterr := at.UnderlyingType.WriteTo(w); uterr != nil {
+	if utn, uterr := writeNode(w, at.UnderlyingType); uterr != nil {
 		err = uterr
 		return
 	} else {
@@ -54,7 +79,7 @@ func (a Annotation) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(an)
 	}
-	if atn, aterr := a.AnnotationType.WriteTo(w); aterr != nil {
+	if atn, aterr := writeNode(w, a.AnnotationType); aterr != nil {
 		err = aterr
 		return
 	} else {
@@ -69,8 +94,16 @@ func (a Annotation) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(on)
 		}
 	}
-	for _, argument := range a.Arguments {
-		if an, aerr := argument.WriteTo(w); aerr != nil {
+	for i, argument := range a.Arguments {
+		if i > 0 {
+			if cn, cerr := w.Write([]byte(`,`)); cerr != nil {
+				err = cerr
+				return
+			} else {
+				n += int64(cn)
+			}
+		}
+		if an, aerr := writeNode(w, argument); aerr != nil {
 			err = aerr
 			return
 		} else {
@@ -96,7 +129,7 @@ func (ta TypeAnnotation) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(an)
 	}
-	if atn, aterr := ta.AnnotationType.WriteTo(w); aterr != nil {
+	if atn, aterr := writeNode(w, ta.AnnotationType); aterr != nil {
 		err = aterr
 		return
 	} else {
@@ -111,8 +144,16 @@ func (ta TypeAnnotation) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(on)
 		}
 	}
-	for _, argument := range ta.Arguments {
-		if an, aerr := argument.WriteTo(w); aerr != nil {
+	for i, argument := range ta.Arguments {
+		if i > 0 {
+			if cn, cerr := w.Write([]byte(`,`)); cerr != nil {
+				err = cerr
+				return
+			} else {
+				n += int64(cn)
+			}
+		}
+		if an, aerr := writeNode(w, argument); aerr != nil {
 			err = aerr
 			return
 		} else {
@@ -132,7 +173,7 @@ func (ta TypeAnnotation) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [ArrayAccess].
 func (aa ArrayAccess) WriteTo(w io.Writer) (n int64, err error) {
-	if xn, xerr := aa.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeOperand(w, aa.Expression, primaryPrecedence); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -144,7 +185,7 @@ func (aa ArrayAccess) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(on)
 	}
-	if in, ierr := aa.Index.WriteTo(w); ierr != nil {
+	if in, ierr := writeNode(w, aa.Index); ierr != nil {
 		err = ierr
 		return
 	} else {
@@ -161,7 +202,7 @@ func (aa ArrayAccess) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [ArrayType].
 func (at ArrayType) WriteTo(w io.Writer) (n int64, err error) {
-	if tn, terr := at.Type.WriteTo(w); terr != nil {
+	if tn, terr := writeNode(w, at.Type); terr != nil {
 		err = terr
 		return
 	} else {
@@ -178,13 +219,19 @@ func (at ArrayType) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [Assert].
 func (a Assert) WriteTo(w io.Writer) (n int64, err error) {
+	if ln, lerr := a.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
 	if an, aerr := w.Write([]byte(`assert`)); aerr != nil {
 		err = aerr
 		return
 	} else {
 		n += int64(an)
 	}
-	if cn, cerr := a.Condition.WriteTo(w); cerr != nil {
+	if cn, cerr := writeNode(w, a.Condition); cerr != nil {
 		err = cerr
 		return
 	} else {
@@ -197,7 +244,7 @@ func (a Assert) WriteTo(w io.Writer) (n int64, err error) {
 		} else {
 			n += int64(cn)
 		}
-		if dn, derr := a.Detail.WriteTo(w); derr != nil {
+		if dn, derr := writeNode(w, a.Detail); derr != nil {
 			err = derr
 			return
 		} else {
@@ -210,12 +257,18 @@ func (a Assert) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(sn)
 	}
+	if tn, terr := a.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
 // Implements [io.WriterTo] interface for [Assignment].
 func (a Assignment) WriteTo(w io.Writer) (n int64, err error) {
-	if vn, verr := a.Variable.WriteTo(w); verr != nil {
+	if vn, verr := writeNode(w, a.Variable); verr != nil {
 		err = verr
 		return
 	} else {
@@ -227,7 +280,7 @@ func (a Assignment) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(en)
 	}
-	if xn, xerr := a.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeNode(w, a.Expression); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -238,6 +291,12 @@ func (a Assignment) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [Block].
 func (b Block) WriteTo(w io.Writer) (n int64, err error) {
+	if ln, lerr := b.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
 	if b.Static {
 		if sn, serr := w.Write([]byte(`static`)); serr != nil {
 			err = serr
@@ -253,24 +312,42 @@ func (b Block) WriteTo(w io.Writer) (n int64, err error) {
 		n += int64(on)
 	}
 	for _, statement := range b.Statements {
-		if sn, serr := statement.WriteTo(w); serr != nil {
+		if sn, serr := writeNode(w, statement); serr != nil {
 			err = serr
 			return
 		} else {
 			n += sn
 		}
 	}
+	if dn, derr := writeDangling(w, b.Dangling); derr != nil {
+		err = derr
+		return
+	} else {
+		n += dn
+	}
 	if cn, cerr := w.Write([]byte(`}`)); cerr != nil {
 		err = cerr
 		return
 	} else {
 		n += int64(cn)
 	}
+	if tn, terr := b.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
 // Implements [io.WriterTo] interface for [Break].
 func (b Break) WriteTo(w io.Writer) (n int64, err error) {
+	if ln, lerr := b.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
 	if bn, berr := w.Write([]byte(`break`)); berr != nil {
 		err = berr
 		return
@@ -291,30 +368,65 @@ func (b Break) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(sn)
 	}
+	if tn, terr := b.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
 // Implements [io.WriterTo] interface for [StatementCase].
 func (sc StatementCase) WriteTo(w io.Writer) (n int64, err error) {
-	if sc.Expression != nil {
+	llen := len(sc.Labels)
+	if llen == 0 || llen == 1 && sc.Labels[0].GetKind() == DEFAULT_CASE_LABEL {
+		if dn, derr := w.Write([]byte(`default`)); derr != nil {
+			err = derr
+			return
+		} else {
+			n += int64(dn)
+		}
+	} else {
 		if cn, cerr := w.Write([]byte(`case`)); cerr != nil {
 			err = cerr
 			return
 		} else {
 			n += int64(cn)
 		}
-		if en, eerr := sc.Expression.WriteTo(w); eerr != nil {
-			err = eerr
+		for i := 0; i < llen-1; i++ {
+			if ln, lerr := writeNode(w, sc.Labels[i]); lerr != nil {
+				err = lerr
+				return
+			} else {
+				n += ln
+			}
+			if cn, cerr := w.Write([]byte(`,`)); cerr != nil {
+				err = cerr
+				return
+			} else {
+				n += int64(cn)
+			}
+		}
+		if ln, lerr := writeNode(w, sc.Labels[llen-1]); lerr != nil {
+			err = lerr
 			return
 		} else {
-			n += en
+			n += ln
 		}
-	} else {
-		if dn, derr := w.Write([]byte(`default`)); derr != nil {
-			err = derr
+	}
+	if sc.Guard != nil {
+		if wn, werr := w.Write([]byte(`when`)); werr != nil {
+			err = werr
 			return
 		} else {
-			n += int64(dn)
+			n += int64(wn)
+		}
+		if gn, gerr := writeNode(w, sc.Guard); gerr != nil {
+			err = gerr
+			return
+		} else {
+			n += gn
 		}
 	}
 	if cn, cerr := w.Write([]byte(`:`)); cerr != nil {
@@ -324,7 +436,7 @@ func (sc StatementCase) WriteTo(w io.Writer) (n int64, err error) {
 		n += int64(cn)
 	}
 	for _, statement := range sc.Statements {
-		if sn, serr := statement.WriteTo(w); serr != nil {
+		if sn, serr := writeNode(w, statement); serr != nil {
 			err = serr
 			return
 		} else {
@@ -352,7 +464,7 @@ func (rc RuleCase) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(cn)
 		}
 		for i := 0; i < llen-1; i++ {
-			if ln, lerr := rc.Labels[i].WriteTo(w); lerr != nil {
+			if ln, lerr := writeNode(w, rc.Labels[i]); lerr != nil {
 				err = lerr
 				return
 			} else {
@@ -365,20 +477,34 @@ func (rc RuleCase) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(cn)
 			}
 		}
-		if ln, lerr := rc.Labels[llen-1].WriteTo(w); lerr != nil {
+		if ln, lerr := writeNode(w, rc.Labels[llen-1]); lerr != nil {
 			err = lerr
 			return
 		} else {
 			n += ln
 		}
 	}
+	if rc.Guard != nil {
+		if wn, werr := w.Write([]byte(`when`)); werr != nil {
+			err = werr
+			return
+		} else {
+			n += int64(wn)
+		}
+		if gn, gerr := writeNode(w, rc.Guard); gerr != nil {
+			err = gerr
+			return
+		} else {
+			n += gn
+		}
+	}
 	if an, aerr := w.Write([]byte(`->`)); aerr != nil {
 		err = aerr
 		return
 	} else {
 		n += int64(an)
 	}
-	if bn, berr := rc.Body.WriteTo(w); berr != nil {
+	if bn, berr := writeNode(w, rc.Body); berr != nil {
 		err = berr
 		return
 	} else {
@@ -401,7 +527,7 @@ func (c Catch) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(on)
 	}
-	if tn, terr := c.Parameter.GetType().WriteTo(w); terr != nil {
+	if tn, terr := writeNode(w, c.Parameter.GetType()); terr != nil {
 		err = terr
 		return
 	} else {
@@ -419,7 +545,7 @@ func (c Catch) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(cn)
 	}
-	if bn, berr := c.Block.WriteTo(w); berr != nil {
+	if bn, berr := writeNode(w, c.Block); berr != nil {
 		err = berr
 		return
 	} else {
@@ -430,7 +556,13 @@ func (c Catch) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [Class].
 func (c Class) WriteTo(w io.Writer) (n int64, err error) {
-	if mn, merr := c.Modifiers.WriteTo(w); merr != nil {
+	if ln, lerr := c.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
+	if mn, merr := writeNode(w, c.Modifiers); merr != nil {
 		err = merr
 		return
 	} else {
@@ -456,7 +588,7 @@ func (c Class) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(on)
 		}
 		for i := 0; i < tplen-1; i++ {
-			if tpn, tperr := c.TypeParameters[i].WriteTo(w); tperr != nil {
+			if tpn, tperr := writeNode(w, c.TypeParameters[i]); tperr != nil {
 				err = tperr
 				return
 			} else {
@@ -469,7 +601,7 @@ func (c Class) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(cn)
 			}
 		}
-		if tpn, tperr := c.TypeParameters[tplen-1].WriteTo(w); tperr != nil {
+		if tpn, tperr := writeNode(w, c.TypeParameters[tplen-1]); tperr != nil {
 			err = tperr
 			return
 		} else {
@@ -489,7 +621,7 @@ func (c Class) WriteTo(w io.Writer) (n int64, err error) {
 		} else {
 			n += int64(en)
 		}
-		if ecn, ecerr := c.ExtendsClause.WriteTo(w); ecerr != nil {
+		if ecn, ecerr := writeNode(w, c.ExtendsClause); ecerr != nil {
 			err = ecerr
 			return
 		} else {
@@ -504,7 +636,7 @@ func (c Class) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(in)
 		}
 		for i := 0; i < iclen-1; i++ {
-			if icn, icerr := c.ImplementsClause[i].WriteTo(w); icerr != nil {
+			if icn, icerr := writeNode(w, c.ImplementsClause[i]); icerr != nil {
 				err = icerr
 				return
 			} else {
@@ -517,13 +649,41 @@ func (c Class) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(cn)
 			}
 		}
-		if icn, icerr := c.ImplementsClause[iclen-1].WriteTo(w); icerr != nil {
+		if icn, icerr := writeNode(w, c.ImplementsClause[iclen-1]); icerr != nil {
 			err = icerr
 			return
 		} else {
 			n += icn
 		}
 	}
+	if pclen := len(c.PermitsClause); pclen > 0 {
+		if pn, perr := w.Write([]byte(`permits`)); perr != nil {
+			err = perr
+			return
+		} else {
+			n += int64(pn)
+		}
+		for j := 0; j < pclen-1; j++ {
+			if pcn, pcerr := writeNode(w, c.PermitsClause[j]); pcerr != nil {
+				err = pcerr
+				return
+			} else {
+				n += pcn
+			}
+			if cn, cerr := w.Write([]byte(`,`)); cerr != nil {
+				err = cerr
+				return
+			} else {
+				n += int64(cn)
+			}
+		}
+		if pcn, pcerr := writeNode(w, c.PermitsClause[pclen-1]); pcerr != nil {
+			err = pcerr
+			return
+		} else {
+			n += pcn
+		}
+	}
 	if on, oerr := w.Write([]byte(`{`)); oerr != nil {
 		err = oerr
 		return
@@ -531,26 +691,38 @@ func (c Class) WriteTo(w io.Writer) (n int64, err error) {
 		n += int64(on)
 	}
 	for _, member := range c.Members {
-		if mn, merr := member.WriteTo(w); merr != nil {
+		if mn, merr := writeNode(w, member); merr != nil {
 			err = merr
 			return
 		} else {
 			n += mn
 		}
 	}
+	if dn, derr := writeDangling(w, c.Dangling); derr != nil {
+		err = derr
+		return
+	} else {
+		n += dn
+	}
 	if cn, cerr := w.Write([]byte(`}`)); cerr != nil {
 		err = cerr
 		return
 	} else {
 		n += int64(cn)
 	}
+	if tn, terr := c.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
 // Implements [io.WriterTo] interface for [CompilationUnit].
 func (cu CompilationUnit) WriteTo(w io.Writer) (n int64, err error) {
 	if cu.Module != nil {
-		if mn, merr := cu.Module.WriteTo(w); merr != nil {
+		if mn, merr := writeNode(w, cu.Module); merr != nil {
 			err = merr
 			return
 		} else {
@@ -558,7 +730,7 @@ func (cu CompilationUnit) WriteTo(w io.Writer) (n int64, err error) {
 		}
 	}
 	if cu.Package != nil {
-		if pn, perr := cu.Package.WriteTo(w); perr != nil {
+		if pn, perr := writeNode(w, cu.Package); perr != nil {
 			err = perr
 			return
 		} else {
@@ -566,7 +738,7 @@ func (cu CompilationUnit) WriteTo(w io.Writer) (n int64, err error) {
 		}
 	}
 	for _, i := range cu.Imports {
-		if in, ierr := i.WriteTo(w); ierr != nil {
+		if in, ierr := writeNode(w, i); ierr != nil {
 			err = ierr
 			return
 		} else {
@@ -574,7 +746,7 @@ func (cu CompilationUnit) WriteTo(w io.Writer) (n int64, err error) {
 		}
 	}
 	for _, decl := range cu.TypeDecls {
-		if tdn, tderr := decl.WriteTo(w); tderr != nil {
+		if tdn, tderr := writeNode(w, decl); tderr != nil {
 			err = tderr
 			return
 		} else {
@@ -586,7 +758,7 @@ func (cu CompilationUnit) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [ConditionalExpression].
 func (cx ConditionalExpression) WriteTo(w io.Writer) (n int64, err error) {
-	if cn, cerr := cx.Condition.WriteTo(w); cerr != nil {
+	if cn, cerr := writeOperand(w, cx.Condition, conditionalOrPrecedence); cerr != nil {
 		err = cerr
 		return
 	} else {
@@ -598,7 +770,7 @@ func (cx ConditionalExpression) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(qn)
 	}
-	if txn, txerr := cx.TrueExpression.WriteTo(w); txerr != nil {
+	if txn, txerr := writeNode(w, cx.TrueExpression); txerr != nil {
 		err = txerr
 		return
 	} else {
@@ -610,7 +782,12 @@ func (cx ConditionalExpression) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(cn)
 	}
-	if fxn, fxerr := cx.FalseExpression.WriteTo(w); fxerr != nil {
+	// The false expression may be a lambda expression, which needs no parentheses.
+	min := conditionalPrecedence
+	if cx.FalseExpression.GetKind() == LAMBDA_EXPRESSION {
+		min = assignmentPrecedence
+	}
+	if fxn, fxerr := writeOperand(w, cx.FalseExpression, min); fxerr != nil {
 		err = fxerr
 		return
 	} else {
@@ -621,6 +798,12 @@ func (cx ConditionalExpression) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [Continue].
 func (c Continue) WriteTo(w io.Writer) (n int64, err error) {
+	if ln, lerr := c.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
 	if cn, cerr := w.Write([]byte(`continue`)); cerr != nil {
 		err = cerr
 		return
@@ -641,18 +824,30 @@ func (c Continue) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(sn)
 	}
+	if tn, terr := c.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
 // Implements [io.WriterTo] interface for [DoWhileLoop].
 func (dwl DoWhileLoop) WriteTo(w io.Writer) (n int64, err error) {
+	if ln, lerr := dwl.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
 	if dn, derr := w.Write([]byte(`do`)); derr != nil {
 		err = derr
 		return
 	} else {
 		n += int64(dn)
 	}
-	if sn, serr := dwl.Statement.WriteTo(w); serr != nil {
+	if sn, serr := writeNode(w, dwl.Statement); serr != nil {
 		err = serr
 		return
 	} else {
@@ -670,7 +865,7 @@ func (dwl DoWhileLoop) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(on)
 	}
-	if cn, cerr := dwl.Condition.WriteTo(w); cerr != nil {
+	if cn, cerr := writeNode(w, dwl.Condition); cerr != nil {
 		err = cerr
 		return
 	} else {
@@ -688,11 +883,23 @@ func (dwl DoWhileLoop) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(sn)
 	}
+	if tn, terr := dwl.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
 // Implements [io.WriterTo] interface for [EnhancedForLoop].
 func (efl EnhancedForLoop) WriteTo(w io.Writer) (n int64, err error) {
+	if ln, lerr := efl.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
 	if fn, ferr := w.Write([]byte(`for`)); ferr != nil {
 		err = ferr
 		return
@@ -706,7 +913,7 @@ func (efl EnhancedForLoop) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(on)
 	}
-	if tn, terr := efl.Variable.GetType().WriteTo(w); terr != nil {
+	if tn, terr := writeNode(w, efl.Variable.GetType()); terr != nil {
 		err = terr
 		return
 	} else {
@@ -724,7 +931,7 @@ func (efl EnhancedForLoop) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(cn)
 	}
-	if xn, xerr := efl.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeNode(w, efl.Expression); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -736,18 +943,30 @@ func (efl EnhancedForLoop) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(cn)
 	}
-	if sn, serr := efl.Statement.WriteTo(w); serr != nil {
+	if sn, serr := writeNode(w, efl.Statement); serr != nil {
 		err = serr
 		return
 	} else {
 		n += sn
 	}
+	if tn, terr := efl.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
 // Implements [io.WriterTo] interface for [ExpressionStatement].
 func (xs ExpressionStatement) WriteTo(w io.Writer) (n int64, err error) {
-	if xn, xerr := xs.Expression.WriteTo(w); xerr != nil {
+	if ln, lerr := xs.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
+	if xn, xerr := writeNode(w, xs.Expression); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -759,12 +978,18 @@ func (xs ExpressionStatement) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(sn)
 	}
+	if tn, terr := xs.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
 // Implements [io.WriterTo] interface for [MemberSelect].
 func (ms MemberSelect) WriteTo(w io.Writer) (n int64, err error) {
-	if xn, xerr := ms.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeOperand(w, ms.Expression, primaryPrecedence); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -787,7 +1012,7 @@ func (ms MemberSelect) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [InvokeMemberReference].
 func (imr InvokeMemberReference) WriteTo(w io.Writer) (n int64, err error) {
-	if qen, qeerr := imr.QualifierExpression.WriteTo(w); qeerr != nil {
+	if qen, qeerr := writeNode(w, imr.QualifierExpression); qeerr != nil {
 		err = qeerr
 		return
 	} else {
@@ -807,7 +1032,7 @@ func (imr InvokeMemberReference) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(on)
 		}
 		for i := 0; i < talen-1; i++ {
-			if tan, taerr := imr.TypeArguments[i].WriteTo(w); taerr != nil {
+			if tan, taerr := writeNode(w, imr.TypeArguments[i]); taerr != nil {
 				err = taerr
 				return
 			} else {
@@ -820,7 +1045,7 @@ func (imr InvokeMemberReference) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(cn)
 			}
 		}
-		if tan, taerr := imr.TypeArguments[talen-1].WriteTo(w); taerr != nil {
+		if tan, taerr := writeNode(w, imr.TypeArguments[talen-1]); taerr != nil {
 			err = taerr
 			return
 		} else {
@@ -844,7 +1069,7 @@ func (imr InvokeMemberReference) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [NewMemberReference].
 func (nmr NewMemberReference) WriteTo(w io.Writer) (n int64, err error) {
-	if qen, qeerr := nmr.QualifierExpression.WriteTo(w); qeerr != nil {
+	if qen, qeerr := writeNode(w, nmr.QualifierExpression); qeerr != nil {
 		err = qeerr
 		return
 	} else {
@@ -864,7 +1089,7 @@ func (nmr NewMemberReference) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(on)
 		}
 		for i := 0; i < talen-1; i++ {
-			if tan, taerr := nmr.TypeArguments[i].WriteTo(w); taerr != nil {
+			if tan, taerr := writeNode(w, nmr.TypeArguments[i]); taerr != nil {
 				err = taerr
 				return
 			} else {
@@ -877,7 +1102,7 @@ func (nmr NewMemberReference) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(cn)
 			}
 		}
-		if tan, taerr := nmr.TypeArguments[talen-1].WriteTo(w); taerr != nil {
+		if tan, taerr := writeNode(w, nmr.TypeArguments[talen-1]); taerr != nil {
 			err = taerr
 			return
 		} else {
@@ -901,6 +1126,12 @@ func (nmr NewMemberReference) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [ForLoop].
 func (fl ForLoop) WriteTo(w io.Writer) (n int64, err error) {
+	if ln, lerr := fl.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
 	if fn, ferr := w.Write([]byte(`for`)); ferr != nil {
 		err = ferr
 		return
@@ -913,33 +1144,20 @@ func (fl ForLoop) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(on)
 	}
-	if ilen := len(fl.Initializer); ilen > 0 {
-		if in, ierr := fl.Initializer[0].GetType().WriteTo(w); ierr != nil {
+	if len(fl.Initializer) > 0 {
+		if in, ierr := writeNode(w, VariableDeclaration{Context: FOR_INIT_CONTEXT, Variables: fl.Initializer}); ierr != nil {
 			err = ierr
 			return
 		} else {
 			n += in
 		}
-		for i := 0; i < ilen-1; i++ {
-			if in, ierr := w.Write([]byte(fl.Initializer[i].GetName())); ierr != nil {
-				err = ierr
+	} else if xlen := len(fl.Expressions); xlen > 0 {
+		for i := 0; i < xlen-1; i++ {
+			if xn, xerr := writeNode(w, fl.Expressions[i]); xerr != nil {
+				err = xerr
 				return
 			} else {
-				n += int64(in)
-			}
-			if fl.Initializer[i].GetInitializer() != nil {
-				if en, eerr := w.Write([]byte(`=`)); eerr != nil {
-					err = eerr
-					return
-				} else {
-					n += int64(en)
-				}
-				if in, ierr := fl.Initializer[i].GetInitializer().WriteTo(w); ierr != nil {
-					err = ierr
-					return
-				} else {
-					n += in
-				}
+				n += xn
 			}
 			if cn, cerr := w.Write([]byte(`,`)); cerr != nil {
 				err = cerr
@@ -948,25 +1166,11 @@ func (fl ForLoop) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(cn)
 			}
 		}
-		if in, ierr := w.Write([]byte(fl.Initializer[ilen-1].GetName())); ierr != nil {
-			err = ierr
+		if xn, xerr := writeNode(w, fl.Expressions[xlen-1]); xerr != nil {
+			err = xerr
 			return
 		} else {
-			n += int64(in)
-		}
-		if fl.Initializer[ilen-1].GetInitializer() != nil {
-			if en, eerr := w.Write([]byte(`=`)); eerr != nil {
-				err = eerr
-				return
-			} else {
-				n += int64(en)
-			}
-			if in, ierr := fl.Initializer[ilen-1].GetInitializer().WriteTo(w); ierr != nil {
-				err = ierr
-				return
-			} else {
-				n += in
-			}
+			n += xn
 		}
 	}
 	if sn, serr := w.Write([]byte(`;`)); serr != nil {
@@ -976,7 +1180,7 @@ func (fl ForLoop) WriteTo(w io.Writer) (n int64, err error) {
 		n += int64(sn)
 	}
 	if fl.Condition != nil {
-		if cn, cerr := fl.Condition.WriteTo(w); cerr != nil {
+		if cn, cerr := writeNode(w, fl.Condition); cerr != nil {
 			err = cerr
 			return
 		} else {
@@ -991,7 +1195,7 @@ func (fl ForLoop) WriteTo(w io.Writer) (n int64, err error) {
 	}
 	if ulen := len(fl.Update); ulen > 0 {
 		for i := 0; i < ulen-1; i++ {
-			if un, uerr := fl.Update[i].WriteTo(w); uerr != nil {
+			if un, uerr := writeNode(w, fl.Update[i]); uerr != nil {
 				err = uerr
 				return
 			} else {
@@ -1004,7 +1208,7 @@ func (fl ForLoop) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(cn)
 			}
 		}
-		if un, uerr := fl.Update[ulen-1].WriteTo(w); uerr != nil {
+		if un, uerr := writeNode(w, fl.Update[ulen-1]); uerr != nil {
 			err = uerr
 			return
 		} else {
@@ -1017,12 +1221,18 @@ func (fl ForLoop) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(cn)
 	}
-	if sn, serr := fl.Statement.WriteTo(w); serr != nil {
+	if sn, serr := writeNode(w, fl.Statement); serr != nil {
 		err = serr
 		return
 	} else {
 		n += sn
 	}
+	if tn, terr := fl.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
@@ -1039,6 +1249,12 @@ func (i Identifier) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [If].
 func (i If) WriteTo(w io.Writer) (n int64, err error) {
+	if ln, lerr := i.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
 	if in, ierr := w.Write([]byte(`if`)); ierr != nil {
 		err = ierr
 		return
@@ -1051,7 +1267,7 @@ func (i If) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(on)
 	}
-	if cn, cerr := i.Condition.WriteTo(w); cerr != nil {
+	if cn, cerr := writeNode(w, i.Condition); cerr != nil {
 		err = cerr
 		return
 	} else {
@@ -1063,7 +1279,7 @@ func (i If) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(cn)
 	}
-	if tn, terr := i.ThenStatement.WriteTo(w); terr != nil {
+	if tn, terr := writeNode(w, i.ThenStatement); terr != nil {
 		err = terr
 		return
 	} else {
@@ -1076,18 +1292,30 @@ func (i If) WriteTo(w io.Writer) (n int64, err error) {
 		} else {
 			n += int64(en)
 		}
-		if en, eerr := i.ElseStatement.WriteTo(w); eerr != nil {
+		if en, eerr := writeNode(w, i.ElseStatement); eerr != nil {
 			err = eerr
 			return
 		} else {
 			n += en
 		}
 	}
+	if tn, terr := i.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
 // Implements [io.WriterTo] interface for [Import].
 func (i Import) WriteTo(w io.Writer) (n int64, err error) {
+	if ln, lerr := i.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
 	if in, ierr := w.Write([]byte(`import`)); ierr != nil {
 		err = ierr
 		return
@@ -1102,7 +1330,7 @@ func (i Import) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(sn)
 		}
 	}
-	if qin, qierr := i.QualifiedIdentifier.WriteTo(w); qierr != nil {
+	if qin, qierr := writeNode(w, i.QualifiedIdentifier); qierr != nil {
 		err = qierr
 		return
 	} else {
@@ -1114,12 +1342,18 @@ func (i Import) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(sn)
 	}
+	if tn, terr := i.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
 // Implements [io.WriterTo] interface for [InstanceOf].
 func (io InstanceOf) WriteTo(w io.Writer) (n int64, err error) {
-	if xn, xerr := io.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeOperand(w, io.Expression, relationalPrecedence); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -1131,25 +1365,32 @@ func (io InstanceOf) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(ion)
 	}
-	if tn, terr := io.Type.WriteTo(w); terr != nil {
-		err = terr
-		return
-	} else {
-		n += tn
-	}
 	if io.Pattern != nil {
-		if pn, perr := io.Pattern.WriteTo(w); perr != nil {
+		if pn, perr := writeNode(w, io.Pattern); perr != nil {
 			err = perr
 			return
 		} else {
 			n += pn
 		}
+	} else {
+		if tn, terr := writeNode(w, io.Type); terr != nil {
+			err = terr
+			return
+		} else {
+			n += tn
+		}
 	}
 	return
 }
 
 // Implements [io.WriterTo] interface for [LabeledStatement].
 func (ls LabeledStatement) WriteTo(w io.Writer) (n int64, err error) {
+	if ln, lerr := ls.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
 	if ln, lerr := w.Write([]byte(ls.Label)); lerr != nil {
 		err = lerr
 		return
@@ -1162,18 +1403,30 @@ func (ls LabeledStatement) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(cn)
 	}
-	if sn, serr := ls.Statement.WriteTo(w); serr != nil {
+	if sn, serr := writeNode(w, ls.Statement); serr != nil {
 		err = serr
 		return
 	} else {
 		n += sn
 	}
+	if tn, terr := ls.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
 // Implements [io.WriterTo] interface for [Method].
 func (m Method) WriteTo(w io.Writer) (n int64, err error) {
-	if mn, merr := m.Modifiers.WriteTo(w); merr != nil {
+	if ln, lerr := m.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
+	if mn, merr := writeNode(w, m.Modifiers); merr != nil {
 		err = merr
 		return
 	} else {
@@ -1187,7 +1440,7 @@ func (m Method) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(on)
 		}
 		for i := 0; i < tplen-1; i++ {
-			if tpn, tperr := m.TypeParameters[i].WriteTo(w); tperr != nil {
+			if tpn, tperr := writeNode(w, m.TypeParameters[i]); tperr != nil {
 				err = tperr
 				return
 			} else {
@@ -1200,7 +1453,7 @@ func (m Method) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(cn)
 			}
 		}
-		if tpn, tperr := m.TypeParameters[tplen-1].WriteTo(w); tperr != nil {
+		if tpn, tperr := writeNode(w, m.TypeParameters[tplen-1]); tperr != nil {
 			err = tperr
 			return
 		} else {
@@ -1214,7 +1467,7 @@ func (m Method) WriteTo(w io.Writer) (n int64, err error) {
 		}
 	}
 	if m.ReturnType != nil {
-		if rtn, rterr := m.ReturnType.WriteTo(w); rterr != nil {
+		if rtn, rterr := writeNode(w, m.ReturnType); rterr != nil {
 			err = rterr
 			return
 		} else {
@@ -1227,96 +1480,113 @@ func (m Method) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(nn)
 	}
-	if on, oerr := w.Write([]byte(`(`)); oerr != nil {
-		err = oerr
-		return
-	} else {
-		n += int64(on)
-	}
-	if m.ReceiverParameter != nil {
-		if rpn, rperr := m.ReceiverParameter.WriteTo(w); rperr != nil {
-			err = rperr
+	if !m.Compact {
+		if on, oerr := w.Write([]byte(`(`)); oerr != nil {
+			err = oerr
 			return
 		} else {
-			n += rpn
+			n += int64(on)
 		}
-		if len(m.Parameters) > 0 {
-			if cn, cerr := w.Write([]byte(`,`)); cerr != nil {
-				err = cerr
+		if m.ReceiverParameter != nil {
+			if rpn, rperr := writeNode(w, m.ReceiverParameter.GetType()); rperr != nil {
+				err = rperr
 				return
 			} else {
-				n += int64(cn)
+				n += rpn
+			}
+			if ne := m.ReceiverParameter.GetNameExpression(); ne != nil {
+				if rpn, rperr := writeNode(w, ne); rperr != nil {
+					err = rperr
+					return
+				} else {
+					n += rpn
+				}
+			} else {
+				if rpn, rperr := w.Write([]byte(m.ReceiverParameter.GetName())); rperr != nil {
+					err = rperr
+					return
+				} else {
+					n += int64(rpn)
+				}
+			}
+			if len(m.Parameters) > 0 {
+				if cn, cerr := w.Write([]byte(`,`)); cerr != nil {
+					err = cerr
+					return
+				} else {
+					n += int64(cn)
+				}
 			}
 		}
-	}
-	if plen := len(m.Parameters); plen > 0 {
-		for i := 0; i < plen-1; i++ {
-			if pn, perr := m.Parameters[i].GetType().WriteTo(w); perr != nil {
+		if plen := len(m.Parameters); plen > 0 {
+			for i := 0; i < plen-1; i++ {
+				if pn, perr := writeParameterType(w, m.Parameters[i]); perr != nil {
+					err = perr
+					return
+				} else {
+					n += pn
+				}
+				if pn, perr := w.Write([]byte(m.Parameters[i].GetName())); perr != nil {
+					err = perr
+					return
+				} else {
+					n += int64(pn)
+				}
+				if m.Parameters[i].GetInitializer() != nil {
+					if en, eerr := w.Write([]byte(`=`)); eerr != nil {
+						err = eerr
+						return
+					} else {
+						n += int64(en)
+					}
+					if pn, perr := writeNode(w, m.Parameters[i].GetInitializer()); perr != nil {
+						err = perr
+						return
+					} else {
+						n += pn
+					}
+				}
+				if cn, cerr := w.Write([]byte(`,`)); cerr != nil {
+					err = cerr
+					return
+				} else {
+					n += int64(cn)
+				}
+			}
+			if pn, perr := writeParameterType(w, m.Parameters[plen-1]); perr != nil {
 				err = perr
 				return
 			} else {
 				n += pn
 			}
-			if pn, perr := w.Write([]byte(m.Parameters[i].GetName())); perr != nil {
+			if pn, perr := w.Write([]byte(m.Parameters[plen-1].GetName())); perr != nil {
 				err = perr
 				return
 			} else {
 				n += int64(pn)
 			}
-			if m.Parameters[i].GetInitializer() != nil {
+			if m.Parameters[plen-1].GetInitializer() != nil {
 				if en, eerr := w.Write([]byte(`=`)); eerr != nil {
 					err = eerr
 					return
 				} else {
 					n += int64(en)
 				}
-				if pn, perr := m.Parameters[i].GetInitializer().WriteTo(w); perr != nil {
+				if pn, perr := writeNode(w, m.Parameters[plen-1].GetInitializer()); perr != nil {
 					err = perr
 					return
 				} else {
 					n += pn
 				}
 			}
-			if cn, cerr := w.Write([]byte(`,`)); cerr != nil {
-				err = cerr
-				return
-			} else {
-				n += int64(cn)
-			}
-		}
-		if pn, perr := m.Parameters[plen-1].GetType().WriteTo(w); perr != nil {
-			err = perr
-			return
-		} else {
-			n += pn
 		}
-		if pn, perr := w.Write([]byte(m.Parameters[plen-1].GetName())); perr != nil {
-			err = perr
+		if cn, cerr := w.Write([]byte(`)`)); cerr != nil {
+			err = cerr
 			return
 		} else {
-			n += int64(pn)
-		}
-		if m.Parameters[plen-1].GetInitializer() != nil {
-			if en, eerr := w.Write([]byte(`=`)); eerr != nil {
-				err = eerr
-				return
-			} else {
-				n += int64(en)
-			}
-			if pn, perr := m.Parameters[plen-1].GetInitializer().WriteTo(w); perr != nil {
-				err = perr
-				return
-			} else {
-				n += pn
-			}
+			n += int64(cn)
 		}
 	}
-	if cn, cerr := w.Write([]byte(`)`)); cerr != nil {
-		err = cerr
-		return
-	} else {
-		n += int64(cn)
-	}
 	if tlen := len(m.Throws); tlen > 0 {
 		if tn, terr := w.Write([]byte(`throws`)); terr != nil {
 			err = terr
@@ -1325,7 +1595,7 @@ func (m Method) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(tn)
 		}
 		for i := 0; i < tlen-1; i++ {
-			if tn, terr := m.Throws[i].WriteTo(w); terr != nil {
+			if tn, terr := writeNode(w, m.Throws[i]); terr != nil {
 				err = terr
 				return
 			} else {
@@ -1338,7 +1608,7 @@ func (m Method) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(cn)
 			}
 		}
-		if tn, terr := m.Throws[tlen-1].WriteTo(w); terr != nil {
+		if tn, terr := writeNode(w, m.Throws[tlen-1]); terr != nil {
 			err = terr
 			return
 		} else {
@@ -1346,7 +1616,7 @@ func (m Method) WriteTo(w io.Writer) (n int64, err error) {
 		}
 	}
 	if m.Body != nil {
-		if bn, berr := m.Body.WriteTo(w); berr != nil {
+		if bn, berr := writeNode(w, m.Body); berr != nil {
 			err = berr
 			return
 		} else {
@@ -1360,18 +1630,61 @@ func (m Method) WriteTo(w io.Writer) (n int64, err error) {
 		} else {
 			n += int64(dn)
 		}
-		if dvn, dverr := m.DefaultValue.WriteTo(w); dverr != nil {
+		if dvn, dverr := writeNode(w, m.DefaultValue); dverr != nil {
 			err = dverr
 			return
 		} else {
 			n += dvn
 		}
 	}
+	if m.Body == nil {
+		if sn, serr := w.Write([]byte(`;`)); serr != nil {
+			err = serr
+			return
+		} else {
+			n += int64(sn)
+		}
+	}
+	if tn, terr := m.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
+// Returns the qualifier and the name of a method select, between which the type arguments of an invocation
+// are written, or nil if the method select is not qualified.
+func invocationQualifier(methodSelect ExpressionNode) (ExpressionNode, string) {
+	switch ms := methodSelect.(type) {
+	case MemberSelectNode:
+		return ms.GetExpression(), ms.GetIdentifier()
+	case ThisNode:
+		return ms.GetQualifier(), "this"
+	case SuperNode:
+		return ms.GetQualifier(), "super"
+	}
+	return nil, ""
+}
+
 // Implements [io.WriterTo] interface for [MethodInvocation].
 func (mi MethodInvocation) WriteTo(w io.Writer) (n int64, err error) {
+	qualifier, name := invocationQualifier(mi.MethodSelect)
+	if qualifier != nil && len(mi.TypeArguments) > 0 {
+		if xn, xerr := writeNode(w, qualifier); xerr != nil {
+			err = xerr
+			return
+		} else {
+			n += xn
+		}
+		if dn, derr := w.Write([]byte(`.`)); derr != nil {
+			err = derr
+			return
+		} else {
+			n += int64(dn)
+		}
+	}
 	if talen := len(mi.TypeArguments); talen > 0 {
 		if on, oerr := w.Write([]byte(`<`)); oerr != nil {
 			err = oerr
@@ -1380,7 +1693,7 @@ func (mi MethodInvocation) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(on)
 		}
 		for i := 0; i < talen-1; i++ {
-			if tan, taerr := mi.TypeArguments[i].WriteTo(w); taerr != nil {
+			if tan, taerr := writeNode(w, mi.TypeArguments[i]); taerr != nil {
 				err = taerr
 				return
 			} else {
@@ -1393,7 +1706,7 @@ func (mi MethodInvocation) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(cn)
 			}
 		}
-		if tan, taerr := mi.TypeArguments[talen-1].WriteTo(w); taerr != nil {
+		if tan, taerr := writeNode(w, mi.TypeArguments[talen-1]); taerr != nil {
 			err = taerr
 			return
 		} else {
@@ -1406,11 +1719,20 @@ func (mi MethodInvocation) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(cn)
 		}
 	}
-	if msn, mserr := mi.MethodSelect.WriteTo(w); mserr != nil {
-		err = mserr
-		return
+	if qualifier != nil && len(mi.TypeArguments) > 0 {
+		if in, ierr := w.Write([]byte(name)); ierr != nil {
+			err = ierr
+			return
+		} else {
+			n += int64(in)
+		}
 	} else {
-		n += msn
+		if msn, mserr := writeNode(w, mi.MethodSelect); mserr != nil {
+			err = mserr
+			return
+		} else {
+			n += msn
+		}
 	}
 	if on, oerr := w.Write([]byte(`(`)); oerr != nil {
 		err = oerr
@@ -1420,7 +1742,7 @@ func (mi MethodInvocation) WriteTo(w io.Writer) (n int64, err error) {
 	}
 	if alen := len(mi.Arguments); alen > 0 {
 		for i := 0; i < alen-1; i++ {
-			if an, aerr := mi.Arguments[i].WriteTo(w); aerr != nil {
+			if an, aerr := writeNode(w, mi.Arguments[i]); aerr != nil {
 				err = aerr
 				return
 			} else {
@@ -1433,7 +1755,7 @@ func (mi MethodInvocation) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(cn)
 			}
 		}
-		if an, aerr := mi.Arguments[alen-1].WriteTo(w); aerr != nil {
+		if an, aerr := writeNode(w, mi.Arguments[alen-1]); aerr != nil {
 			err = aerr
 			return
 		} else {
@@ -1451,87 +1773,108 @@ func (mi MethodInvocation) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [Modifiers].
 func (m Modifiers) WriteTo(w io.Writer) (n int64, err error) {
-	for _, flag := range m.Flags {
-		if fn, ferr := w.Write([]byte(modifiers[flag])); ferr != nil {
-			err = ferr
+	for _, annotation := range m.Annotations {
+		if an, aerr := writeNode(w, annotation); aerr != nil {
+			err = aerr
 			return
 		} else {
-			n += int64(fn)
+			n += an
 		}
 	}
-	for _, annotation := range m.Annotations {
-		if an, aerr := annotation.WriteTo(w); aerr != nil {
-			err = aerr
+	for _, flag := range m.Flags {
+		if fn, ferr := w.Write([]byte(modifiers[flag])); ferr != nil {
+			err = ferr
 			return
 		} else {
-			n += an
+			n += int64(fn)
 		}
 	}
 	return
 }
 
 // Implements [io.WriterTo] interface for [NewArray].
+// Without a type, it is written as a bare array initializer, such as {1, 2}, and its dimensions are ignored.
 func (na NewArray) WriteTo(w io.Writer) (n int64, err error) {
+	// Array types of the element type are written after the dimensions.
+	var dims int
 	if na.Type != nil {
+		typ := na.Type
+		for at, ok := typ.(ArrayTypeNode); ok; at, ok = typ.(ArrayTypeNode) {
+			typ = at.GetType()
+			dims++
+		}
+		if na.Initializers != nil && len(na.Dimensions) == 0 {
+			dims++
+		}
 		if nn, nerr := w.Write([]byte(`new`)); nerr != nil {
 			err = nerr
 			return
 		} else {
 			n += int64(nn)
 		}
-		if tn, terr := na.Type.WriteTo(w); terr != nil {
+		if tn, terr := writeNode(w, typ); terr != nil {
 			err = terr
 			return
 		} else {
 			n += tn
 		}
-	}
-	for _, dimension := range na.Dimensions {
-		if on, oerr := w.Write([]byte(`[`)); oerr != nil {
-			err = oerr
-			return
-		} else {
-			n += int64(on)
-		}
-		if dn, derr := dimension.WriteTo(w); derr != nil {
-			err = derr
-			return
-		} else {
-			n += dn
+		for _, dimension := range na.Dimensions {
+			if on, oerr := w.Write([]byte(`[`)); oerr != nil {
+				err = oerr
+				return
+			} else {
+				n += int64(on)
+			}
+			if dn, derr := writeNode(w, dimension); derr != nil {
+				err = derr
+				return
+			} else {
+				n += dn
+			}
+			if cn, cerr := w.Write([]byte(`]`)); cerr != nil {
+				err = cerr
+				return
+			} else {
+				n += int64(cn)
+			}
 		}
-		if cn, cerr := w.Write([]byte(`]`)); cerr != nil {
-			err = cerr
+	}
+	for i := 0; i < dims; i++ {
+		if bn, berr := w.Write([]byte(`[]`)); berr != nil {
+			err = berr
 			return
 		} else {
-			n += int64(cn)
+			n += int64(bn)
 		}
 	}
-	if ilen := len(na.Initializers); ilen > 0 {
+	if na.Initializers != nil || na.Type == nil {
 		if on, oerr := w.Write([]byte(`{`)); oerr != nil {
 			err = oerr
 			return
 		} else {
 			n += int64(on)
 		}
-		for i := 0; i < ilen-1; i++ {
-			if in, ierr := na.Initializers[i].WriteTo(w); ierr != nil {
+		if ilen := len(na.Initializers); ilen > 0 {
+			for i := 0; i < ilen-1; i++ {
+				if in, ierr := writeNode(w, na.Initializers[i]); ierr != nil {
+					err = ierr
+					return
+				} else {
+					n += in
+				}
+				if cn, cerr := w.Write([]byte(`,`)); cerr != nil {
+					err = cerr
+					return
+				} else {
+					n += int64(cn)
+				}
+			}
+			if in, ierr := writeNode(w, na.Initializers[ilen-1]); ierr != nil {
 				err = ierr
 				return
 			} else {
 				n += in
 			}
-			if cn, cerr := w.Write([]byte(`,`)); cerr != nil {
-				err = cerr
-				return
-			} else {
-				n += int64(cn)
-			}
-		}
-		if in, ierr := na.Initializers[ilen-1].WriteTo(w); ierr != nil {
-			err = ierr
-			return
-		} else {
-			n += in
 		}
 		if cn, cerr := w.Write([]byte(`}`)); cerr != nil {
 			err = cerr
@@ -1546,7 +1889,7 @@ func (na NewArray) WriteTo(w io.Writer) (n int64, err error) {
 // Implements [io.WriterTo] interface for [NewClass].
 func (nc NewClass) WriteTo(w io.Writer) (n int64, err error) {
 	if nc.EnclosingExpression != nil {
-		if exn, exerr := nc.EnclosingExpression.WriteTo(w); exerr != nil {
+		if exn, exerr := writeNode(w, nc.EnclosingExpression); exerr != nil {
 			err = exerr
 			return
 		} else {
@@ -1573,7 +1916,7 @@ func (nc NewClass) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(on)
 		}
 		for i := 0; i < talen-1; i++ {
-			if tan, taerr := nc.TypeArguments[i].WriteTo(w); taerr != nil {
+			if tan, taerr := writeNode(w, nc.TypeArguments[i]); taerr != nil {
 				err = taerr
 				return
 			} else {
@@ -1586,7 +1929,7 @@ func (nc NewClass) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(cn)
 			}
 		}
-		if tan, taerr := nc.TypeArguments[talen-1].WriteTo(w); taerr != nil {
+		if tan, taerr := writeNode(w, nc.TypeArguments[talen-1]); taerr != nil {
 			err = taerr
 			return
 		} else {
@@ -1599,7 +1942,7 @@ func (nc NewClass) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(cn)
 		}
 	}
-	if in, ierr := nc.Identifier.WriteTo(w); ierr != nil {
+	if in, ierr := writeNode(w, nc.Identifier); ierr != nil {
 		err = ierr
 		return
 	} else {
@@ -1613,7 +1956,7 @@ func (nc NewClass) WriteTo(w io.Writer) (n int64, err error) {
 	}
 	if alen := len(nc.Arguments); alen > 0 {
 		for i := 0; i < alen-1; i++ {
-			if an, aerr := nc.Arguments[i].WriteTo(w); aerr != nil {
+			if an, aerr := writeNode(w, nc.Arguments[i]); aerr != nil {
 				err = aerr
 				return
 			} else {
@@ -1626,7 +1969,7 @@ func (nc NewClass) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(cn)
 			}
 		}
-		if an, aerr := nc.Arguments[alen-1].WriteTo(w); aerr != nil {
+		if an, aerr := writeNode(w, nc.Arguments[alen-1]); aerr != nil {
 			err = aerr
 			return
 		} else {
@@ -1640,11 +1983,25 @@ func (nc NewClass) WriteTo(w io.Writer) (n int64, err error) {
 		n += int64(cn)
 	}
 	if nc.ClassBody != nil {
-		if cbn, cberr := nc.ClassBody.WriteTo(w); cberr != nil {
-			err = cberr
+		if on, oerr := w.Write([]byte(`{`)); oerr != nil {
+			err = oerr
+			return
+		} else {
+			n += int64(on)
+		}
+		for _, member := range nc.ClassBody.GetMembers() {
+			if mn, merr := writeNode(w, member); merr != nil {
+				err = merr
+				return
+			} else {
+				n += mn
+			}
+		}
+		if cn, cerr := w.Write([]byte(`}`)); cerr != nil {
+			err = cerr
 			return
 		} else {
-			n += cbn
+			n += int64(cn)
 		}
 	}
 	return
@@ -1660,11 +2017,13 @@ func (xlx ExpressionLambdaExpression) WriteTo(w io.Writer) (n int64, err error)
 	}
 	if plen := len(xlx.Parameters); plen > 0 {
 		for i := 0; i < plen-1; i++ {
-			if pn, perr := xlx.Parameters[i].GetType().WriteTo(w); perr != nil {
-				err = perr
-				return
-			} else {
-				n += pn
+			if xlx.Parameters[i].GetType() != nil {
+				if pn, perr := writeParameterType(w, xlx.Parameters[i]); perr != nil {
+					err = perr
+					return
+				} else {
+					n += pn
+				}
 			}
 			if pn, perr := w.Write([]byte(xlx.Parameters[i].GetName())); perr != nil {
 				err = perr
@@ -1679,11 +2038,13 @@ func (xlx ExpressionLambdaExpression) WriteTo(w io.Writer) (n int64, err error)
 				n += int64(cn)
 			}
 		}
-		if pn, perr := xlx.Parameters[plen-1].GetType().WriteTo(w); perr != nil {
-			err = perr
-			return
-		} else {
-			n += pn
+		if xlx.Parameters[plen-1].GetType() != nil {
+			if pn, perr := writeParameterType(w, xlx.Parameters[plen-1]); perr != nil {
+				err = perr
+				return
+			} else {
+				n += pn
+			}
 		}
 		if pn, perr := w.Write([]byte(xlx.Parameters[plen-1].GetName())); perr != nil {
 			err = perr
@@ -1704,7 +2065,7 @@ func (xlx ExpressionLambdaExpression) WriteTo(w io.Writer) (n int64, err error)
 	} else {
 		n += int64(an)
 	}
-	if xn, xerr := xlx.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeNode(w, xlx.Expression); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -1723,11 +2084,13 @@ func (slx StatementLambdaExpression) WriteTo(w io.Writer) (n int64, err error) {
 	}
 	if plen := len(slx.Parameters); plen > 0 {
 		for i := 0; i < plen-1; i++ {
-			if pn, perr := slx.Parameters[i].GetType().WriteTo(w); perr != nil {
-				err = perr
-				return
-			} else {
-				n += pn
+			if slx.Parameters[i].GetType() != nil {
+				if pn, perr := writeParameterType(w, slx.Parameters[i]); perr != nil {
+					err = perr
+					return
+				} else {
+					n += pn
+				}
 			}
 			if pn, perr := w.Write([]byte(slx.Parameters[i].GetName())); perr != nil {
 				err = perr
@@ -1742,11 +2105,13 @@ func (slx StatementLambdaExpression) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(cn)
 			}
 		}
-		if pn, perr := slx.Parameters[plen-1].GetType().WriteTo(w); perr != nil {
-			err = perr
-			return
-		} else {
-			n += pn
+		if slx.Parameters[plen-1].GetType() != nil {
+			if pn, perr := writeParameterType(w, slx.Parameters[plen-1]); perr != nil {
+				err = perr
+				return
+			} else {
+				n += pn
+			}
 		}
 		if pn, perr := w.Write([]byte(slx.Parameters[plen-1].GetName())); perr != nil {
 			err = perr
@@ -1767,7 +2132,7 @@ func (slx StatementLambdaExpression) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(an)
 	}
-	if bn, berr := slx.Block.WriteTo(w); berr != nil {
+	if bn, berr := writeNode(w, slx.Block); berr != nil {
 		err = berr
 		return
 	} else {
@@ -1778,8 +2143,14 @@ func (slx StatementLambdaExpression) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [Package].
 func (p Package) WriteTo(w io.Writer) (n int64, err error) {
+	if ln, lerr := p.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
 	for _, annotation := range p.Annotations {
-		if an, aerr := annotation.WriteTo(w); aerr != nil {
+		if an, aerr := writeNode(w, annotation); aerr != nil {
 			err = aerr
 			return
 		} else {
@@ -1792,7 +2163,7 @@ func (p Package) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(pn)
 	}
-	if pnn, pnerr := p.PackageName.WriteTo(w); pnerr != nil {
+	if pnn, pnerr := writeNode(w, p.PackageName); pnerr != nil {
 		err = pnerr
 		return
 	} else {
@@ -1804,6 +2175,12 @@ func (p Package) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(sn)
 	}
+	if tn, terr := p.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
@@ -1815,7 +2192,7 @@ func (p Parenthesized) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(on)
 	}
-	if xn, xerr := p.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeNode(w, p.Expression); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -1832,7 +2209,7 @@ func (p Parenthesized) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [BindingPattern].
 func (bp BindingPattern) WriteTo(w io.Writer) (n int64, err error) {
-	if vn, verr := bp.Variable.GetType().WriteTo(w); verr != nil {
+	if vn, verr := writeNode(w, bp.Variable.GetType()); verr != nil {
 		err = verr
 		return
 	} else {
@@ -1849,7 +2226,7 @@ func (bp BindingPattern) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [GuardedPattern].
 func (gp GuardedPattern) WriteTo(w io.Writer) (n int64, err error) {
-	if pn, perr := gp.Pattern.WriteTo(w); perr != nil {
+	if pn, perr := writeNode(w, gp.Pattern); perr != nil {
 		err = perr
 		return
 	} else {
@@ -1861,7 +2238,7 @@ func (gp GuardedPattern) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(an)
 	}
-	if xn, xerr := gp.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeNode(w, gp.Expression); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -1878,7 +2255,7 @@ func (pp ParenthesizedPattern) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(on)
 	}
-	if pn, perr := pp.Pattern.WriteTo(w); perr != nil {
+	if pn, perr := writeNode(w, pp.Pattern); perr != nil {
 		err = perr
 		return
 	} else {
@@ -1904,26 +2281,93 @@ func (DefaultCaseLabel) WriteTo(w io.Writer) (n int64, err error) {
 	return
 }
 
-// Implements [io.WriterTo] interface for [PrimitiveType].
-func (pt PrimitiveType) WriteTo(w io.Writer) (n int64, err error) {
-	switch pt.GetPrimitiveTypeKind() {
-	case BOOLEAN_TYPE_KIND:
-		if bn, berr := w.Write([]byte(`boolean`)); berr != nil {
-			err = berr
-			return
-		} else {
-			n += int64(bn)
-		}
+// Implements [io.WriterTo] interface for [RecordPattern].
+func (rp RecordPattern) WriteTo(w io.Writer) (n int64, err error) {
+	if dn, derr := writeNode(w, rp.Deconstructor); derr != nil {
+		err = derr
 		return
-	case BYTE_TYPE_KIND:
-		if bn, berr := w.Write([]byte(`byte`)); berr != nil {
-			err = berr
+	} else {
+		n += dn
+	}
+	if on, oerr := w.Write([]byte(`(`)); oerr != nil {
+		err = oerr
+		return
+	} else {
+		n += int64(on)
+	}
+	if nplen := len(rp.NestedPatterns); nplen > 0 {
+		for i := 0; i < nplen-1; i++ {
+			if pn, perr := writeNode(w, rp.NestedPatterns[i]); perr != nil {
+				err = perr
+				return
+			} else {
+				n += pn
+			}
+			if cn, cerr := w.Write([]byte(`,`)); cerr != nil {
+				err = cerr
+				return
+			} else {
+				n += int64(cn)
+			}
+		}
+		if pn, perr := writeNode(w, rp.NestedPatterns[nplen-1]); perr != nil {
+			err = perr
 			return
 		} else {
-			n += int64(bn)
+			n += pn
 		}
+	}
+	if cn, cerr := w.Write([]byte(`)`)); cerr != nil {
+		err = cerr
 		return
-	case SHORT_TYPE_KIND:
+	} else {
+		n += int64(cn)
+	}
+	return
+}
+
+// Implements [io.WriterTo] interface for [ConstantCaseLabel].
+func (ccl ConstantCaseLabel) WriteTo(w io.Writer) (n int64, err error) {
+	if xn, xerr := writeNode(w, ccl.ConstantExpression); xerr != nil {
+		err = xerr
+		return
+	} else {
+		n += xn
+	}
+	return
+}
+
+// Implements [io.WriterTo] interface for [PatternCaseLabel].
+func (pcl PatternCaseLabel) WriteTo(w io.Writer) (n int64, err error) {
+	if pn, perr := writeNode(w, pcl.Pattern); perr != nil {
+		err = perr
+		return
+	} else {
+		n += pn
+	}
+	return
+}
+
+// Implements [io.WriterTo] interface for [PrimitiveType].
+func (pt PrimitiveType) WriteTo(w io.Writer) (n int64, err error) {
+	switch pt.GetPrimitiveTypeKind() {
+	case BOOLEAN_TYPE_KIND:
+		if bn, berr := w.Write([]byte(`boolean`)); berr != nil {
+			err = berr
+			return
+		} else {
+			n += int64(bn)
+		}
+		return
+	case BYTE_TYPE_KIND:
+		if bn, berr := w.Write([]byte(`byte`)); berr != nil {
+			err = berr
+			return
+		} else {
+			n += int64(bn)
+		}
+		return
+	case SHORT_TYPE_KIND:
 		if sn, serr := w.Write([]byte(`short`)); serr != nil {
 			err = serr
 			return
@@ -1995,6 +2439,12 @@ func (pt PrimitiveType) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [Return].
 func (r Return) WriteTo(w io.Writer) (n int64, err error) {
+	if ln, lerr := r.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
 	if rn, rerr := w.Write([]byte(`return`)); rerr != nil {
 		err = rerr
 		return
@@ -2002,7 +2452,7 @@ func (r Return) WriteTo(w io.Writer) (n int64, err error) {
 		n += int64(rn)
 	}
 	if r.Expression != nil {
-		if xn, xerr := r.Expression.WriteTo(w); xerr != nil {
+		if xn, xerr := writeNode(w, r.Expression); xerr != nil {
 			err = xerr
 			return
 		} else {
@@ -2015,17 +2465,35 @@ func (r Return) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(sn)
 	}
+	if tn, terr := r.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
 // Implements [io.WriterTo] interface for [EmptyStatement].
-func (EmptyStatement) WriteTo(w io.Writer) (n int64, err error) {
+func (es EmptyStatement) WriteTo(w io.Writer) (n int64, err error) {
+	if ln, lerr := es.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
 	if sn, serr := w.Write([]byte(`;`)); serr != nil {
 		err = serr
 		return
 	} else {
 		n += int64(sn)
 	}
+	if tn, terr := es.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
@@ -2034,6 +2502,12 @@ func (EmptyExpression) WriteTo(w io.Writer) (n int64, err error) { return }
 
 // Implements [io.WriterTo] interface for [Switch].
 func (s Switch) WriteTo(w io.Writer) (n int64, err error) {
+	if ln, lerr := s.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
 	if sn, serr := w.Write([]byte(`switch`)); serr != nil {
 		err = serr
 		return
@@ -2046,7 +2520,7 @@ func (s Switch) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(on)
 	}
-	if xn, xerr := s.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeNode(w, s.Expression); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -2065,19 +2539,31 @@ func (s Switch) WriteTo(w io.Writer) (n int64, err error) {
 		n += int64(on)
 	}
 	for _, c := range s.Cases {
-		if cn, cerr := c.WriteTo(w); cerr != nil {
+		if cn, cerr := writeNode(w, c); cerr != nil {
 			err = cerr
 			return
 		} else {
 			n += cn
 		}
 	}
+	if dn, derr := writeDangling(w, s.Dangling); derr != nil {
+		err = derr
+		return
+	} else {
+		n += dn
+	}
 	if cn, cerr := w.Write([]byte(`}`)); cerr != nil {
 		err = cerr
 		return
 	} else {
 		n += int64(cn)
 	}
+	if tn, terr := s.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
@@ -2095,7 +2581,7 @@ func (sx SwitchExpression) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(on)
 	}
-	if xn, xerr := sx.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeNode(w, sx.Expression); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -2114,13 +2600,19 @@ func (sx SwitchExpression) WriteTo(w io.Writer) (n int64, err error) {
 		n += int64(on)
 	}
 	for _, c := range sx.Cases {
-		if cn, cerr := c.WriteTo(w); cerr != nil {
+		if cn, cerr := writeNode(w, c); cerr != nil {
 			err = cerr
 			return
 		} else {
 			n += cn
 		}
 	}
+	if dn, derr := writeDangling(w, sx.Dangling); derr != nil {
+		err = derr
+		return
+	} else {
+		n += dn
+	}
 	if cn, cerr := w.Write([]byte(`}`)); cerr != nil {
 		err = cerr
 		return
@@ -2132,6 +2624,12 @@ func (sx SwitchExpression) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [Synchronized].
 func (s Synchronized) WriteTo(w io.Writer) (n int64, err error) {
+	if ln, lerr := s.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
 	if sn, serr := w.Write([]byte(`synchronized`)); serr != nil {
 		err = serr
 		return
@@ -2145,7 +2643,7 @@ func (s Synchronized) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(on)
 	}
-	if xn, xerr := s.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeNode(w, s.Expression); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -2157,24 +2655,36 @@ func (s Synchronized) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(cn)
 	}
-	if bn, berr := s.Block.WriteTo(w); berr != nil {
+	if bn, berr := writeNode(w, s.Block); berr != nil {
 		err = berr
 		return
 	} else {
 		n += bn
 	}
+	if tn, terr := s.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
 // Implements [io.WriterTo] interface for [Throw].
 func (t Throw) WriteTo(w io.Writer) (n int64, err error) {
+	if ln, lerr := t.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
 	if tn, terr := w.Write([]byte(`throw`)); terr != nil {
 		err = terr
 		return
 	} else {
 		n += int64(tn)
 	}
-	if xn, xerr := t.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeNode(w, t.Expression); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -2186,11 +2696,31 @@ func (t Throw) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(sn)
 	}
+	if tn, terr := t.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
+// Returns the resource of a "try" statement, with a variable declared in [RESOURCE_CONTEXT].
+func resource(node Node) Node {
+	if v, ok := node.(VariableNode); ok {
+		return VariableDeclaration{Context: RESOURCE_CONTEXT, Variables: []VariableNode{v}}
+	}
+	return node
+}
+
 // Implements [io.WriterTo] interface for [Try].
 func (t Try) WriteTo(w io.Writer) (n int64, err error) {
+	if ln, lerr := t.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
 	if tn, terr := w.Write([]byte(`try`)); terr != nil {
 		err = terr
 		return
@@ -2205,7 +2735,7 @@ func (t Try) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(on)
 		}
 		for i := 0; i < rlen-1; i++ {
-			if rn, rerr := t.Resources[i].WriteTo(w); rerr != nil {
+			if rn, rerr := writeNode(w, resource(t.Resources[i])); rerr != nil {
 				err = rerr
 				return
 			} else {
@@ -2218,7 +2748,7 @@ func (t Try) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(sn)
 			}
 		}
-		if rn, rerr := t.Resources[rlen-1].WriteTo(w); rerr != nil {
+		if rn, rerr := writeNode(w, resource(t.Resources[rlen-1])); rerr != nil {
 			err = rerr
 			return
 		} else {
@@ -2231,14 +2761,14 @@ func (t Try) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(cn)
 		}
 	}
-	if bn, berr := t.Block.WriteTo(w); berr != nil {
+	if bn, berr := writeNode(w, t.Block); berr != nil {
 		err = berr
 		return
 	} else {
 		n += bn
 	}
 	for _, catch := range t.Catches {
-		if cn, cerr := catch.WriteTo(w); cerr != nil {
+		if cn, cerr := writeNode(w, catch); cerr != nil {
 			err = cerr
 			return
 		} else {
@@ -2252,50 +2782,59 @@ func (t Try) WriteTo(w io.Writer) (n int64, err error) {
 		} else {
 			n += int64(fn)
 		}
-		if fbn, fberr := t.FinallyBlock.WriteTo(w); fberr != nil {
+		if fbn, fberr := writeNode(w, t.FinallyBlock); fberr != nil {
 			err = fberr
 			return
 		} else {
 			n += fbn
 		}
 	}
+	if tn, terr := t.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
 // Implements [io.WriterTo] interface for [ParameterizedType].
 func (pt ParameterizedType) WriteTo(w io.Writer) (n int64, err error) {
-	if tn, terr := pt.Type.WriteTo(w); terr != nil {
+	if tn, terr := writeNode(w, pt.Type); terr != nil {
 		err = terr
 		return
 	} else {
 		n += tn
 	}
-	if talen := len(pt.TypeArguments); talen > 0 {
+	// Empty type arguments, unlike nil ones, stand for the diamond.
+	if pt.TypeArguments != nil {
 		if on, oerr := w.Write([]byte(`<`)); oerr != nil {
 			err = oerr
 			return
 		} else {
 			n += int64(on)
 		}
-		for i := 0; i < talen-1; i++ {
-			if tan, taerr := pt.TypeArguments[i].WriteTo(w); taerr != nil {
+		if talen := len(pt.TypeArguments); talen > 0 {
+			for i := 0; i < talen-1; i++ {
+				if tan, taerr := writeNode(w, pt.TypeArguments[i]); taerr != nil {
+					err = taerr
+					return
+				} else {
+					n += tan
+				}
+				if cn, cerr := w.Write([]byte(`,`)); cerr != nil {
+					err = cerr
+					return
+				} else {
+					n += int64(cn)
+				}
+			}
+			if tan, taerr := writeNode(w, pt.TypeArguments[talen-1]); taerr != nil {
 				err = taerr
 				return
 			} else {
 				n += tan
 			}
-			if cn, cerr := w.Write([]byte(`,`)); cerr != nil {
-				err = cerr
-				return
-			} else {
-				n += int64(cn)
-			}
-		}
-		if tan, taerr := pt.TypeArguments[talen-1].WriteTo(w); taerr != nil {
-			err = taerr
-			return
-		} else {
-			n += tan
 		}
 		if cn, cerr := w.Write([]byte(`>`)); cerr != nil {
 			err = cerr
@@ -2311,7 +2850,7 @@ func (pt ParameterizedType) WriteTo(w io.Writer) (n int64, err error) {
 func (ut UnionType) WriteTo(w io.Writer) (n int64, err error) {
 	if talen := len(ut.TypeAlternatives); talen > 0 {
 		for i := 0; i < talen-1; i++ {
-			if tan, taerr := ut.TypeAlternatives[i].WriteTo(w); taerr != nil {
+			if tan, taerr := writeNode(w, ut.TypeAlternatives[i]); taerr != nil {
 				err = taerr
 				return
 			} else {
@@ -2324,7 +2863,7 @@ func (ut UnionType) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(pn)
 			}
 		}
-		if tan, taerr := ut.TypeAlternatives[talen-1].WriteTo(w); taerr != nil {
+		if tan, taerr := writeNode(w, ut.TypeAlternatives[talen-1]); taerr != nil {
 			err = taerr
 			return
 		} else {
@@ -2338,7 +2877,7 @@ func (ut UnionType) WriteTo(w io.Writer) (n int64, err error) {
 func (it IntersectionType) WriteTo(w io.Writer) (n int64, err error) {
 	if blen := len(it.Bounds); blen > 0 {
 		for i := 0; i < blen-1; i++ {
-			if bn, berr := it.Bounds[i].WriteTo(w); berr != nil {
+			if bn, berr := writeNode(w, it.Bounds[i]); berr != nil {
 				err = berr
 				return
 			} else {
@@ -2351,7 +2890,7 @@ func (it IntersectionType) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(pn)
 			}
 		}
-		if bn, berr := it.Bounds[blen-1].WriteTo(w); berr != nil {
+		if bn, berr := writeNode(w, it.Bounds[blen-1]); berr != nil {
 			err = berr
 			return
 		} else {
@@ -2369,7 +2908,7 @@ func (tc TypeCast) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(on)
 	}
-	if tn, terr := tc.Type.WriteTo(w); terr != nil {
+	if tn, terr := writeNode(w, tc.Type); terr != nil {
 		err = terr
 		return
 	} else {
@@ -2381,7 +2920,17 @@ func (tc TypeCast) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(cn)
 	}
-	if xn, xerr := tc.Expression.WriteTo(w); xerr != nil {
+	// The operand of a cast to a reference type may be a lambda expression, but cannot start with "+" or "-".
+	min := unaryPrecedence
+	if tc.Type.GetKind() != PRIMITIVE_TYPE {
+		switch tc.Expression.GetKind() {
+		case LAMBDA_EXPRESSION:
+			min = assignmentPrecedence
+		case UNARY_PLUS, UNARY_MINUS, PREFIX_INCREMENT, PREFIX_DECREMENT:
+			min = primaryPrecedence
+		}
+	}
+	if xn, xerr := writeOperand(w, tc.Expression, min); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -2393,7 +2942,7 @@ func (tc TypeCast) WriteTo(w io.Writer) (n int64, err error) {
 // Implements [io.WriterTo] interface for [TypeParameter].
 func (tp TypeParameter) WriteTo(w io.Writer) (n int64, err error) {
 	for _, annotation := range tp.Annotations {
-		if an, aerr := annotation.WriteTo(w); aerr != nil {
+		if an, aerr := writeNode(w, annotation); aerr != nil {
 			err = aerr
 			return
 		} else {
@@ -2414,20 +2963,20 @@ func (tp TypeParameter) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(en)
 		}
 		for i := 0; i < alen-1; i++ {
-			if bn, berr := tp.Bounds[i].WriteTo(w); berr != nil {
+			if bn, berr := writeNode(w, tp.Bounds[i]); berr != nil {
 				err = berr
 				return
 			} else {
 				n += bn
 			}
-			if cn, cerr := w.Write([]byte(`,`)); cerr != nil {
-				err = cerr
+			if an, aerr := w.Write([]byte(`&`)); aerr != nil {
+				err = aerr
 				return
 			} else {
-				n += int64(cn)
+				n += int64(an)
 			}
 		}
-		if bn, berr := tp.Bounds[alen-1].WriteTo(w); berr != nil {
+		if bn, berr := writeNode(w, tp.Bounds[alen-1]); berr != nil {
 			err = berr
 			return
 		} else {
@@ -2437,15 +2986,44 @@ func (tp TypeParameter) WriteTo(w io.Writer) (n int64, err error) {
 	return
 }
 
+// Writes the type of a parameter, or the element type followed by "..." if it is a variable arity parameter.
+func writeParameterType(w io.Writer, parameter VariableNode) (n int64, err error) {
+	typ := parameter.GetType()
+	if at, ok := typ.(ArrayTypeNode); ok && parameter.IsVarargs() {
+		typ = at.GetType()
+	}
+	if tn, terr := writeNode(w, typ); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
+	if parameter.IsVarargs() {
+		if en, eerr := w.Write([]byte(`...`)); eerr != nil {
+			err = eerr
+			return
+		} else {
+			n += int64(en)
+		}
+	}
+	return
+}
+
 // Implements [io.WriterTo] interface for [Variable].
 func (v Variable) WriteTo(w io.Writer) (n int64, err error) {
-	if mn, merr := v.Modifiers.WriteTo(w); merr != nil {
+	if ln, lerr := v.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
+	if mn, merr := writeNode(w, v.Modifiers); merr != nil {
 		err = merr
 		return
 	} else {
 		n += mn
 	}
-	if tn, terr := v.Type.WriteTo(w); terr != nil {
+	if tn, terr := writeNode(w, v.Type); terr != nil {
 		err = terr
 		return
 	} else {
@@ -2464,7 +3042,7 @@ func (v Variable) WriteTo(w io.Writer) (n int64, err error) {
 		} else {
 			n += int64(en)
 		}
-		if in, ierr := v.Initializer.WriteTo(w); ierr != nil {
+		if in, ierr := writeNode(w, v.Initializer); ierr != nil {
 			err = ierr
 			return
 		} else {
@@ -2477,11 +3055,111 @@ func (v Variable) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(sn)
 	}
+	if tn, terr := v.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
+	return
+}
+
+// Implements [io.WriterTo] interface for [VariableDeclaration].
+// Field and local variable declarations end with ";", while declarations in other contexts are not terminated.
+func (vd VariableDeclaration) WriteTo(w io.Writer) (n int64, err error) {
+	if ln, lerr := vd.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
+	if vlen := len(vd.Variables); vlen > 0 {
+		if vd.GetModifiers() != nil {
+			if mn, merr := writeNode(w, vd.GetModifiers()); merr != nil {
+				err = merr
+				return
+			} else {
+				n += mn
+			}
+		}
+		typ := vd.GetType()
+		if vd.Variables[0].IsVarargs() {
+			if tn, terr := writeParameterType(w, vd.Variables[0]); terr != nil {
+				err = terr
+				return
+			} else {
+				n += tn
+			}
+		} else if tn, terr := writeNode(w, typ); terr != nil {
+			err = terr
+			return
+		} else {
+			n += tn
+		}
+		for i, v := range vd.Variables {
+			if i > 0 {
+				if cn, cerr := w.Write([]byte(`,`)); cerr != nil {
+					err = cerr
+					return
+				} else {
+					n += int64(cn)
+				}
+			}
+			if nn, nerr := w.Write([]byte(v.GetName())); nerr != nil {
+				err = nerr
+				return
+			} else {
+				n += int64(nn)
+			}
+			for d := arrayDims(typ); d < arrayDims(v.GetType()); d++ {
+				if dn, derr := w.Write([]byte(`[]`)); derr != nil {
+					err = derr
+					return
+				} else {
+					n += int64(dn)
+				}
+			}
+			if v.GetInitializer() != nil {
+				if en, eerr := w.Write([]byte(`=`)); eerr != nil {
+					err = eerr
+					return
+				} else {
+					n += int64(en)
+				}
+				if in, ierr := writeNode(w, v.GetInitializer()); ierr != nil {
+					err = ierr
+					return
+				} else {
+					n += in
+				}
+			}
+		}
+	}
+	if vd.Context == FIELD_CONTEXT || vd.Context == LOCAL_VARIABLE_CONTEXT {
+		if sn, serr := w.Write([]byte(`;`)); serr != nil {
+			err = serr
+			return
+		} else {
+			n += int64(sn)
+		}
+	}
+	if tn, terr := vd.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
 // Implements [io.WriterTo] interface for [WhileLoop].
 func (wl WhileLoop) WriteTo(w io.Writer) (n int64, err error) {
+	if ln, lerr := wl.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
 	if wn, werr := w.Write([]byte(`while`)); werr != nil {
 		err = werr
 		return
@@ -2494,7 +3172,7 @@ func (wl WhileLoop) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(on)
 	}
-	if cn, cerr := wl.Condition.WriteTo(w); cerr != nil {
+	if cn, cerr := writeNode(w, wl.Condition); cerr != nil {
 		err = cerr
 		return
 	} else {
@@ -2506,18 +3184,24 @@ func (wl WhileLoop) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(cn)
 	}
-	if sn, serr := wl.Statement.WriteTo(w); serr != nil {
+	if sn, serr := writeNode(w, wl.Statement); serr != nil {
 		err = serr
 		return
 	} else {
 		n += sn
 	}
+	if tn, terr := wl.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
 // Implements [io.WriterTo] interface for [PostfixIncrement].
 func (pi PostfixIncrement) WriteTo(w io.Writer) (n int64, err error) {
-	if xn, xerr := pi.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeOperand(w, pi.Expression, postfixPrecedence); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -2534,7 +3218,7 @@ func (pi PostfixIncrement) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [PostfixDecrement].
 func (pd PostfixDecrement) WriteTo(w io.Writer) (n int64, err error) {
-	if xn, xerr := pd.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeOperand(w, pd.Expression, postfixPrecedence); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -2557,7 +3241,7 @@ func (pi PrefixIncrement) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(dn)
 	}
-	if xn, xerr := pi.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeOperand(w, pi.Expression, unaryPrecedence); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -2574,7 +3258,7 @@ func (pd PrefixDecrement) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(dn)
 	}
-	if xn, xerr := pd.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeOperand(w, pd.Expression, unaryPrecedence); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -2591,7 +3275,7 @@ func (up UnaryPlus) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(pn)
 	}
-	if xn, xerr := up.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeOperand(w, up.Expression, unaryPrecedence); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -2608,7 +3292,7 @@ func (um UnaryMinus) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(mn)
 	}
-	if xn, xerr := um.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeOperand(w, um.Expression, unaryPrecedence); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -2625,7 +3309,7 @@ func (bc BitwiseComplement) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(bcn)
 	}
-	if xn, xerr := bc.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeOperand(w, bc.Expression, unaryPrecedence); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -2642,7 +3326,7 @@ func (lc LogicalComplement) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(lcn)
 	}
-	if xn, xerr := lc.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeOperand(w, lc.Expression, unaryPrecedence); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -2653,7 +3337,7 @@ func (lc LogicalComplement) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [Multiply].
 func (m Multiply) WriteTo(w io.Writer) (n int64, err error) {
-	if lon, loerr := m.LeftOperand.WriteTo(w); loerr != nil {
+	if lon, loerr := writeOperand(w, m.LeftOperand, precedence(m)); loerr != nil {
 		err = loerr
 		return
 	} else {
@@ -2665,7 +3349,7 @@ func (m Multiply) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(mn)
 	}
-	if ron, roerr := m.RightOperand.WriteTo(w); roerr != nil {
+	if ron, roerr := writeOperand(w, m.RightOperand, precedence(m)+1); roerr != nil {
 		err = roerr
 		return
 	} else {
@@ -2676,7 +3360,7 @@ func (m Multiply) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [Divide].
 func (d Divide) WriteTo(w io.Writer) (n int64, err error) {
-	if lon, loerr := d.LeftOperand.WriteTo(w); loerr != nil {
+	if lon, loerr := writeOperand(w, d.LeftOperand, precedence(d)); loerr != nil {
 		err = loerr
 		return
 	} else {
@@ -2688,7 +3372,7 @@ func (d Divide) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(dn)
 	}
-	if ron, roerr := d.RightOperand.WriteTo(w); roerr != nil {
+	if ron, roerr := writeOperand(w, d.RightOperand, precedence(d)+1); roerr != nil {
 		err = roerr
 		return
 	} else {
@@ -2699,7 +3383,7 @@ func (d Divide) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [Remainder].
 func (r Remainder) WriteTo(w io.Writer) (n int64, err error) {
-	if lon, loerr := r.LeftOperand.WriteTo(w); loerr != nil {
+	if lon, loerr := writeOperand(w, r.LeftOperand, precedence(r)); loerr != nil {
 		err = loerr
 		return
 	} else {
@@ -2711,7 +3395,7 @@ func (r Remainder) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(pn)
 	}
-	if ron, roerr := r.RightOperand.WriteTo(w); roerr != nil {
+	if ron, roerr := writeOperand(w, r.RightOperand, precedence(r)+1); roerr != nil {
 		err = roerr
 		return
 	} else {
@@ -2722,7 +3406,7 @@ func (r Remainder) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [Plus].
 func (p Plus) WriteTo(w io.Writer) (n int64, err error) {
-	if lon, loerr := p.LeftOperand.WriteTo(w); loerr != nil {
+	if lon, loerr := writeOperand(w, p.LeftOperand, precedence(p)); loerr != nil {
 		err = loerr
 		return
 	} else {
@@ -2734,7 +3418,7 @@ func (p Plus) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(pn)
 	}
-	if ron, roerr := p.RightOperand.WriteTo(w); roerr != nil {
+	if ron, roerr := writeOperand(w, p.RightOperand, precedence(p)+1); roerr != nil {
 		err = roerr
 		return
 	} else {
@@ -2745,7 +3429,7 @@ func (p Plus) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [Minus].
 func (m Minus) WriteTo(w io.Writer) (n int64, err error) {
-	if lon, loerr := m.LeftOperand.WriteTo(w); loerr != nil {
+	if lon, loerr := writeOperand(w, m.LeftOperand, precedence(m)); loerr != nil {
 		err = loerr
 		return
 	} else {
@@ -2757,7 +3441,7 @@ func (m Minus) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(mn)
 	}
-	if ron, roerr := m.RightOperand.WriteTo(w); roerr != nil {
+	if ron, roerr := writeOperand(w, m.RightOperand, precedence(m)+1); roerr != nil {
 		err = roerr
 		return
 	} else {
@@ -2768,7 +3452,7 @@ func (m Minus) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [LeftShift].
 func (ls LeftShift) WriteTo(w io.Writer) (n int64, err error) {
-	if lon, loerr := ls.LeftOperand.WriteTo(w); loerr != nil {
+	if lon, loerr := writeOperand(w, ls.LeftOperand, precedence(ls)); loerr != nil {
 		err = loerr
 		return
 	} else {
@@ -2780,7 +3464,7 @@ func (ls LeftShift) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(lsn)
 	}
-	if ron, roerr := ls.RightOperand.WriteTo(w); roerr != nil {
+	if ron, roerr := writeOperand(w, ls.RightOperand, precedence(ls)+1); roerr != nil {
 		err = roerr
 		return
 	} else {
@@ -2791,7 +3475,7 @@ func (ls LeftShift) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [RightShift].
 func (rs RightShift) WriteTo(w io.Writer) (n int64, err error) {
-	if lon, loerr := rs.LeftOperand.WriteTo(w); loerr != nil {
+	if lon, loerr := writeOperand(w, rs.LeftOperand, precedence(rs)); loerr != nil {
 		err = loerr
 		return
 	} else {
@@ -2803,7 +3487,7 @@ func (rs RightShift) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(rsn)
 	}
-	if ron, roerr := rs.RightOperand.WriteTo(w); roerr != nil {
+	if ron, roerr := writeOperand(w, rs.RightOperand, precedence(rs)+1); roerr != nil {
 		err = roerr
 		return
 	} else {
@@ -2814,7 +3498,7 @@ func (rs RightShift) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [UnsignedRightShift].
 func (urs UnsignedRightShift) WriteTo(w io.Writer) (n int64, err error) {
-	if lon, loerr := urs.LeftOperand.WriteTo(w); loerr != nil {
+	if lon, loerr := writeOperand(w, urs.LeftOperand, precedence(urs)); loerr != nil {
 		err = loerr
 		return
 	} else {
@@ -2826,7 +3510,7 @@ func (urs UnsignedRightShift) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(ursn)
 	}
-	if ron, roerr := urs.RightOperand.WriteTo(w); roerr != nil {
+	if ron, roerr := writeOperand(w, urs.RightOperand, precedence(urs)+1); roerr != nil {
 		err = roerr
 		return
 	} else {
@@ -2837,7 +3521,7 @@ func (urs UnsignedRightShift) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [LessThan].
 func (lt LessThan) WriteTo(w io.Writer) (n int64, err error) {
-	if lon, loerr := lt.LeftOperand.WriteTo(w); loerr != nil {
+	if lon, loerr := writeOperand(w, lt.LeftOperand, precedence(lt)); loerr != nil {
 		err = loerr
 		return
 	} else {
@@ -2849,7 +3533,7 @@ func (lt LessThan) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(ltn)
 	}
-	if ron, roerr := lt.RightOperand.WriteTo(w); roerr != nil {
+	if ron, roerr := writeOperand(w, lt.RightOperand, precedence(lt)+1); roerr != nil {
 		err = roerr
 		return
 	} else {
@@ -2860,7 +3544,7 @@ func (lt LessThan) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [GreaterThan].
 func (gt GreaterThan) WriteTo(w io.Writer) (n int64, err error) {
-	if lon, loerr := gt.LeftOperand.WriteTo(w); loerr != nil {
+	if lon, loerr := writeOperand(w, gt.LeftOperand, precedence(gt)); loerr != nil {
 		err = loerr
 		return
 	} else {
@@ -2872,7 +3556,7 @@ func (gt GreaterThan) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(gtn)
 	}
-	if ron, roerr := gt.RightOperand.WriteTo(w); roerr != nil {
+	if ron, roerr := writeOperand(w, gt.RightOperand, precedence(gt)+1); roerr != nil {
 		err = roerr
 		return
 	} else {
@@ -2883,7 +3567,7 @@ func (gt GreaterThan) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [LessThanEqual].
 func (lte LessThanEqual) WriteTo(w io.Writer) (n int64, err error) {
-	if lon, loerr := lte.LeftOperand.WriteTo(w); loerr != nil {
+	if lon, loerr := writeOperand(w, lte.LeftOperand, precedence(lte)); loerr != nil {
 		err = loerr
 		return
 	} else {
@@ -2895,7 +3579,7 @@ func (lte LessThanEqual) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(lten)
 	}
-	if ron, roerr := lte.RightOperand.WriteTo(w); roerr != nil {
+	if ron, roerr := writeOperand(w, lte.RightOperand, precedence(lte)+1); roerr != nil {
 		err = roerr
 		return
 	} else {
@@ -2906,7 +3590,7 @@ func (lte LessThanEqual) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [GreaterThanEqual].
 func (gte GreaterThanEqual) WriteTo(w io.Writer) (n int64, err error) {
-	if lon, loerr := gte.LeftOperand.WriteTo(w); loerr != nil {
+	if lon, loerr := writeOperand(w, gte.LeftOperand, precedence(gte)); loerr != nil {
 		err = loerr
 		return
 	} else {
@@ -2918,7 +3602,7 @@ func (gte GreaterThanEqual) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(gten)
 	}
-	if ron, roerr := gte.RightOperand.WriteTo(w); roerr != nil {
+	if ron, roerr := writeOperand(w, gte.RightOperand, precedence(gte)+1); roerr != nil {
 		err = roerr
 		return
 	} else {
@@ -2929,7 +3613,7 @@ func (gte GreaterThanEqual) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [EqualTo].
 func (eq EqualTo) WriteTo(w io.Writer) (n int64, err error) {
-	if lon, loerr := eq.LeftOperand.WriteTo(w); loerr != nil {
+	if lon, loerr := writeOperand(w, eq.LeftOperand, precedence(eq)); loerr != nil {
 		err = loerr
 		return
 	} else {
@@ -2941,7 +3625,7 @@ func (eq EqualTo) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(eqn)
 	}
-	if ron, roerr := eq.RightOperand.WriteTo(w); roerr != nil {
+	if ron, roerr := writeOperand(w, eq.RightOperand, precedence(eq)+1); roerr != nil {
 		err = roerr
 		return
 	} else {
@@ -2952,7 +3636,7 @@ func (eq EqualTo) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [NotEqualTo].
 func (neq NotEqualTo) WriteTo(w io.Writer) (n int64, err error) {
-	if lon, loerr := neq.LeftOperand.WriteTo(w); loerr != nil {
+	if lon, loerr := writeOperand(w, neq.LeftOperand, precedence(neq)); loerr != nil {
 		err = loerr
 		return
 	} else {
@@ -2964,7 +3648,7 @@ func (neq NotEqualTo) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(neqn)
 	}
-	if ron, roerr := neq.RightOperand.WriteTo(w); roerr != nil {
+	if ron, roerr := writeOperand(w, neq.RightOperand, precedence(neq)+1); roerr != nil {
 		err = roerr
 		return
 	} else {
@@ -2975,7 +3659,7 @@ func (neq NotEqualTo) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [And].
 func (a And) WriteTo(w io.Writer) (n int64, err error) {
-	if lon, loerr := a.LeftOperand.WriteTo(w); loerr != nil {
+	if lon, loerr := writeOperand(w, a.LeftOperand, precedence(a)); loerr != nil {
 		err = loerr
 		return
 	} else {
@@ -2987,7 +3671,7 @@ func (a And) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(an)
 	}
-	if ron, roerr := a.RightOperand.WriteTo(w); roerr != nil {
+	if ron, roerr := writeOperand(w, a.RightOperand, precedence(a)+1); roerr != nil {
 		err = roerr
 		return
 	} else {
@@ -2998,7 +3682,7 @@ func (a And) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [Xor].
 func (x Xor) WriteTo(w io.Writer) (n int64, err error) {
-	if lon, loerr := x.LeftOperand.WriteTo(w); loerr != nil {
+	if lon, loerr := writeOperand(w, x.LeftOperand, precedence(x)); loerr != nil {
 		err = loerr
 		return
 	} else {
@@ -3010,7 +3694,7 @@ func (x Xor) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(xn)
 	}
-	if ron, roerr := x.RightOperand.WriteTo(w); roerr != nil {
+	if ron, roerr := writeOperand(w, x.RightOperand, precedence(x)+1); roerr != nil {
 		err = roerr
 		return
 	} else {
@@ -3021,7 +3705,7 @@ func (x Xor) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [Or].
 func (or Or) WriteTo(w io.Writer) (n int64, err error) {
-	if lon, loerr := or.LeftOperand.WriteTo(w); loerr != nil {
+	if lon, loerr := writeOperand(w, or.LeftOperand, precedence(or)); loerr != nil {
 		err = loerr
 		return
 	} else {
@@ -3033,7 +3717,7 @@ func (or Or) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(pn)
 	}
-	if ron, roerr := or.RightOperand.WriteTo(w); roerr != nil {
+	if ron, roerr := writeOperand(w, or.RightOperand, precedence(or)+1); roerr != nil {
 		err = roerr
 		return
 	} else {
@@ -3044,7 +3728,7 @@ func (or Or) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [ConditionalAnd].
 func (ca ConditionalAnd) WriteTo(w io.Writer) (n int64, err error) {
-	if lon, loerr := ca.LeftOperand.WriteTo(w); loerr != nil {
+	if lon, loerr := writeOperand(w, ca.LeftOperand, precedence(ca)); loerr != nil {
 		err = loerr
 		return
 	} else {
@@ -3056,7 +3740,7 @@ func (ca ConditionalAnd) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(an)
 	}
-	if ron, roerr := ca.RightOperand.WriteTo(w); roerr != nil {
+	if ron, roerr := writeOperand(w, ca.RightOperand, precedence(ca)+1); roerr != nil {
 		err = roerr
 		return
 	} else {
@@ -3067,7 +3751,7 @@ func (ca ConditionalAnd) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [ConditionalOr].
 func (or ConditionalOr) WriteTo(w io.Writer) (n int64, err error) {
-	if lon, loerr := or.LeftOperand.WriteTo(w); loerr != nil {
+	if lon, loerr := writeOperand(w, or.LeftOperand, precedence(or)); loerr != nil {
 		err = loerr
 		return
 	} else {
@@ -3079,7 +3763,7 @@ func (or ConditionalOr) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(pn)
 	}
-	if ron, roerr := or.RightOperand.WriteTo(w); roerr != nil {
+	if ron, roerr := writeOperand(w, or.RightOperand, precedence(or)+1); roerr != nil {
 		err = roerr
 		return
 	} else {
@@ -3090,7 +3774,7 @@ func (or ConditionalOr) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [MultiplyAssignment].
 func (ma MultiplyAssignment) WriteTo(w io.Writer) (n int64, err error) {
-	if vn, verr := ma.Variable.WriteTo(w); verr != nil {
+	if vn, verr := writeNode(w, ma.Variable); verr != nil {
 		err = verr
 		return
 	} else {
@@ -3102,7 +3786,7 @@ func (ma MultiplyAssignment) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(man)
 	}
-	if xn, xerr := ma.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeNode(w, ma.Expression); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -3113,7 +3797,7 @@ func (ma MultiplyAssignment) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [DivideAssignment].
 func (da DivideAssignment) WriteTo(w io.Writer) (n int64, err error) {
-	if vn, verr := da.Variable.WriteTo(w); verr != nil {
+	if vn, verr := writeNode(w, da.Variable); verr != nil {
 		err = verr
 		return
 	} else {
@@ -3125,7 +3809,7 @@ func (da DivideAssignment) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(dan)
 	}
-	if xn, xerr := da.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeNode(w, da.Expression); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -3136,7 +3820,7 @@ func (da DivideAssignment) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [RemainderAssignment].
 func (ra RemainderAssignment) WriteTo(w io.Writer) (n int64, err error) {
-	if vn, verr := ra.Variable.WriteTo(w); verr != nil {
+	if vn, verr := writeNode(w, ra.Variable); verr != nil {
 		err = verr
 		return
 	} else {
@@ -3148,7 +3832,7 @@ func (ra RemainderAssignment) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(ran)
 	}
-	if xn, xerr := ra.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeNode(w, ra.Expression); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -3159,7 +3843,7 @@ func (ra RemainderAssignment) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [PlusAssignment].
 func (pa PlusAssignment) WriteTo(w io.Writer) (n int64, err error) {
-	if vn, verr := pa.Variable.WriteTo(w); verr != nil {
+	if vn, verr := writeNode(w, pa.Variable); verr != nil {
 		err = verr
 		return
 	} else {
@@ -3171,7 +3855,7 @@ func (pa PlusAssignment) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(pan)
 	}
-	if xn, xerr := pa.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeNode(w, pa.Expression); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -3182,7 +3866,7 @@ func (pa PlusAssignment) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [MinusAssignment].
 func (ma MinusAssignment) WriteTo(w io.Writer) (n int64, err error) {
-	if vn, verr := ma.Variable.WriteTo(w); verr != nil {
+	if vn, verr := writeNode(w, ma.Variable); verr != nil {
 		err = verr
 		return
 	} else {
@@ -3194,7 +3878,7 @@ func (ma MinusAssignment) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(man)
 	}
-	if xn, xerr := ma.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeNode(w, ma.Expression); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -3205,7 +3889,7 @@ func (ma MinusAssignment) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [LeftShiftAssignment].
 func (lsa LeftShiftAssignment) WriteTo(w io.Writer) (n int64, err error) {
-	if vn, verr := lsa.Variable.WriteTo(w); verr != nil {
+	if vn, verr := writeNode(w, lsa.Variable); verr != nil {
 		err = verr
 		return
 	} else {
@@ -3217,7 +3901,7 @@ func (lsa LeftShiftAssignment) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(lsan)
 	}
-	if xn, xerr := lsa.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeNode(w, lsa.Expression); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -3228,7 +3912,7 @@ func (lsa LeftShiftAssignment) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [RightShiftAssignment].
 func (rsa RightShiftAssignment) WriteTo(w io.Writer) (n int64, err error) {
-	if vn, verr := rsa.Variable.WriteTo(w); verr != nil {
+	if vn, verr := writeNode(w, rsa.Variable); verr != nil {
 		err = verr
 		return
 	} else {
@@ -3240,7 +3924,7 @@ func (rsa RightShiftAssignment) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(rsan)
 	}
-	if xn, xerr := rsa.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeNode(w, rsa.Expression); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -3251,7 +3935,7 @@ func (rsa RightShiftAssignment) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [UnsignedRightShiftAssignment].
 func (ursa UnsignedRightShiftAssignment) WriteTo(w io.Writer) (n int64, err error) {
-	if vn, verr := ursa.Variable.WriteTo(w); verr != nil {
+	if vn, verr := writeNode(w, ursa.Variable); verr != nil {
 		err = verr
 		return
 	} else {
@@ -3263,7 +3947,7 @@ func (ursa UnsignedRightShiftAssignment) WriteTo(w io.Writer) (n int64, err erro
 	} else {
 		n += int64(ursan)
 	}
-	if xn, xerr := ursa.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeNode(w, ursa.Expression); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -3274,7 +3958,7 @@ func (ursa UnsignedRightShiftAssignment) WriteTo(w io.Writer) (n int64, err erro
 
 // Implements [io.WriterTo] interface for [AndAssignment].
 func (aa AndAssignment) WriteTo(w io.Writer) (n int64, err error) {
-	if vn, verr := aa.Variable.WriteTo(w); verr != nil {
+	if vn, verr := writeNode(w, aa.Variable); verr != nil {
 		err = verr
 		return
 	} else {
@@ -3286,7 +3970,7 @@ func (aa AndAssignment) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(aan)
 	}
-	if xn, xerr := aa.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeNode(w, aa.Expression); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -3297,7 +3981,7 @@ func (aa AndAssignment) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [XorAssignment].
 func (xa XorAssignment) WriteTo(w io.Writer) (n int64, err error) {
-	if vn, verr := xa.Variable.WriteTo(w); verr != nil {
+	if vn, verr := writeNode(w, xa.Variable); verr != nil {
 		err = verr
 		return
 	} else {
@@ -3309,7 +3993,7 @@ func (xa XorAssignment) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(xan)
 	}
-	if xn, xerr := xa.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeNode(w, xa.Expression); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -3320,7 +4004,7 @@ func (xa XorAssignment) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [OrAssignment].
 func (oa OrAssignment) WriteTo(w io.Writer) (n int64, err error) {
-	if vn, verr := oa.Variable.WriteTo(w); verr != nil {
+	if vn, verr := writeNode(w, oa.Variable); verr != nil {
 		err = verr
 		return
 	} else {
@@ -3332,7 +4016,7 @@ func (oa OrAssignment) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(oan)
 	}
-	if xn, xerr := oa.Expression.WriteTo(w); xerr != nil {
+	if xn, xerr := writeNode(w, oa.Expression); xerr != nil {
 		err = xerr
 		return
 	} else {
@@ -3407,7 +4091,16 @@ func (bl BooleanLiteral) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [CharLiteral].
 func (cl CharLiteral) WriteTo(w io.Writer) (n int64, err error) {
-	if cln, clerr := w.Write([]byte(`'` + cl.Value + `'`)); clerr != nil {
+	r, size := lexer.DecodeRuneInString(cl.Value)
+	if size == 0 || size != len(cl.Value) {
+		err = fmt.Errorf("char literal value %q is not a single character", cl.Value)
+		return
+	}
+	text, err := lexer.QuoteChar(r, cl.ASCII)
+	if err != nil {
+		return
+	}
+	if cln, clerr := w.Write([]byte(text)); clerr != nil {
 		err = clerr
 		return
 	} else {
@@ -3418,7 +4111,7 @@ func (cl CharLiteral) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [StringLiteral].
 func (sl StringLiteral) WriteTo(w io.Writer) (n int64, err error) {
-	if sln, slerr := w.Write([]byte(`"` + strings.ReplaceAll(sl.Value, "\"", "\\\"") + `"`)); slerr != nil {
+	if sln, slerr := w.Write([]byte(lexer.QuoteString(sl.Value, sl.ASCII))); slerr != nil {
 		err = slerr
 		return
 	} else {
@@ -3427,6 +4120,17 @@ func (sl StringLiteral) WriteTo(w io.Writer) (n int64, err error) {
 	return
 }
 
+// Implements [io.WriterTo] interface for [TextBlockLiteral].
+func (tbl TextBlockLiteral) WriteTo(w io.Writer) (n int64, err error) {
+	if tbln, tblerr := w.Write([]byte(lexer.QuoteTextBlock(tbl.Value, tbl.ASCII))); tblerr != nil {
+		err = tblerr
+		return
+	} else {
+		n += int64(tbln)
+	}
+	return
+}
+
 // Implements [io.WriterTo] interface for [NullLiteral].
 func (NullLiteral) WriteTo(w io.Writer) (n int64, err error) {
 	if nln, nlerr := w.Write([]byte(`null`)); nlerr != nil {
@@ -3463,7 +4167,7 @@ func (xw ExtendsWildcard) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(en)
 	}
-	if bn, berr := xw.Bound.WriteTo(w); berr != nil {
+	if bn, berr := writeNode(w, xw.Bound); berr != nil {
 		err = berr
 		return
 	} else {
@@ -3486,7 +4190,7 @@ func (sw SuperWildcard) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(sn)
 	}
-	if bn, berr := sw.Bound.WriteTo(w); berr != nil {
+	if bn, berr := writeNode(w, sw.Bound); berr != nil {
 		err = berr
 		return
 	} else {
@@ -3503,7 +4207,13 @@ func (Erroneous) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [Interface].
 func (i Interface) WriteTo(w io.Writer) (n int64, err error) {
-	if mn, merr := i.Modifiers.WriteTo(w); merr != nil {
+	if ln, lerr := i.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
+	if mn, merr := writeNode(w, i.Modifiers); merr != nil {
 		err = merr
 		return
 	} else {
@@ -3529,7 +4239,7 @@ func (i Interface) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(on)
 		}
 		for j := 0; j < tplen-1; j++ {
-			if tpn, tperr := i.TypeParameters[j].WriteTo(w); tperr != nil {
+			if tpn, tperr := writeNode(w, i.TypeParameters[j]); tperr != nil {
 				err = tperr
 				return
 			} else {
@@ -3542,7 +4252,7 @@ func (i Interface) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(cn)
 			}
 		}
-		if tpn, tperr := i.TypeParameters[tplen-1].WriteTo(w); tperr != nil {
+		if tpn, tperr := writeNode(w, i.TypeParameters[tplen-1]); tperr != nil {
 			err = tperr
 			return
 		} else {
@@ -3555,14 +4265,28 @@ func (i Interface) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(cn)
 		}
 	}
-	if i.ExtendsClause != nil {
+	if eclen := len(i.ExtendsClause); eclen > 0 {
 		if en, eerr := w.Write([]byte(`extends`)); eerr != nil {
 			err = eerr
 			return
 		} else {
 			n += int64(en)
 		}
-		if ecn, ecerr := i.ExtendsClause.WriteTo(w); ecerr != nil {
+		for j := 0; j < eclen-1; j++ {
+			if ecn, ecerr := writeNode(w, i.ExtendsClause[j]); ecerr != nil {
+				err = ecerr
+				return
+			} else {
+				n += ecn
+			}
+			if cn, cerr := w.Write([]byte(`,`)); cerr != nil {
+				err = cerr
+				return
+			} else {
+				n += int64(cn)
+			}
+		}
+		if ecn, ecerr := writeNode(w, i.ExtendsClause[eclen-1]); ecerr != nil {
 			err = ecerr
 			return
 		} else {
@@ -3577,7 +4301,7 @@ func (i Interface) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(pn)
 		}
 		for j := 0; j < pclen-1; j++ {
-			if pcn, pcerr := i.PermitsClause[j].WriteTo(w); pcerr != nil {
+			if pcn, pcerr := writeNode(w, i.PermitsClause[j]); pcerr != nil {
 				err = pcerr
 				return
 			} else {
@@ -3590,7 +4314,7 @@ func (i Interface) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(cn)
 			}
 		}
-		if pcn, pcerr := i.PermitsClause[pclen-1].WriteTo(w); pcerr != nil {
+		if pcn, pcerr := writeNode(w, i.PermitsClause[pclen-1]); pcerr != nil {
 			err = pcerr
 			return
 		} else {
@@ -3604,26 +4328,51 @@ func (i Interface) WriteTo(w io.Writer) (n int64, err error) {
 		n += int64(on)
 	}
 	for _, member := range i.Members {
-		if mn, merr := member.WriteTo(w); merr != nil {
+		if mn, merr := writeNode(w, member); merr != nil {
 			err = merr
 			return
 		} else {
 			n += mn
 		}
 	}
+	if dn, derr := writeDangling(w, i.Dangling); derr != nil {
+		err = derr
+		return
+	} else {
+		n += dn
+	}
 	if cn, cerr := w.Write([]byte(`}`)); cerr != nil {
 		err = cerr
 		return
 	} else {
 		n += int64(cn)
 	}
+	if tn, terr := i.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
 // Implements [io.WriterTo] interface for [Enum].
+// An enum constant among the members is an error, since it would be written after the constant list.
 func (e Enum) WriteTo(w io.Writer) (n int64, err error) {
-	if mn, merr := e.Modifiers.WriteTo(w); merr != nil {
-		err = merr
+	for _, member := range e.Members {
+		if ec, ok := member.(EnumConstantNode); ok {
+			err = fmt.Errorf("enum constant %s of enum %s is a member rather than a constant", ec.GetName(), e.SimpleName)
+			return
+		}
+	}
+	if ln, lerr := e.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
+	if mn, merr := writeNode(w, e.Modifiers); merr != nil {
+		err = merr
 		return
 	} else {
 		n += mn
@@ -3640,32 +4389,118 @@ func (e Enum) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(snn)
 	}
+	if iclen := len(e.ImplementsClause); iclen > 0 {
+		if in, ierr := w.Write([]byte(`implements`)); ierr != nil {
+			err = ierr
+			return
+		} else {
+			n += int64(in)
+		}
+		for i := 0; i < iclen-1; i++ {
+			if icn, icerr := writeNode(w, e.ImplementsClause[i]); icerr != nil {
+				err = icerr
+				return
+			} else {
+				n += icn
+			}
+			if cn, cerr := w.Write([]byte(`,`)); cerr != nil {
+				err = cerr
+				return
+			} else {
+				n += int64(cn)
+			}
+		}
+		if icn, icerr := writeNode(w, e.ImplementsClause[iclen-1]); icerr != nil {
+			err = icerr
+			return
+		} else {
+			n += icn
+		}
+	}
 	if on, oerr := w.Write([]byte(`{`)); oerr != nil {
 		err = oerr
 		return
 	} else {
 		n += int64(on)
 	}
+	// The constants are separated by commas and, if any members follow, terminated by a semicolon.
+	for i, constant := range e.Constants {
+		if cn, cerr := writeNode(w, constant); cerr != nil {
+			err = cerr
+			return
+		} else {
+			n += cn
+		}
+		if i < len(e.Constants)-1 {
+			if cn, cerr := w.Write([]byte(`,`)); cerr != nil {
+				err = cerr
+				return
+			} else {
+				n += int64(cn)
+			}
+		} else if len(e.Members) > 0 {
+			if sn, serr := w.Write([]byte(`;`)); serr != nil {
+				err = serr
+				return
+			} else {
+				n += int64(sn)
+			}
+		}
+		if cn, ok := constant.(CommentedNode); ok {
+			if tn, terr := cn.GetComments().writeTrailing(w); terr != nil {
+				err = terr
+				return
+			} else {
+				n += tn
+			}
+		}
+	}
+	if len(e.Constants) == 0 && len(e.Members) > 0 {
+		if sn, serr := w.Write([]byte(`;`)); serr != nil {
+			err = serr
+			return
+		} else {
+			n += int64(sn)
+		}
+	}
 	for _, member := range e.Members {
-		if mn, merr := member.WriteTo(w); merr != nil {
+		if mn, merr := writeNode(w, member); merr != nil {
 			err = merr
 			return
 		} else {
 			n += mn
 		}
 	}
+	if dn, derr := writeDangling(w, e.Dangling); derr != nil {
+		err = derr
+		return
+	} else {
+		n += dn
+	}
 	if cn, cerr := w.Write([]byte(`}`)); cerr != nil {
 		err = cerr
 		return
 	} else {
 		n += int64(cn)
 	}
+	if tn, terr := e.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
 // Implements [io.WriterTo] interface for [AnnotationType].
 func (at AnnotationType) WriteTo(w io.Writer) (n int64, err error) {
-	if mn, merr := at.Modifiers.WriteTo(w); merr != nil {
+	if ln, lerr := at.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
+	if mn, merr := writeNode(w, at.Modifiers); merr != nil {
 		err = merr
 		return
 	} else {
@@ -3690,26 +4525,38 @@ func (at AnnotationType) WriteTo(w io.Writer) (n int64, err error) {
 		n += int64(on)
 	}
 	for _, member := range at.Members {
-		if mn, merr := member.WriteTo(w); merr != nil {
+		if mn, merr := writeNode(w, member); merr != nil {
 			err = merr
 			return
 		} else {
 			n += mn
 		}
 	}
+	if dn, derr := writeDangling(w, at.Dangling); derr != nil {
+		err = derr
+		return
+	} else {
+		n += dn
+	}
 	if cn, cerr := w.Write([]byte(`}`)); cerr != nil {
 		err = cerr
 		return
 	} else {
 		n += int64(cn)
 	}
+	if tn, terr := at.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
 // Implements [io.WriterTo] interface for [Module].
 func (m Module) WriteTo(w io.Writer) (n int64, err error) {
 	for _, annotation := range m.Annotations {
-		if an, aerr := annotation.WriteTo(w); aerr != nil {
+		if an, aerr := writeNode(w, annotation); aerr != nil {
 			err = aerr
 			return
 		} else {
@@ -3730,7 +4577,7 @@ func (m Module) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(mn)
 	}
-	if nn, nerr := m.Name.WriteTo(w); nerr != nil {
+	if nn, nerr := writeNode(w, m.Name); nerr != nil {
 		err = nerr
 		return
 	} else {
@@ -3743,7 +4590,7 @@ func (m Module) WriteTo(w io.Writer) (n int64, err error) {
 		n += int64(on)
 	}
 	for _, directive := range m.Directives {
-		if dn, derr := directive.WriteTo(w); derr != nil {
+		if dn, derr := writeNode(w, directive); derr != nil {
 			err = derr
 			return
 		} else {
@@ -3767,7 +4614,7 @@ func (x Exports) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(xn)
 	}
-	if pnn, pnerr := x.PackageName.WriteTo(w); pnerr != nil {
+	if pnn, pnerr := writeNode(w, x.PackageName); pnerr != nil {
 		err = pnerr
 		return
 	} else {
@@ -3781,7 +4628,7 @@ func (x Exports) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(tn)
 		}
 		for i := 0; i < mnlen-1; i++ {
-			if mnn, mnerr := x.ModuleNames[i].WriteTo(w); mnerr != nil {
+			if mnn, mnerr := writeNode(w, x.ModuleNames[i]); mnerr != nil {
 				err = mnerr
 				return
 			} else {
@@ -3794,7 +4641,7 @@ func (x Exports) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(cn)
 			}
 		}
-		if mnn, mnerr := x.ModuleNames[mnlen-1].WriteTo(w); mnerr != nil {
+		if mnn, mnerr := writeNode(w, x.ModuleNames[mnlen-1]); mnerr != nil {
 			err = mnerr
 			return
 		} else {
@@ -3818,7 +4665,7 @@ func (o Opens) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(on)
 	}
-	if pnn, pnerr := o.PackageName.WriteTo(w); pnerr != nil {
+	if pnn, pnerr := writeNode(w, o.PackageName); pnerr != nil {
 		err = pnerr
 		return
 	} else {
@@ -3832,7 +4679,7 @@ func (o Opens) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(tn)
 		}
 		for i := 0; i < mnlen-1; i++ {
-			if mnn, mnerr := o.ModuleNames[i].WriteTo(w); mnerr != nil {
+			if mnn, mnerr := writeNode(w, o.ModuleNames[i]); mnerr != nil {
 				err = mnerr
 				return
 			} else {
@@ -3845,7 +4692,7 @@ func (o Opens) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(cn)
 			}
 		}
-		if mnn, mnerr := o.ModuleNames[mnlen-1].WriteTo(w); mnerr != nil {
+		if mnn, mnerr := writeNode(w, o.ModuleNames[mnlen-1]); mnerr != nil {
 			err = mnerr
 			return
 		} else {
@@ -3869,7 +4716,7 @@ func (p Provides) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(pn)
 	}
-	if snn, snerr := p.ServiceName.WriteTo(w); snerr != nil {
+	if snn, snerr := writeNode(w, p.ServiceName); snerr != nil {
 		err = snerr
 		return
 	} else {
@@ -3883,7 +4730,7 @@ func (p Provides) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(tn)
 		}
 		for i := 0; i < inlen-1; i++ {
-			if inn, inerr := p.ImplementationNames[i].WriteTo(w); inerr != nil {
+			if inn, inerr := writeNode(w, p.ImplementationNames[i]); inerr != nil {
 				err = inerr
 				return
 			} else {
@@ -3896,7 +4743,7 @@ func (p Provides) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(cn)
 			}
 		}
-		if inn, inerr := p.ImplementationNames[inlen-1].WriteTo(w); inerr != nil {
+		if inn, inerr := writeNode(w, p.ImplementationNames[inlen-1]); inerr != nil {
 			err = inerr
 			return
 		} else {
@@ -3914,7 +4761,13 @@ func (p Provides) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [Record].
 func (r Record) WriteTo(w io.Writer) (n int64, err error) {
-	if mn, merr := r.Modifiers.WriteTo(w); merr != nil {
+	if ln, lerr := r.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
+	if mn, merr := writeNode(w, r.Modifiers); merr != nil {
 		err = merr
 		return
 	} else {
@@ -3932,6 +4785,82 @@ func (r Record) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(snn)
 	}
+	if tplen := len(r.TypeParameters); tplen > 0 {
+		if on, oerr := w.Write([]byte(`<`)); oerr != nil {
+			err = oerr
+			return
+		} else {
+			n += int64(on)
+		}
+		for i := 0; i < tplen-1; i++ {
+			if tpn, tperr := writeNode(w, r.TypeParameters[i]); tperr != nil {
+				err = tperr
+				return
+			} else {
+				n += tpn
+			}
+			if cn, cerr := w.Write([]byte(`,`)); cerr != nil {
+				err = cerr
+				return
+			} else {
+				n += int64(cn)
+			}
+		}
+		if tpn, tperr := writeNode(w, r.TypeParameters[tplen-1]); tperr != nil {
+			err = tperr
+			return
+		} else {
+			n += tpn
+		}
+		if cn, cerr := w.Write([]byte(`>`)); cerr != nil {
+			err = cerr
+			return
+		} else {
+			n += int64(cn)
+		}
+	}
+	if on, oerr := w.Write([]byte(`(`)); oerr != nil {
+		err = oerr
+		return
+	} else {
+		n += int64(on)
+	}
+	for i, component := range r.Components {
+		if i > 0 {
+			if cn, cerr := w.Write([]byte(`,`)); cerr != nil {
+				err = cerr
+				return
+			} else {
+				n += int64(cn)
+			}
+		}
+		if component.GetModifiers() != nil {
+			if mn, merr := writeNode(w, component.GetModifiers()); merr != nil {
+				err = merr
+				return
+			} else {
+				n += mn
+			}
+		}
+		if tn, terr := writeParameterType(w, component); terr != nil {
+			err = terr
+			return
+		} else {
+			n += tn
+		}
+		if cn, cerr := w.Write([]byte(component.GetName())); cerr != nil {
+			err = cerr
+			return
+		} else {
+			n += int64(cn)
+		}
+	}
+	if cn, cerr := w.Write([]byte(`)`)); cerr != nil {
+		err = cerr
+		return
+	} else {
+		n += int64(cn)
+	}
 	if iclen := len(r.ImplementsClause); iclen > 0 {
 		if in, ierr := w.Write([]byte(`implements`)); ierr != nil {
 			err = ierr
@@ -3940,7 +4869,7 @@ func (r Record) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(in)
 		}
 		for i := 0; i < iclen-1; i++ {
-			if icn, icerr := r.ImplementsClause[i].WriteTo(w); icerr != nil {
+			if icn, icerr := writeNode(w, r.ImplementsClause[i]); icerr != nil {
 				err = icerr
 				return
 			} else {
@@ -3953,7 +4882,7 @@ func (r Record) WriteTo(w io.Writer) (n int64, err error) {
 				n += int64(cn)
 			}
 		}
-		if icn, icerr := r.ImplementsClause[iclen-1].WriteTo(w); icerr != nil {
+		if icn, icerr := writeNode(w, r.ImplementsClause[iclen-1]); icerr != nil {
 			err = icerr
 			return
 		} else {
@@ -3967,19 +4896,31 @@ func (r Record) WriteTo(w io.Writer) (n int64, err error) {
 		n += int64(on)
 	}
 	for _, member := range r.Members {
-		if mn, merr := member.WriteTo(w); merr != nil {
+		if mn, merr := writeNode(w, member); merr != nil {
 			err = merr
 			return
 		} else {
 			n += mn
 		}
 	}
+	if dn, derr := writeDangling(w, r.Dangling); derr != nil {
+		err = derr
+		return
+	} else {
+		n += dn
+	}
 	if cn, cerr := w.Write([]byte(`}`)); cerr != nil {
 		err = cerr
 		return
 	} else {
 		n += int64(cn)
 	}
+	if tn, terr := r.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
 	return
 }
 
@@ -4007,7 +4948,7 @@ func (r Requires) WriteTo(w io.Writer) (n int64, err error) {
 			n += int64(tn)
 		}
 	}
-	if mnn, mnerr := r.ModuleName.WriteTo(w); mnerr != nil {
+	if mnn, mnerr := writeNode(w, r.ModuleName); mnerr != nil {
 		err = mnerr
 		return
 	} else {
@@ -4030,7 +4971,7 @@ func (u Uses) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(un)
 	}
-	if snn, snerr := u.ServiceName.WriteTo(w); snerr != nil {
+	if snn, snerr := writeNode(w, u.ServiceName); snerr != nil {
 		err = snerr
 		return
 	} else {
@@ -4047,13 +4988,19 @@ func (u Uses) WriteTo(w io.Writer) (n int64, err error) {
 
 // Implements [io.WriterTo] interface for [Yield].
 func (y Yield) WriteTo(w io.Writer) (n int64, err error) {
+	if ln, lerr := y.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
 	if yn, yerr := w.Write([]byte(`yield`)); yerr != nil {
 		err = yerr
 		return
 	} else {
 		n += int64(yn)
 	}
-	if vn, verr := y.Value.WriteTo(w); verr != nil {
+	if vn, verr := writeNode(w, y.Value); verr != nil {
 		err = verr
 		return
 	} else {
@@ -4065,5 +5012,168 @@ func (y Yield) WriteTo(w io.Writer) (n int64, err error) {
 	} else {
 		n += int64(sn)
 	}
+	if tn, terr := y.Comments.writeTrailing(w); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
+	return
+}
+
+// Implements [io.WriterTo] interface for [EnumConstant].
+// The trailing comments are not written, since [Enum] writes them after the separator following the constant.
+func (ec EnumConstant) WriteTo(w io.Writer) (n int64, err error) {
+	if ln, lerr := ec.Comments.writeLeading(w); lerr != nil {
+		err = lerr
+		return
+	} else {
+		n += ln
+	}
+	for _, annotation := range ec.Annotations {
+		if an, aerr := writeNode(w, annotation); aerr != nil {
+			err = aerr
+			return
+		} else {
+			n += an
+		}
+	}
+	if nn, nerr := w.Write([]byte(ec.Name)); nerr != nil {
+		err = nerr
+		return
+	} else {
+		n += int64(nn)
+	}
+	if ec.Arguments != nil {
+		if on, oerr := w.Write([]byte(`(`)); oerr != nil {
+			err = oerr
+			return
+		} else {
+			n += int64(on)
+		}
+		if alen := len(ec.Arguments); alen > 0 {
+			for i := 0; i < alen-1; i++ {
+				if an, aerr := writeNode(w, ec.Arguments[i]); aerr != nil {
+					err = aerr
+					return
+				} else {
+					n += an
+				}
+				if cn, cerr := w.Write([]byte(`,`)); cerr != nil {
+					err = cerr
+					return
+				} else {
+					n += int64(cn)
+				}
+			}
+			if an, aerr := writeNode(w, ec.Arguments[alen-1]); aerr != nil {
+				err = aerr
+				return
+			} else {
+				n += an
+			}
+		}
+		if cn, cerr := w.Write([]byte(`)`)); cerr != nil {
+			err = cerr
+			return
+		} else {
+			n += int64(cn)
+		}
+	}
+	if ec.ClassBody != nil {
+		if on, oerr := w.Write([]byte(`{`)); oerr != nil {
+			err = oerr
+			return
+		} else {
+			n += int64(on)
+		}
+		for _, member := range ec.ClassBody.GetMembers() {
+			if mn, merr := writeNode(w, member); merr != nil {
+				err = merr
+				return
+			} else {
+				n += mn
+			}
+		}
+		if cn, cerr := w.Write([]byte(`}`)); cerr != nil {
+			err = cerr
+			return
+		} else {
+			n += int64(cn)
+		}
+	}
+	return
+}
+
+// Implements [io.WriterTo] interface for [ClassLiteral].
+func (cl ClassLiteral) WriteTo(w io.Writer) (n int64, err error) {
+	if tn, terr := writeNode(w, cl.Type); terr != nil {
+		err = terr
+		return
+	} else {
+		n += tn
+	}
+	if dn, derr := w.Write([]byte(`.`)); derr != nil {
+		err = derr
+		return
+	} else {
+		n += int64(dn)
+	}
+	if cn, cerr := w.Write([]byte(`class`)); cerr != nil {
+		err = cerr
+		return
+	} else {
+		n += int64(cn)
+	}
+	return
+}
+
+// Implements [io.WriterTo] interface for [This].
+func (t This) WriteTo(w io.Writer) (n int64, err error) {
+	if t.Qualifier != nil {
+		if qn, qerr := writeOperand(w, t.Qualifier, primaryPrecedence); qerr != nil {
+			err = qerr
+			return
+		} else {
+			n += qn
+		}
+		if dn, derr := w.Write([]byte(`.`)); derr != nil {
+			err = derr
+			return
+		} else {
+			n += int64(dn)
+		}
+	}
+	if tn, terr := w.Write([]byte(`this`)); terr != nil {
+		err = terr
+		return
+	} else {
+		n += int64(tn)
+	}
+	return
+}
+
+// Implements [io.WriterTo] interface for [Super].
+func (s Super) WriteTo(w io.Writer) (n int64, err error) {
+	if s.Qualifier != nil {
+		if qn, qerr := writeOperand(w, s.Qualifier, primaryPrecedence); qerr != nil {
+			err = qerr
+			return
+		} else {
+			n += qn
+		}
+		if dn, derr := w.Write([]byte(`.`)); derr != nil {
+			err = derr
+			return
+		} else {
+			n += int64(dn)
+		}
+	}
+	if sn, serr := w.Write([]byte(`super`)); serr != nil {
+		err = serr
+		return
+	} else {
+		n += int64(sn)
+	}
 	return
 }
@@ -244,8 +244,12 @@ func TestStatementCase_WriteTo(t *testing.T) {
 	t.Parallel()
 	sw := SpaceWriter{}
 	sc := javast.StatementCase{
-		Expression: javast.Identifier{
-			Name: "CONST",
+		Labels: []javast.CaseLabelNode{
+			javast.ConstantCaseLabel{
+				ConstantExpression: javast.Identifier{
+					Name: "CONST",
+				},
+			},
 		},
 		Statements: []javast.StatementNode{
 			javast.ExpressionStatement{
@@ -1071,6 +1075,43 @@ func TestNewArray_WriteTo(t *testing.T) {
 	}
 }
 
+func TestNewArray_WriteTo_Initializer(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	na := javast.NewArray{
+		Dimensions: []javast.ExpressionNode{
+			javast.IntLiteral{
+				Value: "2",
+			},
+		},
+		Initializers: []javast.ExpressionNode{
+			javast.NewArray{
+				Initializers: []javast.ExpressionNode{
+					javast.IntLiteral{
+						Value: "1",
+					},
+				},
+			},
+			javast.NewArray{
+				Initializers: []javast.ExpressionNode{
+					javast.IntLiteral{
+						Value: "2",
+					},
+					javast.NewArray{},
+				},
+			},
+		},
+	}
+	if _, err := na.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "{ { 1 } , { 2 , { } } }"
+	if got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+}
+
 func TestNewClass_WriteTo(t *testing.T) {
 	t.Parallel()
 	sw := SpaceWriter{}
@@ -1351,6 +1392,83 @@ func TestDefaultCaseLabel_WriteTo(t *testing.T) {
 	}
 }
 
+func TestRecordPattern_WriteTo(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	rp := javast.RecordPattern{
+		Deconstructor: javast.Identifier{
+			Name: "Point",
+		},
+		NestedPatterns: []javast.PatternNode{
+			javast.BindingPattern{
+				Variable: javast.Variable{
+					Name: "x",
+					Type: javast.PrimitiveType{
+						PrimitiveTypeKind: javast.INT_TYPE_KIND,
+					},
+				},
+			},
+			javast.BindingPattern{
+				Variable: javast.Variable{
+					Name: "y",
+					Type: javast.Identifier{
+						Name: "var",
+					},
+				},
+			},
+		},
+	}
+	if _, err := rp.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "Point ( int x , var y )"
+	if got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+}
+
+func TestConstantCaseLabel_WriteTo(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	ccl := javast.ConstantCaseLabel{
+		ConstantExpression: javast.Identifier{
+			Name: "RED",
+		},
+	}
+	if _, err := ccl.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "RED"
+	if got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+}
+
+func TestPatternCaseLabel_WriteTo(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	pcl := javast.PatternCaseLabel{
+		Pattern: javast.BindingPattern{
+			Variable: javast.Variable{
+				Name: "s",
+				Type: javast.Identifier{
+					Name: "String",
+				},
+			},
+		},
+	}
+	if _, err := pcl.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "String s"
+	if got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+}
+
 func TestPrimitiveType_WriteTo(t *testing.T) {
 	t.Parallel()
 	sw := SpaceWriter{}
@@ -1422,8 +1540,12 @@ func TestSwitch_WriteTo(t *testing.T) {
 		},
 		Cases: []javast.CaseNode{
 			javast.StatementCase{
-				Expression: javast.IntLiteral{
-					Value: "1",
+				Labels: []javast.CaseLabelNode{
+					javast.ConstantCaseLabel{
+						ConstantExpression: javast.IntLiteral{
+							Value: "1",
+						},
+					},
 				},
 				Statements: []javast.StatementNode{
 					javast.ExpressionStatement{
@@ -1442,7 +1564,6 @@ func TestSwitch_WriteTo(t *testing.T) {
 				},
 			},
 			javast.StatementCase{
-				Expression: nil,
 				Statements: []javast.StatementNode{
 					javast.ExpressionStatement{
 						Expression: javast.Assignment{
@@ -1477,8 +1598,12 @@ func TestSwitchExpression_WriteTo(t *testing.T) {
 		},
 		Cases: []javast.CaseNode{
 			javast.StatementCase{
-				Expression: javast.IntLiteral{
-					Value: "1",
+				Labels: []javast.CaseLabelNode{
+					javast.ConstantCaseLabel{
+						ConstantExpression: javast.IntLiteral{
+							Value: "1",
+						},
+					},
 				},
 				Statements: []javast.StatementNode{
 					javast.ExpressionStatement{
@@ -1497,7 +1622,6 @@ func TestSwitchExpression_WriteTo(t *testing.T) {
 				},
 			},
 			javast.StatementCase{
-				Expression: nil,
 				Statements: []javast.StatementNode{
 					javast.ExpressionStatement{
 						Expression: javast.Assignment{
@@ -1811,6 +1935,87 @@ func TestVariable_WriteTo(t *testing.T) {
 	}
 }
 
+func TestVariableDeclaration_WriteTo(t *testing.T) {
+	t.Parallel()
+	modifiers := javast.Modifiers{
+		Flags: []javast.Modifier{
+			javast.FINAL_MODIFIER,
+		},
+	}
+	variables := []javast.VariableNode{
+		javast.Variable{
+			Modifiers: modifiers,
+			Name:      "a",
+			Type: javast.PrimitiveType{
+				PrimitiveTypeKind: javast.INT_TYPE_KIND,
+			},
+			Initializer: javast.IntLiteral{
+				Value: "1",
+			},
+		},
+		javast.Variable{
+			Modifiers: modifiers,
+			Name:      "b",
+			Type: javast.PrimitiveType{
+				PrimitiveTypeKind: javast.INT_TYPE_KIND,
+			},
+		},
+		javast.Variable{
+			Modifiers: modifiers,
+			Name:      "c",
+			Type: javast.ArrayType{
+				Type: javast.PrimitiveType{
+					PrimitiveTypeKind: javast.INT_TYPE_KIND,
+				},
+			},
+			Initializer: javast.NewArray{
+				Initializers: []javast.ExpressionNode{},
+			},
+		},
+	}
+	tests := map[javast.DeclarationContext]string{
+		javast.FIELD_CONTEXT:          "final int a = 1 , b , c [] = { } ;",
+		javast.LOCAL_VARIABLE_CONTEXT: "final int a = 1 , b , c [] = { } ;",
+		javast.FOR_INIT_CONTEXT:       "final int a = 1 , b , c [] = { }",
+	}
+	for context, want := range tests {
+		sw := SpaceWriter{}
+		vd := javast.VariableDeclaration{
+			Context:   context,
+			Variables: variables,
+		}
+		if _, err := vd.WriteTo(&sw); err != nil {
+			t.Error(err)
+		}
+		if got := sw.String(); got != want {
+			t.Errorf("sw.String() = %s, want %s", got, want)
+		}
+	}
+	sw := SpaceWriter{}
+	vd := javast.VariableDeclaration{
+		Context: javast.PARAMETER_CONTEXT,
+		Variables: []javast.VariableNode{
+			javast.Variable{
+				Name: "args",
+				Type: javast.ArrayType{
+					Type: javast.Identifier{
+						Name: "String",
+					},
+				},
+				Varargs: true,
+			},
+		},
+	}
+	if _, err := vd.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "String ... args"
+	if got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+}
+
 func TestWhileLoop_WriteTo(t *testing.T) {
 	t.Parallel()
 	sw := SpaceWriter{}
@@ -2699,7 +2904,7 @@ func TestCharLiteral_WriteTo(t *testing.T) {
 	t.Parallel()
 	sw := SpaceWriter{}
 	cl := javast.CharLiteral{
-		Value: "\\n",
+		Value: "\n",
 	}
 	if _, err := cl.WriteTo(&sw); err != nil {
 		t.Error(err)
@@ -2803,8 +3008,10 @@ func TestInterface_WriteTo(t *testing.T) {
 		},
 		SimpleName:     "ExpressionNode",
 		TypeParameters: nil,
-		ExtendsClause: javast.Identifier{
-			Name: "Node",
+		ExtendsClause: []javast.Node{
+			javast.Identifier{
+				Name: "Node",
+			},
 		},
 		PermitsClause: nil,
 		Members: []javast.Node{
@@ -2831,7 +3038,7 @@ func TestInterface_WriteTo(t *testing.T) {
 		t.Error(err)
 	}
 	got := sw.String()
-	want := "public interface ExpressionNode extends Node { public void expressionNode ( ) }"
+	want := "public interface ExpressionNode extends Node { public void expressionNode ( ) ; }"
 	if got != want {
 		t.Errorf("sw.String() = %s, want %s", got, want)
 	}
@@ -2848,26 +3055,26 @@ func TestEnum_WriteTo(t *testing.T) {
 			Annotations: nil,
 		},
 		SimpleName: "DayOfWeek",
-		Members: []javast.Node{
-			javast.Identifier{
-				Name: "SUNDAY,",
+		Constants: []javast.EnumConstantNode{
+			javast.EnumConstant{
+				Name: "SUNDAY",
 			},
-			javast.Identifier{
-				Name: "MONDAY,",
+			javast.EnumConstant{
+				Name: "MONDAY",
 			},
-			javast.Identifier{
-				Name: "TUESDAY,",
+			javast.EnumConstant{
+				Name: "TUESDAY",
 			},
-			javast.Identifier{
-				Name: "WEDNESDAY,",
+			javast.EnumConstant{
+				Name: "WEDNESDAY",
 			},
-			javast.Identifier{
-				Name: "THURSDAY,",
+			javast.EnumConstant{
+				Name: "THURSDAY",
 			},
-			javast.Identifier{
-				Name: "FRIDAY,",
+			javast.EnumConstant{
+				Name: "FRIDAY",
 			},
-			javast.Identifier{
+			javast.EnumConstant{
 				Name: "SATURDAY",
 			},
 		},
@@ -2876,12 +3083,37 @@ func TestEnum_WriteTo(t *testing.T) {
 		t.Error(err)
 	}
 	got := sw.String()
-	want := "public enum DayOfWeek { SUNDAY, MONDAY, TUESDAY, WEDNESDAY, THURSDAY, FRIDAY, SATURDAY }"
+	want := "public enum DayOfWeek { SUNDAY , MONDAY , TUESDAY , WEDNESDAY , THURSDAY , FRIDAY , SATURDAY }"
 	if got != want {
 		t.Errorf("sw.String() = %s, want %s", got, want)
 	}
 }
 
+func TestEnum_WriteTo_ConstantMember(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	e := javast.Enum{
+		Modifiers:  javast.Modifiers{},
+		SimpleName: "Color",
+		Constants: []javast.EnumConstantNode{
+			javast.EnumConstant{
+				Name: "RED",
+			},
+		},
+		Members: []javast.Node{
+			javast.EnumConstant{
+				Name: "GREEN",
+			},
+		},
+	}
+	if _, err := e.WriteTo(&sw); err == nil {
+		t.Error("WriteTo() error = nil, want error")
+	}
+	if got := sw.String(); got != "" {
+		t.Errorf("sw.String() = %s, want empty", got)
+	}
+}
+
 func TestAnnotationType_WriteTo(t *testing.T) {
 	t.Parallel()
 	sw := SpaceWriter{}
@@ -2930,7 +3162,7 @@ func TestAnnotationType_WriteTo(t *testing.T) {
 		t.Error(err)
 	}
 	got := sw.String()
-	want := "public @interface Author { public void setAuthor ( String author ) }"
+	want := "public @interface Author { public void setAuthor ( String author ) ; }"
 	if got != want {
 		t.Errorf("sw.String() = %s, want %s", got, want)
 	}
@@ -3088,6 +3320,31 @@ func TestRecord_WriteTo(t *testing.T) {
 		},
 		SimpleName:     "Cat",
 		TypeParameters: nil,
+		Components: []javast.VariableNode{
+			javast.Variable{
+				Modifiers: javast.Modifiers{
+					Flags: nil,
+					Annotations: []javast.AnnotationNode{
+						javast.Annotation{
+							AnnotationType: javast.Identifier{
+								Name: "NonNull",
+							},
+						},
+					},
+				},
+				Name: "name",
+				Type: javast.Identifier{
+					Name: "String",
+				},
+			},
+			javast.Variable{
+				Modifiers: javast.Modifiers{},
+				Name:      "lives",
+				Type: javast.PrimitiveType{
+					PrimitiveTypeKind: javast.INT_TYPE_KIND,
+				},
+			},
+		},
 		ImplementsClause: []javast.Node{
 			javast.Identifier{
 				Name: "Animal",
@@ -3099,7 +3356,7 @@ func TestRecord_WriteTo(t *testing.T) {
 		t.Error(err)
 	}
 	got := sw.String()
-	want := "public record Cat implements Animal { }"
+	want := "public record Cat ( @ NonNull String name , int lives ) implements Animal { }"
 	if got != want {
 		t.Errorf("sw.String() = %s, want %s", got, want)
 	}
@@ -3174,3 +3431,139 @@ func TestYield_WriteTo(t *testing.T) {
 		t.Errorf("sw.String() = %s, want %s", got, want)
 	}
 }
+
+func TestClassLiteral_WriteTo(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	cl := javast.ClassLiteral{
+		Type: javast.ArrayType{
+			Type: javast.PrimitiveType{
+				PrimitiveTypeKind: javast.INT_TYPE_KIND,
+			},
+		},
+	}
+	if _, err := cl.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "int [] . class"
+	if got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+}
+
+func TestThis_WriteTo(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	ms := javast.MemberSelect{
+		Expression: javast.This{
+			Qualifier: javast.Identifier{
+				Name: "Outer",
+			},
+		},
+		Identifier: "count",
+	}
+	if _, err := ms.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "Outer . this . count"
+	if got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+}
+
+func TestSuper_WriteTo(t *testing.T) {
+	t.Parallel()
+	sw := SpaceWriter{}
+	mi := javast.MethodInvocation{
+		TypeArguments: []javast.Node{
+			javast.Identifier{
+				Name: "String",
+			},
+		},
+		MethodSelect: javast.Super{
+			Qualifier: javast.Identifier{
+				Name: "outer",
+			},
+		},
+		Arguments: []javast.ExpressionNode{
+			javast.Identifier{
+				Name: "value",
+			},
+		},
+	}
+	if _, err := mi.WriteTo(&sw); err != nil {
+		t.Error(err)
+	}
+	got := sw.String()
+	want := "outer . < String > super ( value )"
+	if got != want {
+		t.Errorf("sw.String() = %s, want %s", got, want)
+	}
+}
+
+func TestClass_WriteTo_Initializers(t *testing.T) {
+	t.Parallel()
+	initializers := func(statement string) []javast.Node {
+		call := javast.ExpressionStatement{
+			Expression: javast.MethodInvocation{
+				MethodSelect: javast.Identifier{
+					Name: statement,
+				},
+			},
+		}
+		return []javast.Node{
+			javast.Block{
+				Static:     true,
+				Statements: []javast.StatementNode{call},
+			},
+			javast.Block{
+				Statements: []javast.StatementNode{call},
+			},
+		}
+	}
+	tests := []struct {
+		node javast.Node
+		want string
+	}{
+		{
+			javast.Class{
+				Modifiers:  javast.Modifiers{},
+				SimpleName: "Cache",
+				Members:    initializers("load"),
+			},
+			"class Cache { static { load ( ) ; } { load ( ) ; } }",
+		},
+		{
+			javast.Enum{
+				Modifiers:  javast.Modifiers{},
+				SimpleName: "Color",
+				Constants: []javast.EnumConstantNode{
+					javast.EnumConstant{
+						Name: "RED",
+					},
+				},
+				Members: initializers("init"),
+			},
+			"enum Color { RED ; static { init ( ) ; } { init ( ) ; } }",
+		},
+		{
+			javast.Record{
+				Modifiers:  javast.Modifiers{},
+				SimpleName: "Point",
+				Members:    initializers("check")[:1],
+			},
+			"record Point ( ) { static { check ( ) ; } }",
+		},
+	}
+	for _, test := range tests {
+		sw := SpaceWriter{}
+		if _, err := test.node.WriteTo(&sw); err != nil {
+			t.Error(err)
+		}
+		if got := sw.String(); got != test.want {
+			t.Errorf("sw.String() = %s, want %s", got, test.want)
+		}
+	}
+}